@@ -0,0 +1,17 @@
+package constant
+
+const (
+	DEFAULT_PAGE      = 1
+	DEFAULT_PAGE_SIZE = 10
+	MAX_PAGE_SIZE     = 100
+)
+
+const (
+	PAGE_QUERY      = "page"
+	PAGE_SIZE_QUERY = "page_size"
+	SEARCH_QUERY    = "search"
+	USER_ID_QUERY   = "user_id"
+	ADMIN_ID_QUERY  = "admin_id"
+	FROM_QUERY      = "from"
+	TO_QUERY        = "to"
+)
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	apiKeyModel "github.com/TechwizsonORG/auth-service/api/model/apikey"
+	"github.com/TechwizsonORG/auth-service/api/util"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/apikey"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ApiKeyHandler struct {
+	apiKeyService apikey.ApiKeyInterface
+}
+
+func NewApiKeyHandler(apiKeyService apikey.ApiKeyInterface) *ApiKeyHandler {
+	return &ApiKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (a *ApiKeyHandler) ApiKeyRoutes(route *gin.RouterGroup) {
+	apiKeyRoute := route.Group("/api-keys", middleware.AuthorizationMiddleware(nil, nil), a.rejectApiKey)
+	apiKeyRoute.GET("", a.getApiKeys)
+	apiKeyRoute.POST("", middleware.SensitiveMiddleware(), a.createApiKey)
+	apiKeyRoute.DELETE("/:id", middleware.SensitiveMiddleware(), a.revokeApiKey)
+}
+
+// rejectApiKey keeps a leaked key from minting other keys, keys are managed with an access token only
+func (a *ApiKeyHandler) rejectApiKey(c *gin.Context) {
+	if strings.HasPrefix(c.GetHeader("Authorization"), "ApiKey ") {
+		c.JSON(403, model.NewApiResponse(403, "Api keys can't manage api keys", false, nil))
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+// GetApiKeys godoc
+//
+//	@Summary		List api keys
+//	@Description	Revoked keys are left out, expired ones are flagged
+//	@Tags			api-keys
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		403				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=[]apiKeyModel.ApiKeyResponse}
+//	@Router			/auth/api-keys [get]
+func (a *ApiKeyHandler) getApiKeys(c *gin.Context) {
+	userId, _ := util.GetUserId(c)
+	apiKeys, appErr := a.apiKeyService.GetApiKeys(userId)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(apiKeyModel.FromApiKeys(apiKeys)))
+}
+
+// CreateApiKey godoc
+//
+//	@Summary		Create api key
+//	@Description	The key is only returned once, it is sent as "Authorization: ApiKey <key>" and only carries the given scopes
+//	@Accept			json
+//	@Tags			api-keys
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"access token"
+//	@Param			apiKey			body		apiKeyModel.CreateApiKeyRequest		true	"api key model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		403				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		201				{object}	model.ApiResponse{data=apiKeyModel.CreateApiKeyResponse}
+//	@Router			/auth/api-keys [post]
+func (a *ApiKeyHandler) createApiKey(c *gin.Context) {
+	var createReq apiKeyModel.CreateApiKeyRequest
+	if bindErr := c.BindJSON(&createReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	key, apiKey, appErr := a.apiKeyService.CreateApiKey(userId, createReq.Name, createReq.Scopes, createReq.ExpiresAt)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(201, model.NewApiResponse(201, "Created", true, apiKeyModel.CreateApiKeyResponse{
+		ApiKeyResponse: apiKeyModel.FromApiKey(*apiKey),
+		Key:            key,
+	}))
+}
+
+// RevokeApiKey godoc
+//
+//	@Summary	Revoke api key
+//	@Tags		api-keys
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Param		id				path		string	true	"api key id"
+//	@Failure	400				{object}	model.ApiResponse
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	403				{object}	model.ApiResponse
+//	@Failure	404				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	204
+//	@Router		/auth/api-keys/{id} [delete]
+func (a *ApiKeyHandler) revokeApiKey(c *gin.Context) {
+	apiKeyId, parseErr := uuid.Parse(c.Param("id"))
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewAppError(400, "Couldn't parse api key id", "Couldn't parse api key id", nil)})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	if appErr := a.apiKeyService.RevokeApiKey(userId, apiKeyId); appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.Status(204)
+}
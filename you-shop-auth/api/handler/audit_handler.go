@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/api/constant"
+	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	auditModel "github.com/TechwizsonORG/auth-service/api/model/audit"
+	"github.com/TechwizsonORG/auth-service/api/util"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/audit"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type AuditHandler struct {
+	auditService audit.AuditInterface
+}
+
+func NewAuditHandler(auditService audit.AuditInterface) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+func (a *AuditHandler) AuditRoutes(route *gin.RouterGroup) {
+	auditRoute := route.Group("/audit", middleware.AuthorizationMiddleware([]string{"admin"}, nil))
+	auditRoute.GET("", a.getAudits)
+}
+
+// parseTimeQuery reads an RFC 3339 time from the query, a missing one is the zero time
+func parseTimeQuery(c *gin.Context, key string) (time.Time, bool) {
+	value := c.Query(key)
+	if value == "" {
+		return time.Time{}, true
+	}
+	parsed, parseErr := time.Parse(time.RFC3339, value)
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewAppError(400, "Invalid "+key, key+" must be an RFC 3339 time", nil)})
+		return time.Time{}, false
+	}
+	return parsed, true
+}
+
+// parseUuidQuery reads an id from the query, a missing one is the nil id
+func parseUuidQuery(c *gin.Context, key string) (uuid.UUID, bool) {
+	value := c.Query(key)
+	if value == "" {
+		return uuid.Nil, true
+	}
+	parsed, parseErr := uuid.Parse(value)
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewAppError(400, "Invalid "+key, key+" must be an uuid", nil)})
+		return uuid.Nil, false
+	}
+	return parsed, true
+}
+
+// GetAudits godoc
+//
+//	@Summary		List auth audit events
+//	@Description	Latest events first, every filter is optional
+//	@Tags			audit
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			user_id			query		string	false	"user id"
+//	@Param			from			query		string	false	"RFC 3339 time, inclusive"
+//	@Param			to				query		string	false	"RFC 3339 time, exclusive"
+//	@Param			page			query		int		false	"Page number"	default(1)
+//	@Param			page_size		query		int		false	"Page size"		default(10)
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=auditModel.AuditPageResponse}
+//	@Router			/auth/audit [get]
+func (a *AuditHandler) getAudits(c *gin.Context) {
+	ok, paginationErr := util.PaginationValidator(c)
+	if !ok {
+		c.Errors = append(c.Errors, &gin.Error{Err: paginationErr})
+		return
+	}
+	userId, ok := parseUuidQuery(c, constant.USER_ID_QUERY)
+	if !ok {
+		return
+	}
+	from, ok := parseTimeQuery(c, constant.FROM_QUERY)
+	if !ok {
+		return
+	}
+	to, ok := parseTimeQuery(c, constant.TO_QUERY)
+	if !ok {
+		return
+	}
+	page, pageSize := util.GetPaginationQuery(c)
+	audits, e := a.auditService.GetAudits(userId, from, to, page, pageSize)
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(auditModel.FromAuditPage(*audits)))
+}
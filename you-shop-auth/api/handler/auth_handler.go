@@ -19,7 +19,15 @@ func NewAuthHandler(authService service.AuthInterface) *AuthHandler {
 
 func (a *AuthHandler) AuthRoutes(router *gin.RouterGroup) {
 	router.POST("login", a.login)
+	router.POST("login/2fa", a.twoFactorLogin)
+	router.POST("login/code", a.requestOneTimeCode)
+	router.POST("login/code/verify", a.oneTimeCodeLogin)
 	router.POST("register", a.Register)
+	router.POST("logout", a.logout)
+	router.POST("verify-email", a.verifyEmail)
+	router.POST("verify-email/resend", a.resendVerificationEmail)
+	router.POST("forgot-password", a.forgotPassword)
+	router.POST("reset-password", a.resetPassword)
 }
 
 // Login godoc
@@ -30,6 +38,8 @@ func (a *AuthHandler) AuthRoutes(router *gin.RouterGroup) {
 //	@Produce	json
 //	@Param		login	body		authModel.LoginRequest	true	"login model"
 //	@Failure	401		{object}	model.ApiResponse
+//	@Failure	403		{object}	model.ApiResponse
+//	@Failure	429		{object}	model.ApiResponse
 //	@Failure	500		{object}	model.ApiResponse
 //	@Success	200		{object}	model.ApiResponse{data=authModel.LoginResponse}
 //	@Router		/auth/login [post]
@@ -39,12 +49,109 @@ func (a *AuthHandler) login(c *gin.Context) {
 		c.Errors = append(c.Errors, &gin.Error{Err: err})
 		return
 	}
-	user, accessToken, refreshToken, e := a.authService.Login(loginReq.Email, loginReq.Username, loginReq.Password)
+	device := loginReq.Device
+	if device == "" {
+		device = c.Request.UserAgent()
+	}
+	result, e := a.authService.Login(loginReq.Email, loginReq.Username, loginReq.Password, device, c.ClientIP())
 	if e != nil {
 		c.Errors = append(c.Errors, &gin.Error{Err: e})
 		return
 	}
-	c.JSON(200, model.SuccessResponse(authModel.From(*user, accessToken, refreshToken)))
+	c.JSON(200, model.SuccessResponse(authModel.From(*result)))
+}
+
+// TwoFactorLogin godoc
+//
+//	@Summary		Complete a login with a two factor code
+//	@Description	Exchanges the challenge token returned by login and a TOTP or recovery code for the tokens
+//	@Accept			json
+//	@Tags			auth
+//	@Produce		json
+//	@Param			login	body		authModel.TwoFactorLoginRequest	true	"two factor login model"
+//	@Failure		400		{object}	model.ApiResponse
+//	@Failure		401		{object}	model.ApiResponse
+//	@Failure		429		{object}	model.ApiResponse
+//	@Failure		500		{object}	model.ApiResponse
+//	@Success		200		{object}	model.ApiResponse{data=authModel.LoginResponse}
+//	@Router			/auth/login/2fa [post]
+func (a *AuthHandler) twoFactorLogin(c *gin.Context) {
+	var loginReq authModel.TwoFactorLoginRequest
+	if err := c.BindJSON(&loginReq); err != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err})
+		return
+	}
+	device := loginReq.Device
+	if device == "" {
+		device = c.Request.UserAgent()
+	}
+	result, e := a.authService.CompleteTwoFactorLogin(loginReq.ChallengeToken, loginReq.Code, device, c.ClientIP())
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(authModel.From(*result)))
+}
+
+// RequestOneTimeCode godoc
+//
+//	@Summary		Request a login code
+//	@Description	Sends a short lived numeric code by email or sms, answers the same whether the user exists or not
+//	@Accept			json
+//	@Tags			auth
+//	@Produce		json
+//	@Param			code	body		authModel.OneTimeCodeRequest	true	"one time code request model"
+//	@Failure		400		{object}	model.ApiResponse
+//	@Failure		500		{object}	model.ApiResponse
+//	@Success		202
+//	@Router			/auth/login/code [post]
+func (a *AuthHandler) requestOneTimeCode(c *gin.Context) {
+	var codeReq authModel.OneTimeCodeRequest
+	if err := c.BindJSON(&codeReq); err != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err})
+		return
+	}
+	channel := codeReq.Channel
+	if channel == "" {
+		channel = "email"
+	}
+	if e := a.authService.RequestOneTimeCode(codeReq.Email, codeReq.Username, channel); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(202)
+}
+
+// OneTimeCodeLogin godoc
+//
+//	@Summary		Login with a one time code
+//	@Description	Exchanges a code from /auth/login/code for the tokens, users with two factor authentication get a challenge token like on login
+//	@Accept			json
+//	@Tags			auth
+//	@Produce		json
+//	@Param			login	body		authModel.OneTimeCodeLoginRequest	true	"one time code login model"
+//	@Failure		401		{object}	model.ApiResponse
+//	@Failure		403		{object}	model.ApiResponse
+//	@Failure		429		{object}	model.ApiResponse
+//	@Failure		500		{object}	model.ApiResponse
+//	@Success		200		{object}	model.ApiResponse{data=authModel.LoginResponse}
+//	@Router			/auth/login/code/verify [post]
+func (a *AuthHandler) oneTimeCodeLogin(c *gin.Context) {
+	var loginReq authModel.OneTimeCodeLoginRequest
+	if err := c.BindJSON(&loginReq); err != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err})
+		return
+	}
+	device := loginReq.Device
+	if device == "" {
+		device = c.Request.UserAgent()
+	}
+	result, e := a.authService.LoginWithOneTimeCode(loginReq.Email, loginReq.Username, loginReq.Code, device, c.ClientIP())
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(authModel.From(*result)))
 }
 
 // Register godoc
@@ -73,3 +180,128 @@ func (a *AuthHandler) Register(c *gin.Context) {
 
 	c.Status(201)
 }
+
+// Logout godoc
+//
+//	@Summary	Logout
+//	@Accept		json
+//	@Tags		auth
+//	@Produce	json
+//	@Param		Authorization	header		string					true	"access token"
+//	@Param		logout			body		authModel.LogoutRequest	false	"logout model"
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	204
+//	@Router		/auth/logout [post]
+func (a *AuthHandler) logout(c *gin.Context) {
+	var logoutReq authModel.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.BindJSON(&logoutReq); err != nil {
+			c.Errors = append(c.Errors, &gin.Error{Err: err})
+			return
+		}
+	}
+	e := a.authService.Logout(c.GetHeader("Authorization"), logoutReq.RefreshToken)
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// VerifyEmail godoc
+//
+//	@Summary	Verify email
+//	@Accept		json
+//	@Tags		auth
+//	@Produce	json
+//	@Param		verify	body		authModel.VerifyEmailRequest	true	"verify email model"
+//	@Failure	400		{object}	model.ApiResponse
+//	@Failure	500		{object}	model.ApiResponse
+//	@Success	204
+//	@Router		/auth/verify-email [post]
+func (a *AuthHandler) verifyEmail(c *gin.Context) {
+	var verifyEmailReq authModel.VerifyEmailRequest
+	if err := c.BindJSON(&verifyEmailReq); err != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err})
+		return
+	}
+	if e := a.authService.VerifyEmail(verifyEmailReq.Token); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// ResendVerificationEmail godoc
+//
+//	@Summary		Resend verification email
+//	@Description	Answers the same whether the email is registered or not
+//	@Accept			json
+//	@Tags			auth
+//	@Produce		json
+//	@Param			resend	body		authModel.ResendVerificationEmailRequest	true	"resend verification email model"
+//	@Failure		500		{object}	model.ApiResponse
+//	@Success		202
+//	@Router			/auth/verify-email/resend [post]
+func (a *AuthHandler) resendVerificationEmail(c *gin.Context) {
+	var resendReq authModel.ResendVerificationEmailRequest
+	if err := c.BindJSON(&resendReq); err != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err})
+		return
+	}
+	if e := a.authService.ResendVerificationEmail(resendReq.Email); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(202)
+}
+
+// ForgotPassword godoc
+//
+//	@Summary		Forgot password
+//	@Description	Mails a password reset link, answers the same whether the email is registered or not
+//	@Accept			json
+//	@Tags			auth
+//	@Produce		json
+//	@Param			forgot	body		authModel.ForgotPasswordRequest	true	"forgot password model"
+//	@Failure		429		{object}	model.ApiResponse
+//	@Failure		500		{object}	model.ApiResponse
+//	@Success		202
+//	@Router			/auth/forgot-password [post]
+func (a *AuthHandler) forgotPassword(c *gin.Context) {
+	var forgotPasswordReq authModel.ForgotPasswordRequest
+	if err := c.BindJSON(&forgotPasswordReq); err != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err})
+		return
+	}
+	if e := a.authService.ForgotPassword(forgotPasswordReq.Email, c.ClientIP()); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(202)
+}
+
+// ResetPassword godoc
+//
+//	@Summary	Reset password
+//	@Accept		json
+//	@Tags		auth
+//	@Produce	json
+//	@Param		reset	body		authModel.ResetPasswordRequest	true	"reset password model"
+//	@Failure	400		{object}	model.ApiResponse
+//	@Failure	500		{object}	model.ApiResponse
+//	@Success	204
+//	@Router		/auth/reset-password [post]
+func (a *AuthHandler) resetPassword(c *gin.Context) {
+	var resetPasswordReq authModel.ResetPasswordRequest
+	if err := c.BindJSON(&resetPasswordReq); err != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err})
+		return
+	}
+	if e := a.authService.ResetPassword(resetPasswordReq.Token, resetPasswordReq.Password); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"github.com/TechwizsonORG/auth-service/api/constant"
+	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	impersonationModel "github.com/TechwizsonORG/auth-service/api/model/impersonation"
+	"github.com/TechwizsonORG/auth-service/api/util"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/audit"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ImpersonationHandler struct {
+	tokenService token.TokenInterface
+	auditService audit.AuditInterface
+}
+
+func NewImpersonationHandler(tokenService token.TokenInterface, auditService audit.AuditInterface) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		tokenService: tokenService,
+		auditService: auditService,
+	}
+}
+
+func (i *ImpersonationHandler) ImpersonationRoutes(route *gin.RouterGroup) {
+	impersonationRoute := route.Group("/impersonations", middleware.AuthorizationMiddleware([]string{"admin"}, nil))
+	impersonationRoute.GET("", i.getImpersonations)
+	impersonationRoute.POST("", i.startImpersonation)
+	impersonationRoute.DELETE("/:id", i.endImpersonation)
+}
+
+// GetImpersonations godoc
+//
+//	@Summary		List impersonations
+//	@Description	Latest first, every filter is optional
+//	@Tags			impersonation
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			admin_id		query		string	false	"admin id"
+//	@Param			user_id			query		string	false	"impersonated user id"
+//	@Param			page			query		int		false	"Page number"	default(1)
+//	@Param			page_size		query		int		false	"Page size"		default(10)
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=impersonationModel.ImpersonationPageResponse}
+//	@Router			/auth/impersonations [get]
+func (i *ImpersonationHandler) getImpersonations(c *gin.Context) {
+	ok, paginationErr := util.PaginationValidator(c)
+	if !ok {
+		c.Errors = append(c.Errors, &gin.Error{Err: paginationErr})
+		return
+	}
+	adminId, ok := parseUuidQuery(c, constant.ADMIN_ID_QUERY)
+	if !ok {
+		return
+	}
+	userId, ok := parseUuidQuery(c, constant.USER_ID_QUERY)
+	if !ok {
+		return
+	}
+	page, pageSize := util.GetPaginationQuery(c)
+	impersonations, e := i.auditService.GetImpersonations(adminId, userId, page, pageSize)
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(impersonationModel.FromImpersonationPage(*impersonations)))
+}
+
+// StartImpersonation godoc
+//
+//	@Summary		Impersonate a user
+//	@Description	Returns a short lived access token acting as the user, it can't be refreshed and sensitive endpoints reject it
+//	@Accept			json
+//	@Tags			impersonation
+//	@Produce		json
+//	@Param			Authorization	header		string												true	"access token"
+//	@Param			impersonation	body		impersonationModel.StartImpersonationRequest		true	"impersonation model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		201				{object}	model.ApiResponse{data=impersonationModel.StartImpersonationResponse}
+//	@Router			/auth/impersonations [post]
+func (i *ImpersonationHandler) startImpersonation(c *gin.Context) {
+	var startReq impersonationModel.StartImpersonationRequest
+	if bindErr := c.BindJSON(&startReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	adminId, _ := util.GetUserId(c)
+	accessToken, impersonation, appErr := i.tokenService.GenerateImpersonationToken(adminId, startReq.UserId, startReq.Reason, c.ClientIP())
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(201, model.NewApiResponse(201, "Created", true, impersonationModel.StartImpersonationResponse{
+		ImpersonationResponse: impersonationModel.FromImpersonation(*impersonation),
+		AccessToken:           accessToken,
+	}))
+}
+
+// EndImpersonation godoc
+//
+//	@Summary	End an impersonation
+//	@Tags		impersonation
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Param		id				path		string	true	"impersonation id"
+//	@Failure	400				{object}	model.ApiResponse
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	404				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	204
+//	@Router		/auth/impersonations/{id} [delete]
+func (i *ImpersonationHandler) endImpersonation(c *gin.Context) {
+	impersonationId, parseErr := uuid.Parse(c.Param("id"))
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewAppError(400, "Couldn't parse impersonation id", "Couldn't parse impersonation id", nil)})
+		return
+	}
+	adminId, _ := util.GetUserId(c)
+	if appErr := i.tokenService.EndImpersonation(adminId, impersonationId); appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.Status(204)
+}
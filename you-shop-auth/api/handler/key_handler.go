@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"github.com/TechwizsonORG/auth-service/usecase/key"
+	"github.com/gin-gonic/gin"
+)
+
+type KeyHandler struct {
+	service key.KeyInterface
+}
+
+func NewKeyHandler(service key.KeyInterface) *KeyHandler {
+	return &KeyHandler{
+		service: service,
+	}
+}
+
+func (k *KeyHandler) KeyRoutes(route *gin.RouterGroup) {
+	route.GET("/.well-known/jwks.json", k.getJsonWebKeySet)
+}
+
+// GetJsonWebKeySet godoc
+//
+//	@Summary	Public keys used to verify tokens
+//	@Tags		token
+//	@Produce	json
+//	@Failure	500	{object}	model.ApiResponse
+//	@Success	200	{object}	model.JsonWebKeySet
+//	@Router		/auth/.well-known/jwks.json [get]
+func (k *KeyHandler) getJsonWebKeySet(c *gin.Context) {
+	jwks, getErr := k.service.GetJsonWebKeySet()
+	if getErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: getErr})
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(200, jwks)
+}
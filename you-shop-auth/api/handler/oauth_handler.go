@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	oauthModel "github.com/TechwizsonORG/auth-service/api/model/oauth"
+	"github.com/TechwizsonORG/auth-service/api/util"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/oauth"
+	"github.com/gin-gonic/gin"
+)
+
+type OAuthHandler struct {
+	service oauth.OAuthInterface
+}
+
+func NewOAuthHandler(service oauth.OAuthInterface) *OAuthHandler {
+	return &OAuthHandler{
+		service: service,
+	}
+}
+
+func (o *OAuthHandler) OAuthRoutes(route *gin.RouterGroup) {
+	route.GET("/authorize", middleware.AuthorizationMiddleware(nil, nil), middleware.SensitiveMiddleware(), o.authorize)
+	route.POST("/authorize", middleware.AuthorizationMiddleware(nil, nil), middleware.SensitiveMiddleware(), o.consent)
+	route.POST("/token", o.token)
+	route.GET("/.well-known/openid-configuration", o.discovery)
+	route.GET("/userinfo", o.userInfo)
+	route.POST("/userinfo", o.userInfo)
+	route.POST("/introspect", o.introspect)
+}
+
+// Authorize godoc
+//
+//	@Summary		Start an authorization code request
+//	@Description	Returns the consent information, or the redirect uri with the code when the user already consented
+//	@Tags			oauth
+//	@Produce		json
+//	@Param			Authorization			header		string	true	"access token"
+//	@Param			response_type			query		string	true	"must be code"
+//	@Param			client_id				query		string	true	"client id"
+//	@Param			redirect_uri			query		string	false	"redirect uri"
+//	@Param			scope					query		string	false	"space separated scopes"
+//	@Param			state					query		string	false	"state"
+//	@Param			code_challenge			query		string	true	"PKCE code challenge"
+//	@Param			code_challenge_method	query		string	true	"must be S256"
+//	@Failure		400						{object}	model.ApiResponse
+//	@Failure		401						{object}	model.ApiResponse
+//	@Failure		403						{object}	model.ApiResponse
+//	@Success		200						{object}	model.ApiResponse{data=oauthModel.AuthorizeResponse}
+//	@Router			/auth/authorize [get]
+func (o *OAuthHandler) authorize(c *gin.Context) {
+	var authorizeReq oauthModel.AuthorizeRequest
+	if bindErr := c.ShouldBindQuery(&authorizeReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	result, appErr := o.service.Authorize(userId, authorizeReq.ToModel())
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(oauthModel.FromAuthorizationResult(*result)))
+}
+
+// Consent godoc
+//
+//	@Summary	Approve or deny an authorization code request
+//	@Accept		json
+//	@Tags		oauth
+//	@Produce	json
+//	@Param		Authorization	header		string						true	"access token"
+//	@Param		consent			body		oauthModel.ConsentRequest	true	"consent model"
+//	@Failure	400				{object}	model.ApiResponse
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	403				{object}	model.ApiResponse
+//	@Success	200				{object}	model.ApiResponse{data=oauthModel.AuthorizeResponse}
+//	@Router		/auth/authorize [post]
+func (o *OAuthHandler) consent(c *gin.Context) {
+	var consentReq oauthModel.ConsentRequest
+	if bindErr := c.BindJSON(&consentReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	result, appErr := o.service.Consent(userId, consentReq.AuthorizeRequest.ToModel(), consentReq.Approved)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(oauthModel.FromAuthorizationResult(*result)))
+}
+
+// Token godoc
+//
+//	@Summary		OAuth2 token endpoint
+//	@Description	Supports authorization_code (with PKCE) and refresh_token grants. Clients may authenticate with HTTP Basic.
+//	@Accept			x-www-form-urlencoded
+//	@Tags			oauth
+//	@Produce		json
+//	@Param			grant_type		formData	string	true	"grant type"
+//	@Param			code			formData	string	false	"authorization code"
+//	@Param			redirect_uri	formData	string	false	"redirect uri used in the authorization request"
+//	@Param			client_id		formData	string	false	"client id"
+//	@Param			client_secret	formData	string	false	"client secret"
+//	@Param			code_verifier	formData	string	false	"PKCE code verifier"
+//	@Param			refresh_token	formData	string	false	"refresh token"
+//	@Param			scope			formData	string	false	"space separated scopes requested with the client credentials grant"
+//	@Failure		400				{object}	oauthModel.ErrorResponse
+//	@Failure		401				{object}	oauthModel.ErrorResponse
+//	@Success		200				{object}	oauthModel.TokenResponse
+//	@Router			/auth/token [post]
+func (o *OAuthHandler) token(c *gin.Context) {
+	var tokenReq oauthModel.TokenRequest
+	if bindErr := c.ShouldBind(&tokenReq); bindErr != nil {
+		o.writeError(c, err.NewOAuthError(http.StatusBadRequest, "invalid_request", bindErr.Error()))
+		return
+	}
+	if clientId, clientSecret, ok := c.Request.BasicAuth(); ok {
+		tokenReq.ClientId = clientId
+		tokenReq.ClientSecret = clientSecret
+	}
+	request := tokenReq.ToModel()
+	request.Device = c.Request.UserAgent()
+	request.Ip = c.ClientIP()
+
+	result, appErr := o.service.Token(request)
+	if appErr != nil {
+		o.writeError(c, appErr)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(200, oauthModel.FromTokenResult(*result))
+}
+
+// Discovery godoc
+//
+//	@Summary	OpenID Provider configuration
+//	@Tags		oauth
+//	@Produce	json
+//	@Success	200	{object}	oauthModel.DiscoveryResponse
+//	@Router		/auth/.well-known/openid-configuration [get]
+func (o *OAuthHandler) discovery(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=3600")
+	c.JSON(200, oauthModel.FromDiscovery(o.service.GetDiscovery()))
+}
+
+// UserInfo godoc
+//
+//	@Summary	OpenID Connect userinfo endpoint
+//	@Tags		oauth
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Failure	401				{object}	oauthModel.ErrorResponse
+//	@Success	200				{object}	oauthModel.UserInfoResponse
+//	@Router		/auth/userinfo [get]
+func (o *OAuthHandler) userInfo(c *gin.Context) {
+	userInfo, appErr := o.service.UserInfo(c.GetHeader("Authorization"))
+	if appErr != nil {
+		c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+		o.writeError(c, appErr)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(200, oauthModel.FromUserInfo(*userInfo))
+}
+
+// Introspect godoc
+//
+//	@Summary		RFC 7662 token introspection
+//	@Description	Clients must be confidential and may authenticate with HTTP Basic. Invalid tokens are answered with active false.
+//	@Accept			x-www-form-urlencoded
+//	@Tags			oauth
+//	@Produce		json
+//	@Param			token			formData	string	true	"access or refresh token"
+//	@Param			token_type_hint	formData	string	false	"access_token or refresh_token"
+//	@Param			client_id		formData	string	false	"client id"
+//	@Param			client_secret	formData	string	false	"client secret"
+//	@Failure		400				{object}	oauthModel.ErrorResponse
+//	@Failure		401				{object}	oauthModel.ErrorResponse
+//	@Success		200				{object}	oauthModel.IntrospectionResponse
+//	@Router			/auth/introspect [post]
+func (o *OAuthHandler) introspect(c *gin.Context) {
+	var introspectionReq oauthModel.IntrospectionRequest
+	if bindErr := c.ShouldBind(&introspectionReq); bindErr != nil {
+		o.writeError(c, err.NewOAuthError(http.StatusBadRequest, "invalid_request", bindErr.Error()))
+		return
+	}
+	if introspectionReq.Token == "" {
+		o.writeError(c, err.NewOAuthError(http.StatusBadRequest, "invalid_request", "Token is required"))
+		return
+	}
+	if clientId, clientSecret, ok := c.Request.BasicAuth(); ok {
+		introspectionReq.ClientId = clientId
+		introspectionReq.ClientSecret = clientSecret
+	}
+	introspection, appErr := o.service.Introspect(introspectionReq.ClientId, introspectionReq.ClientSecret, introspectionReq.Token, introspectionReq.TokenTypeHint)
+	if appErr != nil {
+		o.writeError(c, appErr)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(200, oauthModel.FromTokenIntrospection(*introspection))
+}
+
+// writeError answers with the RFC 6749 error format instead of the usual api response
+func (o *OAuthHandler) writeError(c *gin.Context, appErr *err.AppError) {
+	c.Header("Cache-Control", "no-store")
+	if appErr.Code >= http.StatusInternalServerError {
+		c.JSON(appErr.Code, oauthModel.ErrorResponse{Error: "server_error"})
+		return
+	}
+	c.JSON(appErr.Code, oauthModel.ErrorResponse{
+		Error:            appErr.Title,
+		ErrorDescription: appErr.Message,
+	})
+}
@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	permissionModel "github.com/TechwizsonORG/auth-service/api/model/permission"
+	"github.com/TechwizsonORG/auth-service/usecase/permission"
+	"github.com/gin-gonic/gin"
+)
+
+type PermissionHandler struct {
+	permissionService permission.PermissionInterface
+}
+
+func NewPermissionHandler(permissionService permission.PermissionInterface) *PermissionHandler {
+	return &PermissionHandler{
+		permissionService: permissionService,
+	}
+}
+
+func (p *PermissionHandler) PermissionRoutes(route *gin.RouterGroup) {
+	roleRoute := route.Group("/roles", middleware.AuthorizationMiddleware([]string{"admin"}, nil))
+	roleRoute.GET("", p.getRoles)
+	roleRoute.GET("/:role", p.getRole)
+	roleRoute.POST("", p.addRole)
+	roleRoute.PUT("/:role", p.updateRole)
+	roleRoute.DELETE("/:role", p.deleteRole)
+	roleRoute.PUT("/:role/scopes/:scope", p.addRoleScope)
+	roleRoute.DELETE("/:role/scopes/:scope", p.removeRoleScope)
+
+	scopeRoute := route.Group("/scopes", middleware.AuthorizationMiddleware([]string{"admin"}, nil))
+	scopeRoute.GET("", p.getScopes)
+	scopeRoute.POST("", p.addScope)
+	scopeRoute.PUT("/:scope", p.updateScope)
+	scopeRoute.DELETE("/:scope", p.deleteScope)
+}
+
+// GetRoles godoc
+//
+//	@Summary	List roles with their parent and scopes
+//	@Tags		permission
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	200				{object}	model.ApiResponse{data=[]permissionModel.RoleResponse}
+//	@Router		/auth/roles [get]
+func (p *PermissionHandler) getRoles(c *gin.Context) {
+	roles, e := p.permissionService.GetRoles()
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	result := []permissionModel.RoleResponse{}
+	for _, role := range roles {
+		result = append(result, permissionModel.FromRoleDetail(role))
+	}
+	c.JSON(200, model.SuccessResponse(result))
+}
+
+// GetRole godoc
+//
+//	@Summary	Get role
+//	@Tags		permission
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Param		role			path		string	true	"role name"
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	404				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	200				{object}	model.ApiResponse{data=permissionModel.RoleResponse}
+//	@Router		/auth/roles/{role} [get]
+func (p *PermissionHandler) getRole(c *gin.Context) {
+	role, e := p.permissionService.GetRole(c.Param("role"))
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(permissionModel.FromRoleDetail(*role)))
+}
+
+// AddRole godoc
+//
+//	@Summary		Add role
+//	@Description	The role inherits every scope of its parent
+//	@Accept			json
+//	@Tags			permission
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"access token"
+//	@Param			role			body		permissionModel.AddRoleRequest	true	"role model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		201				{object}	model.ApiResponse{data=permissionModel.RoleResponse}
+//	@Router			/auth/roles [post]
+func (p *PermissionHandler) addRole(c *gin.Context) {
+	var addRoleReq permissionModel.AddRoleRequest
+	if bindErr := c.BindJSON(&addRoleReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	if _, e := p.permissionService.AddRole(addRoleReq.Name, addRoleReq.Description, addRoleReq.Parent); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	role, e := p.permissionService.GetRole(addRoleReq.Name)
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(201, model.NewApiResponse(201, "Created", true, permissionModel.FromRoleDetail(*role)))
+}
+
+// UpdateRole godoc
+//
+//	@Summary		Update role
+//	@Description	Changes the description and the inherited role, a role can't end up inheriting itself
+//	@Accept			json
+//	@Tags			permission
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"access token"
+//	@Param			role			path		string								true	"role name"
+//	@Param			update			body		permissionModel.UpdateRoleRequest	true	"role model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=permissionModel.RoleResponse}
+//	@Router			/auth/roles/{role} [put]
+func (p *PermissionHandler) updateRole(c *gin.Context) {
+	var updateRoleReq permissionModel.UpdateRoleRequest
+	if bindErr := c.BindJSON(&updateRoleReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	if _, e := p.permissionService.UpdateRole(c.Param("role"), updateRoleReq.Description, updateRoleReq.Parent); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	role, e := p.permissionService.GetRole(c.Param("role"))
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(permissionModel.FromRoleDetail(*role)))
+}
+
+// DeleteRole godoc
+//
+//	@Summary		Delete role
+//	@Description	Users lose the role, rejected while another role inherits it
+//	@Tags			permission
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			role			path		string	true	"role name"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/roles/{role} [delete]
+func (p *PermissionHandler) deleteRole(c *gin.Context) {
+	if e := p.permissionService.DeleteRole(c.Param("role")); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// AddRoleScope godoc
+//
+//	@Summary	Grant scope to role
+//	@Tags		permission
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Param		role			path		string	true	"role name"
+//	@Param		scope			path		string	true	"scope name"
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	404				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	204
+//	@Router		/auth/roles/{role}/scopes/{scope} [put]
+func (p *PermissionHandler) addRoleScope(c *gin.Context) {
+	if e := p.permissionService.AddRoleScope(c.Param("role"), c.Param("scope")); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// RemoveRoleScope godoc
+//
+//	@Summary	Revoke scope from role
+//	@Tags		permission
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Param		role			path		string	true	"role name"
+//	@Param		scope			path		string	true	"scope name"
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	404				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	204
+//	@Router		/auth/roles/{role}/scopes/{scope} [delete]
+func (p *PermissionHandler) removeRoleScope(c *gin.Context) {
+	if e := p.permissionService.RemoveRoleScope(c.Param("role"), c.Param("scope")); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// GetScopes godoc
+//
+//	@Summary	List scopes
+//	@Tags		permission
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	200				{object}	model.ApiResponse{data=[]permissionModel.ScopeResponse}
+//	@Router		/auth/scopes [get]
+func (p *PermissionHandler) getScopes(c *gin.Context) {
+	scopes, e := p.permissionService.GetScopes()
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	result := []permissionModel.ScopeResponse{}
+	for _, scope := range scopes {
+		result = append(result, permissionModel.FromScope(scope))
+	}
+	c.JSON(200, model.SuccessResponse(result))
+}
+
+// AddScope godoc
+//
+//	@Summary	Add scope
+//	@Accept		json
+//	@Tags		permission
+//	@Produce	json
+//	@Param		Authorization	header		string							true	"access token"
+//	@Param		scope			body		permissionModel.AddScopeRequest	true	"scope model"
+//	@Failure	400				{object}	model.ApiResponse
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	201				{object}	model.ApiResponse{data=permissionModel.ScopeResponse}
+//	@Router		/auth/scopes [post]
+func (p *PermissionHandler) addScope(c *gin.Context) {
+	var addScopeReq permissionModel.AddScopeRequest
+	if bindErr := c.BindJSON(&addScopeReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	scope, e := p.permissionService.AddScope(addScopeReq.Name, addScopeReq.Description)
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(201, model.NewApiResponse(201, "Created", true, permissionModel.FromScope(*scope)))
+}
+
+// UpdateScope godoc
+//
+//	@Summary	Update scope
+//	@Accept		json
+//	@Tags		permission
+//	@Produce	json
+//	@Param		Authorization	header		string								true	"access token"
+//	@Param		scope			path		string								true	"scope name"
+//	@Param		update			body		permissionModel.UpdateScopeRequest	true	"scope model"
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	404				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	200				{object}	model.ApiResponse{data=permissionModel.ScopeResponse}
+//	@Router		/auth/scopes/{scope} [put]
+func (p *PermissionHandler) updateScope(c *gin.Context) {
+	var updateScopeReq permissionModel.UpdateScopeRequest
+	if bindErr := c.BindJSON(&updateScopeReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	scope, e := p.permissionService.UpdateScope(c.Param("scope"), updateScopeReq.Description)
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(permissionModel.FromScope(*scope)))
+}
+
+// DeleteScope godoc
+//
+//	@Summary		Delete scope
+//	@Description	Users and roles lose the scope
+//	@Tags			permission
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			scope			path		string	true	"scope name"
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/scopes/{scope} [delete]
+func (p *PermissionHandler) deleteScope(c *gin.Context) {
+	if e := p.permissionService.DeleteScope(c.Param("scope")); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
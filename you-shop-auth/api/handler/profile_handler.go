@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	profileModel "github.com/TechwizsonORG/auth-service/api/model/profile"
+	"github.com/TechwizsonORG/auth-service/api/util"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/profile"
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileHandler struct {
+	profileService profile.ProfileInterface
+}
+
+func NewProfileHandler(profileService profile.ProfileInterface) *ProfileHandler {
+	return &ProfileHandler{
+		profileService: profileService,
+	}
+}
+
+func (p *ProfileHandler) ProfileRoutes(route *gin.RouterGroup) {
+	profileRoute := route.Group("/me", middleware.AuthorizationMiddleware(nil, nil))
+	profileRoute.GET("", p.getProfile)
+	profileRoute.PUT("", middleware.SensitiveMiddleware(), p.updateProfile)
+	profileRoute.DELETE("", middleware.SensitiveMiddleware(), p.deleteAccount)
+	profileRoute.POST("/password", middleware.SensitiveMiddleware(), p.changePassword)
+	profileRoute.POST("/email", middleware.SensitiveMiddleware(), p.changeEmail)
+	profileRoute.POST("/avatar", middleware.SensitiveMiddleware(), p.updateAvatar)
+}
+
+// GetProfile godoc
+//
+//	@Summary	Get profile
+//	@Tags		profile
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	200				{object}	model.ApiResponse{data=profileModel.ProfileResponse}
+//	@Router		/auth/me [get]
+func (p *ProfileHandler) getProfile(c *gin.Context) {
+	userId, _ := util.GetUserId(c)
+	user, appErr := p.profileService.GetProfile(userId)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(profileModel.FromUser(*user)))
+}
+
+// UpdateProfile godoc
+//
+//	@Summary	Update profile
+//	@Accept		json
+//	@Tags		profile
+//	@Produce	json
+//	@Param		Authorization	header		string								true	"access token"
+//	@Param		profile			body		profileModel.UpdateProfileRequest	true	"profile model"
+//	@Failure	400				{object}	model.ApiResponse
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	200				{object}	model.ApiResponse{data=profileModel.ProfileResponse}
+//	@Router		/auth/me [put]
+func (p *ProfileHandler) updateProfile(c *gin.Context) {
+	var updateReq profileModel.UpdateProfileRequest
+	if bindErr := c.BindJSON(&updateReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	user, appErr := p.profileService.UpdateProfile(userId, updateReq.Username, updateReq.PhoneNumber)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(profileModel.FromUser(*user)))
+}
+
+// DeleteAccount godoc
+//
+//	@Summary		Delete account
+//	@Description	Anonymizes the account and ends all sessions, orders and payments are pseudonymized asynchronously
+//	@Accept			json
+//	@Tags			profile
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"access token"
+//	@Param			account			body		profileModel.DeleteAccountRequest	true	"delete account model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		429				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		202
+//	@Router			/auth/me [delete]
+func (p *ProfileHandler) deleteAccount(c *gin.Context) {
+	var deleteReq profileModel.DeleteAccountRequest
+	if bindErr := c.BindJSON(&deleteReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	if appErr := p.profileService.DeleteAccount(userId, deleteReq.CurrentPassword, c.ClientIP()); appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.Status(202)
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change password
+//	@Description	All sessions and api keys of the user are ended, the user has to log in again
+//	@Accept			json
+//	@Tags			profile
+//	@Produce		json
+//	@Param			Authorization	header		string								true	"access token"
+//	@Param			password		body		profileModel.ChangePasswordRequest	true	"password model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		429				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/me/password [post]
+func (p *ProfileHandler) changePassword(c *gin.Context) {
+	var passwordReq profileModel.ChangePasswordRequest
+	if bindErr := c.BindJSON(&passwordReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	if appErr := p.profileService.ChangePassword(userId, passwordReq.CurrentPassword, passwordReq.NewPassword, c.ClientIP()); appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.Status(204)
+}
+
+// ChangeEmail godoc
+//
+//	@Summary		Change email
+//	@Description	The new email is unverified until the link mailed to it is opened
+//	@Accept			json
+//	@Tags			profile
+//	@Produce		json
+//	@Param			Authorization	header		string							true	"access token"
+//	@Param			email			body		profileModel.ChangeEmailRequest	true	"email model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		429				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		202
+//	@Router			/auth/me/email [post]
+func (p *ProfileHandler) changeEmail(c *gin.Context) {
+	var emailReq profileModel.ChangeEmailRequest
+	if bindErr := c.BindJSON(&emailReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	if appErr := p.profileService.ChangeEmail(userId, emailReq.CurrentPassword, emailReq.Email, c.ClientIP()); appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.Status(202)
+}
+
+// UpdateAvatar godoc
+//
+//	@Summary		Update avatar
+//	@Description	The image is stored in the image service
+//	@Accept			multipart/form-data
+//	@Tags			profile
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			avatar_file		formData	file	true	"Avatar File"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=profileModel.AvatarResponse}
+//	@Router			/auth/me/avatar [post]
+func (p *ProfileHandler) updateAvatar(c *gin.Context) {
+	file, header, parseErr := c.Request.FormFile("avatar_file")
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProfileError("Form didn't include avatar_file field")})
+		return
+	}
+	defer file.Close()
+	userId, _ := util.GetUserId(c)
+	avatarUrl, appErr := p.profileService.UpdateAvatar(userId, header.Filename, file)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(profileModel.AvatarResponse{AvatarUrl: avatarUrl}))
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	sessionModel "github.com/TechwizsonORG/auth-service/api/model/session"
+	"github.com/TechwizsonORG/auth-service/api/util"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/session"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type SessionHandler struct {
+	sessionService session.SessionInterface
+	tokenService   token.TokenInterface
+}
+
+func NewSessionHandler(sessionService session.SessionInterface, tokenService token.TokenInterface) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+		tokenService:   tokenService,
+	}
+}
+
+func (s *SessionHandler) SessionRoutes(route *gin.RouterGroup) {
+	sessionRoute := route.Group("/sessions", middleware.AuthorizationMiddleware(nil, nil))
+	sessionRoute.GET("", s.getSessions)
+	sessionRoute.DELETE("", middleware.SensitiveMiddleware(), s.revokeSessions)
+	sessionRoute.DELETE("/:id", middleware.SensitiveMiddleware(), s.revokeSession)
+}
+
+// GetSessions godoc
+//
+//	@Summary		List sessions
+//	@Description	Lists the devices the user is logged in on, the session of the access token is marked as current
+//	@Tags			sessions
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=[]sessionModel.SessionResponse}
+//	@Router			/auth/sessions [get]
+func (s *SessionHandler) getSessions(c *gin.Context) {
+	userId, _ := util.GetUserId(c)
+	sessions, appErr := s.sessionService.GetSessions(userId)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	currentSessionId := s.tokenService.IntrospectToken(c.GetHeader("Authorization"), token.AccessToken).SessionId
+	c.JSON(200, model.SuccessResponse(sessionModel.FromSessions(sessions, currentSessionId)))
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke session
+//	@Description	Logs the user out of the device, the access and refresh tokens of the session stop working
+//	@Tags			sessions
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			id				path		string	true	"session id"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/sessions/{id} [delete]
+func (s *SessionHandler) revokeSession(c *gin.Context) {
+	sessionId, parseErr := uuid.Parse(c.Param("id"))
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewAppError(400, "Couldn't parse session id", "Couldn't parse session id", nil)})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	if appErr := s.sessionService.RevokeSession(userId, sessionId); appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.Status(204)
+}
+
+// RevokeSessions godoc
+//
+//	@Summary		Revoke all sessions
+//	@Description	Logs the user out of every device, the current one included, and revokes their api keys
+//	@Tags			sessions
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/sessions [delete]
+func (s *SessionHandler) revokeSessions(c *gin.Context) {
+	userId, _ := util.GetUserId(c)
+	if appErr := s.sessionService.RevokeSessions(userId); appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.Status(204)
+}
@@ -4,8 +4,10 @@ import (
 	"github.com/TechwizsonORG/auth-service/api/model"
 	requestModel "github.com/TechwizsonORG/auth-service/api/model/token"
 	userResponse "github.com/TechwizsonORG/auth-service/api/model/user"
+	"github.com/TechwizsonORG/auth-service/err"
 	"github.com/TechwizsonORG/auth-service/usecase/token"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type TokenHandler struct {
@@ -21,6 +23,8 @@ func NewTokenHandler(service token.TokenInterface) *TokenHandler {
 func (t *TokenHandler) TokenRoutes(route *gin.RouterGroup) {
 	tokenRoute := route.Group("/token")
 	tokenRoute.POST("/validate", t.validateToken)
+	tokenRoute.POST("/refresh", t.refreshToken)
+	tokenRoute.POST("/revoke", t.revokeToken)
 }
 
 // ValidateToken godoc
@@ -41,11 +45,80 @@ func (t *TokenHandler) validateToken(c *gin.Context) {
 		return
 	}
 
-	user, role, scope, validationError := t.service.ValidateTokenWithResponse(validateTokenRequest.Token, validateTokenRequest.Type)
+	user, role, scope, actorId, validationError := t.service.ValidateTokenWithResponse(validateTokenRequest.Token, validateTokenRequest.Type)
 	if validationError != nil {
 		c.Errors = append(c.Errors, &gin.Error{Err: validationError})
 		return
 	}
 
-	c.JSON(200, model.SuccessResponse(userResponse.From(*user, role, scope)))
+	response := userResponse.From(*user, role, scope)
+	if actorId != uuid.Nil {
+		response.ActorId = &actorId
+	}
+	c.JSON(200, model.SuccessResponse(response))
+}
+
+// RefreshToken godoc
+//
+//	@Summary	Exchange refresh token
+//	@Accept		json
+//	@Tags		token
+//	@Produce	json
+//	@Param		refresh	body		requestModel.RefreshTokenRequest	true	"refresh token model"
+//	@Failure	401		{object}	model.ApiResponse
+//	@Failure	500		{object}	model.ApiResponse
+//	@Success	200		{object}	model.ApiResponse{data=requestModel.RefreshTokenResponse}
+//	@Router		/auth/token/refresh [post]
+func (t *TokenHandler) refreshToken(c *gin.Context) {
+	var refreshTokenRequest requestModel.RefreshTokenRequest
+	if err := c.BindJSON(&refreshTokenRequest); err != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err})
+		return
+	}
+
+	device := refreshTokenRequest.Device
+	if device == "" {
+		device = c.Request.UserAgent()
+	}
+	accessToken, refreshToken, exchangeErr := t.service.ExchangeRefreshToken(refreshTokenRequest.RefreshToken, "", device, c.ClientIP())
+	if exchangeErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: exchangeErr})
+		return
+	}
+
+	c.JSON(200, model.SuccessResponse(requestModel.RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}))
+}
+
+// RevokeToken godoc
+//
+//	@Summary	Revoke token
+//	@Accept		json
+//	@Tags		token
+//	@Produce	json
+//	@Param		revoke	body		requestModel.RevokeTokenRequest	true	"revoke token model"
+//	@Failure	400		{object}	model.ApiResponse
+//	@Failure	401		{object}	model.ApiResponse
+//	@Failure	500		{object}	model.ApiResponse
+//	@Success	204
+//	@Router		/auth/token/revoke [post]
+func (t *TokenHandler) revokeToken(c *gin.Context) {
+	var revokeTokenRequest requestModel.RevokeTokenRequest
+	if err := c.BindJSON(&revokeTokenRequest); err != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err})
+		return
+	}
+
+	tokenType, ok := revokeTokenRequest.TokenType()
+	if !ok {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewAppError(400, "Invalid token type", "type must be access or refresh", nil)})
+		return
+	}
+	if revokeErr := t.service.RevokeToken(revokeTokenRequest.Token, tokenType); revokeErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: revokeErr})
+		return
+	}
+	c.Status(204)
 }
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	twoFactorModel "github.com/TechwizsonORG/auth-service/api/model/twofactor"
+	"github.com/TechwizsonORG/auth-service/api/util"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/TechwizsonORG/auth-service/usecase/twofactor"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService twofactor.TwoFactorInterface
+	tokenService     token.TokenInterface
+}
+
+func NewTwoFactorHandler(twoFactorService twofactor.TwoFactorInterface, tokenService token.TokenInterface) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		twoFactorService: twoFactorService,
+		tokenService:     tokenService,
+	}
+}
+
+func (t *TwoFactorHandler) TwoFactorRoutes(route *gin.RouterGroup) {
+	twoFactorRoute := route.Group("/2fa")
+	twoFactorRoute.POST("/enroll", middleware.SensitiveMiddleware(), t.enroll)
+	twoFactorRoute.POST("/enable", middleware.SensitiveMiddleware(), t.enable)
+	twoFactorRoute.POST("/disable", middleware.AuthorizationMiddleware(nil, nil), middleware.SensitiveMiddleware(), t.disable)
+	twoFactorRoute.POST("/recovery-codes", middleware.AuthorizationMiddleware(nil, nil), middleware.SensitiveMiddleware(), t.regenerateRecoveryCodes)
+}
+
+// getUserId resolves the user from the access token, or from the enrollment challenge of the login
+// so users who must enroll can do it before their first login. Challenges of the two factor login step are rejected
+func (t *TwoFactorHandler) getUserId(c *gin.Context, challengeToken string) (userId uuid.UUID, isChallenge bool, appErr *err.AppError) {
+	if userId, getErr := util.GetUserId(c); getErr == nil {
+		return userId, false, nil
+	}
+	if challengeToken == "" {
+		return uuid.Nil, false, err.NewAppError(401, "Unauthorized", "Unauthorized", nil)
+	}
+	userId, appErr = t.tokenService.ValidateChallengeToken(challengeToken, token.TwoFactorEnrollmentToken)
+	return userId, true, appErr
+}
+
+// Enroll godoc
+//
+//	@Summary		Enroll two factor authentication
+//	@Description	Creates a pending TOTP secret, authenticate with an access token or the challenge token of the login
+//	@Accept			json
+//	@Tags			2fa
+//	@Produce		json
+//	@Param			Authorization	header		string							false	"access token"
+//	@Param			enroll			body		twoFactorModel.EnrollRequest	false	"enroll model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=twoFactorModel.EnrollResponse}
+//	@Router			/auth/2fa/enroll [post]
+func (t *TwoFactorHandler) enroll(c *gin.Context) {
+	var enrollReq twoFactorModel.EnrollRequest
+	if c.Request.ContentLength > 0 {
+		if bindErr := c.BindJSON(&enrollReq); bindErr != nil {
+			c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+			return
+		}
+	}
+	userId, _, appErr := t.getUserId(c, enrollReq.ChallengeToken)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	secret, otpAuthUri, appErr := t.twoFactorService.Enroll(userId)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(twoFactorModel.EnrollResponse{
+		Secret:     secret,
+		OtpAuthUri: otpAuthUri,
+	}))
+}
+
+// Enable godoc
+//
+//	@Summary		Enable two factor authentication
+//	@Description	Verifies the first code of the enrolled secret and returns the recovery codes, they are only shown once.
+//	@Description	A challenge token is used up, the user then logs in again with a new code
+//	@Accept			json
+//	@Tags			2fa
+//	@Produce		json
+//	@Param			Authorization	header		string							false	"access token"
+//	@Param			enable			body		twoFactorModel.EnableRequest	true	"enable model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=twoFactorModel.RecoveryCodesResponse}
+//	@Router			/auth/2fa/enable [post]
+func (t *TwoFactorHandler) enable(c *gin.Context) {
+	var enableReq twoFactorModel.EnableRequest
+	if bindErr := c.BindJSON(&enableReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, isChallenge, appErr := t.getUserId(c, enableReq.ChallengeToken)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	recoveryCodes, appErr := t.twoFactorService.Enable(userId, enableReq.Code)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	if isChallenge {
+		if appErr = t.tokenService.UseChallengeToken(enableReq.ChallengeToken, token.TwoFactorEnrollmentToken); appErr != nil {
+			c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+			return
+		}
+	}
+	c.JSON(200, model.SuccessResponse(twoFactorModel.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}))
+}
+
+// Disable godoc
+//
+//	@Summary		Disable two factor authentication
+//	@Description	Not allowed for roles which require two factor authentication
+//	@Accept			json
+//	@Tags			2fa
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"access token"
+//	@Param			disable			body		twoFactorModel.CodeRequest	true	"code model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/2fa/disable [post]
+func (t *TwoFactorHandler) disable(c *gin.Context) {
+	var codeReq twoFactorModel.CodeRequest
+	if bindErr := c.BindJSON(&codeReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	if appErr := t.twoFactorService.Disable(userId, codeReq.Code); appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.Status(204)
+}
+
+// RegenerateRecoveryCodes godoc
+//
+//	@Summary		Regenerate recovery codes
+//	@Description	The previous recovery codes stop working
+//	@Accept			json
+//	@Tags			2fa
+//	@Produce		json
+//	@Param			Authorization	header		string						true	"access token"
+//	@Param			code			body		twoFactorModel.CodeRequest	true	"code model"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=twoFactorModel.RecoveryCodesResponse}
+//	@Router			/auth/2fa/recovery-codes [post]
+func (t *TwoFactorHandler) regenerateRecoveryCodes(c *gin.Context) {
+	var codeReq twoFactorModel.CodeRequest
+	if bindErr := c.BindJSON(&codeReq); bindErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: bindErr})
+		return
+	}
+	userId, _ := util.GetUserId(c)
+	recoveryCodes, appErr := t.twoFactorService.RegenerateRecoveryCodes(userId, codeReq.Code)
+	if appErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(twoFactorModel.RecoveryCodesResponse{RecoveryCodes: recoveryCodes}))
+}
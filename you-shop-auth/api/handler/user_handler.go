@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"github.com/TechwizsonORG/auth-service/api/constant"
+	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	userModel "github.com/TechwizsonORG/auth-service/api/model/user"
+	"github.com/TechwizsonORG/auth-service/api/util"
+	"github.com/TechwizsonORG/auth-service/err"
+	service "github.com/TechwizsonORG/auth-service/usecase/auth"
+	"github.com/TechwizsonORG/auth-service/usecase/user"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type UserHandler struct {
+	authService service.AuthInterface
+	userService user.UserInterface
+}
+
+func NewUserHandler(authService service.AuthInterface, userService user.UserInterface) *UserHandler {
+	return &UserHandler{
+		authService: authService,
+		userService: userService,
+	}
+}
+
+func (u *UserHandler) UserRoutes(route *gin.RouterGroup) {
+	userRoute := route.Group("/users", middleware.AuthorizationMiddleware([]string{"admin"}, nil))
+	userRoute.GET("", u.getUsers)
+	userRoute.GET("/:id", u.getUser)
+	userRoute.POST("/:id/unlock", u.unlockUser)
+	userRoute.POST("/:id/deactivate", u.deactivateUser)
+	userRoute.POST("/:id/reactivate", u.reactivateUser)
+	userRoute.PUT("/:id/roles/:role", u.assignRole)
+	userRoute.DELETE("/:id/roles/:role", u.removeRole)
+	userRoute.PUT("/:id/scopes/:scope", u.assignScope)
+	userRoute.DELETE("/:id/scopes/:scope", u.removeScope)
+}
+
+func parseUserId(c *gin.Context) (uuid.UUID, bool) {
+	userId, parseErr := uuid.Parse(c.Param("id"))
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewAppError(400, "Couldn't parse user id", "Couldn't parse user id", nil)})
+		return uuid.Nil, false
+	}
+	return userId, true
+}
+
+// GetUsers godoc
+//
+//	@Summary		List users
+//	@Description	Includes deactivated users, search matches the username or the email
+//	@Tags			user
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			search			query		string	false	"part of the username or email"
+//	@Param			page			query		int		false	"Page number"	default(1)
+//	@Param			page_size		query		int		false	"Page size"		default(10)
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		200				{object}	model.ApiResponse{data=userModel.AdminUserPageResponse}
+//	@Router			/auth/users [get]
+func (u *UserHandler) getUsers(c *gin.Context) {
+	ok, paginationErr := util.PaginationValidator(c)
+	if !ok {
+		c.Errors = append(c.Errors, &gin.Error{Err: paginationErr})
+		return
+	}
+	page, pageSize := util.GetPaginationQuery(c)
+	users, e := u.userService.GetUsers(c.Query(constant.SEARCH_QUERY), page, pageSize)
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(userModel.FromUserPage(*users)))
+}
+
+// GetUser godoc
+//
+//	@Summary	Get user with their roles and scopes
+//	@Tags		user
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Param		id				path		string	true	"user id"
+//	@Failure	400				{object}	model.ApiResponse
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	404				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	200				{object}	model.ApiResponse{data=userModel.AdminUserDetailResponse}
+//	@Router		/auth/users/{id} [get]
+func (u *UserHandler) getUser(c *gin.Context) {
+	userId, ok := parseUserId(c)
+	if !ok {
+		return
+	}
+	detail, e := u.userService.GetUser(userId)
+	if e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.JSON(200, model.SuccessResponse(userModel.FromUserDetail(*detail)))
+}
+
+// UnlockUser godoc
+//
+//	@Summary		Unlock user
+//	@Description	Clears the failed logins of the user and lifts the lockout
+//	@Tags			user
+//	@Produce		json
+//	@Param			id	path		string	true	"user id"
+//	@Failure		400	{object}	model.ApiResponse
+//	@Failure		401	{object}	model.ApiResponse
+//	@Failure		500	{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/users/{id}/unlock [post]
+func (u *UserHandler) unlockUser(c *gin.Context) {
+	userId, ok := parseUserId(c)
+	if !ok {
+		return
+	}
+	if e := u.authService.UnlockUser(userId); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// DeactivateUser godoc
+//
+//	@Summary		Deactivate user
+//	@Description	The user can't log in anymore and all of their sessions and api keys end
+//	@Tags			user
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			id				path		string	true	"user id"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/users/{id}/deactivate [post]
+func (u *UserHandler) deactivateUser(c *gin.Context) {
+	userId, ok := parseUserId(c)
+	if !ok {
+		return
+	}
+	adminId, _ := util.GetUserId(c)
+	if e := u.userService.DeactivateUser(adminId, userId); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// ReactivateUser godoc
+//
+//	@Summary	Reactivate user
+//	@Tags		user
+//	@Produce	json
+//	@Param		Authorization	header		string	true	"access token"
+//	@Param		id				path		string	true	"user id"
+//	@Failure	400				{object}	model.ApiResponse
+//	@Failure	401				{object}	model.ApiResponse
+//	@Failure	404				{object}	model.ApiResponse
+//	@Failure	500				{object}	model.ApiResponse
+//	@Success	204
+//	@Router		/auth/users/{id}/reactivate [post]
+func (u *UserHandler) reactivateUser(c *gin.Context) {
+	userId, ok := parseUserId(c)
+	if !ok {
+		return
+	}
+	if e := u.userService.ReactivateUser(userId); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// AssignRole godoc
+//
+//	@Summary		Assign role to user
+//	@Description	Takes effect from the next token issued to the user
+//	@Tags			user
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			id				path		string	true	"user id"
+//	@Param			role			path		string	true	"role name"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/users/{id}/roles/{role} [put]
+func (u *UserHandler) assignRole(c *gin.Context) {
+	userId, ok := parseUserId(c)
+	if !ok {
+		return
+	}
+	adminId, _ := util.GetUserId(c)
+	if e := u.userService.AssignRole(adminId, userId, c.Param("role")); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// RemoveRole godoc
+//
+//	@Summary		Remove role from user
+//	@Description	Takes effect from the next token issued to the user
+//	@Tags			user
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			id				path		string	true	"user id"
+//	@Param			role			path		string	true	"role name"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/users/{id}/roles/{role} [delete]
+func (u *UserHandler) removeRole(c *gin.Context) {
+	userId, ok := parseUserId(c)
+	if !ok {
+		return
+	}
+	adminId, _ := util.GetUserId(c)
+	if e := u.userService.RemoveRole(adminId, userId, c.Param("role")); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// AssignScope godoc
+//
+//	@Summary		Assign scope to user
+//	@Description	Takes effect from the next token issued to the user
+//	@Tags			user
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			id				path		string	true	"user id"
+//	@Param			scope			path		string	true	"scope name"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/users/{id}/scopes/{scope} [put]
+func (u *UserHandler) assignScope(c *gin.Context) {
+	userId, ok := parseUserId(c)
+	if !ok {
+		return
+	}
+	if e := u.userService.AssignScope(userId, c.Param("scope")); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
+
+// RemoveScope godoc
+//
+//	@Summary		Remove scope from user
+//	@Description	Takes effect from the next token issued to the user
+//	@Tags			user
+//	@Produce		json
+//	@Param			Authorization	header		string	true	"access token"
+//	@Param			id				path		string	true	"user id"
+//	@Param			scope			path		string	true	"scope name"
+//	@Failure		400				{object}	model.ApiResponse
+//	@Failure		401				{object}	model.ApiResponse
+//	@Failure		404				{object}	model.ApiResponse
+//	@Failure		500				{object}	model.ApiResponse
+//	@Success		204
+//	@Router			/auth/users/{id}/scopes/{scope} [delete]
+func (u *UserHandler) removeScope(c *gin.Context) {
+	userId, ok := parseUserId(c)
+	if !ok {
+		return
+	}
+	if e := u.userService.RemoveScope(userId, c.Param("scope")); e != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: e})
+		return
+	}
+	c.Status(204)
+}
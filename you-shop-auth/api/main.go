@@ -10,11 +10,27 @@ import (
 	"github.com/TechwizsonORG/auth-service/api/docs"
 	"github.com/TechwizsonORG/auth-service/api/handler"
 	"github.com/TechwizsonORG/auth-service/api/middleware"
+	"github.com/TechwizsonORG/auth-service/background"
 	"github.com/TechwizsonORG/auth-service/config"
 	configModel "github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/infrastructure/image"
+	"github.com/TechwizsonORG/auth-service/infrastructure/mailer"
+	"github.com/TechwizsonORG/auth-service/infrastructure/notifier"
+	"github.com/TechwizsonORG/auth-service/infrastructure/rabbitmq"
 	"github.com/TechwizsonORG/auth-service/infrastructure/repository"
+	"github.com/TechwizsonORG/auth-service/job"
+	"github.com/TechwizsonORG/auth-service/usecase/apikey"
+	"github.com/TechwizsonORG/auth-service/usecase/audit"
 	"github.com/TechwizsonORG/auth-service/usecase/auth"
+	"github.com/TechwizsonORG/auth-service/usecase/key"
+	"github.com/TechwizsonORG/auth-service/usecase/oauth"
+	"github.com/TechwizsonORG/auth-service/usecase/outbox"
+	"github.com/TechwizsonORG/auth-service/usecase/permission"
+	"github.com/TechwizsonORG/auth-service/usecase/profile"
+	"github.com/TechwizsonORG/auth-service/usecase/session"
 	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/TechwizsonORG/auth-service/usecase/twofactor"
+	"github.com/TechwizsonORG/auth-service/usecase/user"
 	"github.com/TechwizsonORG/auth-service/util"
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
@@ -27,8 +43,14 @@ import (
 
 func main() {
 
-	databaseConfig, svrConfig, logConfig, jwtConfig, mode := config.Init()
+	databaseConfig, svrConfig, logConfig, jwtConfig, mailConfig, securityConfig, rabbitMqConfig, httpEndpoint, notifierConfig, mode := config.Init()
 	logger := createLogger(logConfig)
+	util.SetPasswordHashParams(util.PasswordHashParams{
+		Memory:      uint32(securityConfig.PasswordHashMemory),
+		Iterations:  uint32(securityConfig.PasswordHashIterations),
+		Parallelism: uint8(securityConfig.PasswordHashParallelism),
+	})
+	util.SetMaxConcurrentPasswordHashes(securityConfig.PasswordHashMaxConcurrent)
 
 	db, err := sql.Open("postgres", databaseConfig.GetPostgresDSN())
 	if err != nil {
@@ -39,11 +61,57 @@ func main() {
 	userRepo := repository.NewUserRepository(db, logger)
 	roleRepo := repository.NewRoleRepository(db, logger)
 	scopeRepo := repository.NewScopeRepository(db, logger)
-	tokenService := token.NewTokenService(logger, userRepo, *jwtConfig, roleRepo, scopeRepo)
-	authService := auth.NewAuthService(logger, userRepo, tokenService)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db, logger)
+	sessionRepo := repository.NewSessionRepository(db, logger)
+	apiKeyRepo := repository.NewApiKeyRepository(db, logger)
+	impersonationRepo := repository.NewImpersonationRepository(db, logger)
+	accessTokenRepo := repository.NewAccessTokenRepository(db, logger)
+	signingKeyRepo := repository.NewSigningKeyRepository(db, logger)
+	keyService := key.NewKeyService(logger, *jwtConfig, signingKeyRepo)
+	clientRepo := repository.NewClientRepository(db, logger)
+	msgQueue := rabbitmq.NewDefaultMessageQueue(*rabbitMqConfig, logger)
+	auditService := audit.NewAuditService(logger, repository.NewAuthAuditRepository(db, logger), impersonationRepo, msgQueue)
+	tokenService := token.NewTokenService(logger, userRepo, *jwtConfig, roleRepo, scopeRepo, refreshTokenRepo, sessionRepo, apiKeyRepo, impersonationRepo, accessTokenRepo, clientRepo, keyService, auditService)
+	passwordResetTokenRepo := repository.NewPasswordResetTokenRepository(db, logger)
+	emailVerificationTokenRepo := repository.NewEmailVerificationTokenRepository(db, logger)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(db, logger)
+	twoFactorRepo := repository.NewTwoFactorRepository(db, logger)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(db, logger)
+	oneTimeCodeRepo := repository.NewOneTimeCodeRepository(db, logger)
+	twoFactorService := twofactor.NewTwoFactorService(logger, *securityConfig, userRepo, roleRepo, twoFactorRepo, recoveryCodeRepo)
+	defaultMailer := mailer.NewMailer(logger, *mailConfig)
+	authService := auth.NewAuthService(logger, userRepo, tokenService, passwordResetTokenRepo, emailVerificationTokenRepo, loginAttemptRepo, oneTimeCodeRepo, twoFactorService, auditService, defaultMailer, notifier.NewNotifier(logger, *notifierConfig, defaultMailer), *mailConfig, *securityConfig)
+	if e := authService.VerifyLegacyEmails(); e != nil {
+		logger.Error().Msg("Failed to verify the emails of users who registered before email verification")
+	}
+	authorizationCodeRepo := repository.NewAuthorizationCodeRepository(db, logger)
+	consentRepo := repository.NewConsentRepository(db, logger)
+	oauthService := oauth.NewOAuthService(logger, *jwtConfig, clientRepo, authorizationCodeRepo, consentRepo, scopeRepo, tokenService)
+	imageUploader := image.NewHttpImageUploader(logger, *httpEndpoint, clientRepo, tokenService)
+	profileService := profile.NewProfileService(logger, userRepo, tokenService, authService, imageUploader, auditService)
 
 	authHandler := handler.NewAuthHandler(authService)
 	tokenHandler := handler.NewTokenHandler(tokenService)
+	keyHandler := handler.NewKeyHandler(keyService)
+	oauthHandler := handler.NewOAuthHandler(oauthService)
+	userHandler := handler.NewUserHandler(authService, user.NewUserService(logger, userRepo, roleRepo, scopeRepo, tokenService, auditService))
+	twoFactorHandler := handler.NewTwoFactorHandler(twoFactorService, tokenService)
+	permissionService := permission.NewPermissionService(logger, roleRepo, scopeRepo)
+	if e := permissionService.SeedDefaultScopes(); e != nil {
+		logger.Error().Msg("Failed to seed the default scopes")
+	}
+	permissionHandler := handler.NewPermissionHandler(permissionService)
+	profileHandler := handler.NewProfileHandler(profileService)
+	auditHandler := handler.NewAuditHandler(auditService)
+	impersonationHandler := handler.NewImpersonationHandler(tokenService, auditService)
+	apiKeyHandler := handler.NewApiKeyHandler(apikey.NewApiKeyService(logger, apiKeyRepo, scopeRepo, auditService))
+	sessionHandler := handler.NewSessionHandler(session.NewSessionService(logger, sessionRepo, tokenService), tokenService)
+
+	// background job
+	job := job.NewJob(logger)
+	background.Go(logger, job.CleanupExpiredTokens(tokenService, time.Hour))
+	background.Go(logger, job.RotateSigningKey(keyService, time.Hour))
+	background.Go(logger, job.PublishOutbox(outbox.NewOutboxService(logger, repository.NewOutboxRepository(db, logger), msgQueue), 10*time.Second))
 
 	docs.SwaggerInfo.Title = "Auth API"
 	docs.SwaggerInfo.Version = "1.0"
@@ -59,6 +127,7 @@ func main() {
 	router.Use(middleware.Cors())
 	router.Use(middleware.Recovery(logger))
 	router.Use(middleware.RequestLog(logger))
+	router.Use(middleware.AuthenticateMiddleware(logger, tokenService))
 	router.Use(middleware.ErrorHandler(logger))
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -73,6 +142,16 @@ func main() {
 
 	authHandler.AuthRoutes(v1)
 	tokenHandler.TokenRoutes(v1)
+	keyHandler.KeyRoutes(v1)
+	oauthHandler.OAuthRoutes(v1)
+	userHandler.UserRoutes(v1)
+	twoFactorHandler.TwoFactorRoutes(v1)
+	permissionHandler.PermissionRoutes(v1)
+	profileHandler.ProfileRoutes(v1)
+	sessionHandler.SessionRoutes(v1)
+	auditHandler.AuditRoutes(v1)
+	impersonationHandler.ImpersonationRoutes(v1)
+	apiKeyHandler.ApiKeyRoutes(v1)
 
 	logger.Info().Msgf("Auth Service is running on %s:%d", svrConfig.Host, svrConfig.Port)
 	router.Run(fmt.Sprintf("%s:%d", svrConfig.Host, svrConfig.Port))
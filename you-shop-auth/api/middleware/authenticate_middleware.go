@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// AuthenticateMiddleware validates the access token locally and sets the same headers
+// the other services get from /token/validate
+func AuthenticateMiddleware(logger zerolog.Logger, tokenService token.TokenInterface) gin.HandlerFunc {
+	logger = logger.
+		With().
+		Str("Middleware", "Auth").
+		Logger()
+	return func(c *gin.Context) {
+		// Never trust these headers from the outside
+		c.Request.Header.Del("Authenticated")
+		c.Request.Header.Del("userId")
+		c.Request.Header.Del("role")
+		c.Request.Header.Del("scope")
+		c.Request.Header.Del("actorId")
+
+		tokenString := c.Request.Header.Get("Authorization")
+		if tokenString == "" {
+			c.Next()
+			return
+		}
+		user, role, scope, actorId, validationErr := tokenService.ValidateTokenWithResponse(tokenString, token.AccessToken)
+		if validationErr != nil {
+			logger.Debug().Err(validationErr).Msg("")
+			logger.Warn().Msg("Unauthorized request accessed")
+			c.Next()
+			return
+		}
+		// This header will be marker to know user have been authenticated
+		c.Request.Header.Set("Authenticated", "")
+		c.Request.Header.Set("userId", user.Id.String())
+		c.Request.Header.Set("role", role)
+		c.Request.Header.Set("scope", scope)
+		if actorId != uuid.Nil {
+			c.Request.Header.Set("actorId", actorId.String())
+		}
+		c.Next()
+	}
+}
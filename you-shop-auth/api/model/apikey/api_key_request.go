@@ -0,0 +1,10 @@
+package apikey
+
+import "time"
+
+type CreateApiKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// At most a year from now
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,52 @@
+package apikey
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+)
+
+type ApiKeyResponse struct {
+	Id     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	Prefix string    `json:"prefix"`
+	Scopes []string  `json:"scopes"`
+	// Nil until the key is used
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	Expired    bool       `json:"expired"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type CreateApiKeyResponse struct {
+	ApiKeyResponse
+	// Only returned once, send it as "Authorization: ApiKey <key>"
+	Key string `json:"key"`
+}
+
+func FromApiKey(apiKey entity.ApiKey) ApiKeyResponse {
+	response := ApiKeyResponse{
+		Id:        apiKey.Id,
+		Name:      apiKey.Name,
+		Prefix:    apiKey.Prefix,
+		Scopes:    strings.Split(apiKey.Scope, ","),
+		ExpiresAt: apiKey.ExpiresAt,
+		Expired:   apiKey.IsExpired(util.GetCurrentUtcTime(7)),
+		CreatedAt: apiKey.CreatedAt,
+	}
+	if !apiKey.LastUsedAt.IsZero() {
+		response.LastUsedAt = &apiKey.LastUsedAt
+	}
+	return response
+}
+
+func FromApiKeys(apiKeys []entity.ApiKey) []ApiKeyResponse {
+	response := []ApiKeyResponse{}
+	for _, apiKey := range apiKeys {
+		response = append(response, FromApiKey(apiKey))
+	}
+	return response
+}
@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	auditModel "github.com/TechwizsonORG/auth-service/usecase/audit/model"
+	"github.com/google/uuid"
+)
+
+type AuditResponse struct {
+	Id         uuid.UUID       `json:"id"`
+	EventType  string          `json:"eventType"`
+	UserId     uuid.UUID       `json:"userId"`
+	ActorId    uuid.UUID       `json:"actorId"`
+	Ip         string          `json:"ip"`
+	Detail     json.RawMessage `json:"detail" swaggertype:"object"`
+	OccurredAt time.Time       `json:"occurredAt"`
+}
+
+type AuditPageResponse struct {
+	Items    []AuditResponse `json:"items"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"pageSize"`
+	Total    int             `json:"total"`
+}
+
+func FromAudit(audit entity.AuthAudit) AuditResponse {
+	return AuditResponse{
+		Id:         audit.Id,
+		EventType:  audit.EventType,
+		UserId:     audit.UserId,
+		ActorId:    audit.ActorId,
+		Ip:         audit.Ip,
+		Detail:     json.RawMessage(audit.Detail),
+		OccurredAt: audit.OccurredAt,
+	}
+}
+
+func FromAuditPage(page auditModel.AuditPage) *AuditPageResponse {
+	response := &AuditPageResponse{
+		Items:    []AuditResponse{},
+		Page:     page.Page,
+		PageSize: page.PageSize,
+		Total:    page.Total,
+	}
+	for _, audit := range page.Audits {
+		response.Items = append(response.Items, FromAudit(audit))
+	}
+	return response
+}
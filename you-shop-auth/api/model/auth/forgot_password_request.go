@@ -0,0 +1,5 @@
+package auth
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
@@ -1,24 +1,28 @@
 package auth
 
 import (
-	"github.com/TechwizsonORG/auth-service/entity"
+	authModel "github.com/TechwizsonORG/auth-service/usecase/auth/model"
 	"github.com/google/uuid"
 )
 
 type LoginResponse struct {
-	Id           uuid.UUID `json:"id"`
-	AccessToken  string    `json:"accessToken"`
-	RefreshToken string    `json:"refreshToken"`
-	Username     string    `json:"username"`
-	AvatarUrl    string    `json:"avatarUrl"`
+	Id                 uuid.UUID `json:"id"`
+	AccessToken        string    `json:"accessToken"`
+	RefreshToken       string    `json:"refreshToken"`
+	Username           string    `json:"username"`
+	AvatarUrl          string    `json:"avatarUrl"`
+	ChallengeToken     string    `json:"challengeToken,omitempty"`
+	EnrollmentRequired bool      `json:"enrollmentRequired,omitempty"`
 }
 
-func From(u entity.User, accessToken, refreshToken string) *LoginResponse {
+func From(result authModel.LoginResult) *LoginResponse {
 	return &LoginResponse{
-		Id:           u.Id,
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-		Username:     u.Username,
-		AvatarUrl:    u.AvatarUrl,
+		Id:                 result.User.Id,
+		AccessToken:        result.AccessToken,
+		RefreshToken:       result.RefreshToken,
+		Username:           result.User.Username,
+		AvatarUrl:          result.User.AvatarUrl,
+		ChallengeToken:     result.ChallengeToken,
+		EnrollmentRequired: result.EnrollmentRequired,
 	}
 }
@@ -4,4 +4,5 @@ type LoginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 	Email    string `json:"email"`
+	Device   string `json:"device"`
 }
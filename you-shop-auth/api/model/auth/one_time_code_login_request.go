@@ -0,0 +1,8 @@
+package auth
+
+type OneTimeCodeLoginRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Code     string `json:"code"`
+	Device   string `json:"device"`
+}
@@ -0,0 +1,8 @@
+package auth
+
+type OneTimeCodeRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	// email or sms, defaults to email
+	Channel string `json:"channel"`
+}
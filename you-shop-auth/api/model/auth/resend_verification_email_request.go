@@ -0,0 +1,5 @@
+package auth
+
+type ResendVerificationEmailRequest struct {
+	Email string `json:"email"`
+}
@@ -0,0 +1,6 @@
+package auth
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,8 @@
+package auth
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	// TOTP code or recovery code
+	Code   string `json:"code"`
+	Device string `json:"device"`
+}
@@ -0,0 +1,9 @@
+package impersonation
+
+import "github.com/google/uuid"
+
+type StartImpersonationRequest struct {
+	UserId uuid.UUID `json:"userId"`
+	// Why support needs to act as the user, e.g. a ticket number
+	Reason string `json:"reason"`
+}
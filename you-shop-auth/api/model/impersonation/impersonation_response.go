@@ -0,0 +1,63 @@
+package impersonation
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	auditModel "github.com/TechwizsonORG/auth-service/usecase/audit/model"
+	"github.com/google/uuid"
+)
+
+type ImpersonationResponse struct {
+	Id        uuid.UUID `json:"id"`
+	AdminId   uuid.UUID `json:"adminId"`
+	UserId    uuid.UUID `json:"userId"`
+	Reason    string    `json:"reason"`
+	Ip        string    `json:"ip"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Nil unless the admin ended the impersonation before it expired
+	EndedAt *time.Time `json:"endedAt"`
+}
+
+type StartImpersonationResponse struct {
+	ImpersonationResponse
+	// Short lived and can't be refreshed, sensitive endpoints reject it
+	AccessToken string `json:"accessToken"`
+}
+
+type ImpersonationPageResponse struct {
+	Items    []ImpersonationResponse `json:"items"`
+	Page     int                     `json:"page"`
+	PageSize int                     `json:"pageSize"`
+	Total    int                     `json:"total"`
+}
+
+func FromImpersonation(impersonation entity.Impersonation) ImpersonationResponse {
+	response := ImpersonationResponse{
+		Id:        impersonation.Id,
+		AdminId:   impersonation.AdminId,
+		UserId:    impersonation.UserId,
+		Reason:    impersonation.Reason,
+		Ip:        impersonation.Ip,
+		CreatedAt: impersonation.CreatedAt,
+		ExpiresAt: impersonation.ExpiresAt,
+	}
+	if impersonation.IsEnded() {
+		response.EndedAt = &impersonation.EndedAt
+	}
+	return response
+}
+
+func FromImpersonationPage(page auditModel.ImpersonationPage) *ImpersonationPageResponse {
+	response := &ImpersonationPageResponse{
+		Items:    []ImpersonationResponse{},
+		Page:     page.Page,
+		PageSize: page.PageSize,
+		Total:    page.Total,
+	}
+	for _, impersonation := range page.Impersonations {
+		response.Items = append(response.Items, FromImpersonation(impersonation))
+	}
+	return response
+}
@@ -0,0 +1,30 @@
+package oauth
+
+import "github.com/TechwizsonORG/auth-service/usecase/oauth/model"
+
+type AuthorizeRequest struct {
+	ResponseType        string `form:"response_type" json:"response_type"`
+	ClientId            string `form:"client_id" json:"client_id"`
+	RedirectUri         string `form:"redirect_uri" json:"redirect_uri"`
+	Scope               string `form:"scope" json:"scope"`
+	State               string `form:"state" json:"state"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+}
+
+type ConsentRequest struct {
+	AuthorizeRequest
+	Approved bool `json:"approved"`
+}
+
+func (a AuthorizeRequest) ToModel() model.AuthorizationRequest {
+	return model.AuthorizationRequest{
+		ResponseType:        a.ResponseType,
+		ClientId:            a.ClientId,
+		RedirectUri:         a.RedirectUri,
+		Scope:               a.Scope,
+		State:               a.State,
+		CodeChallenge:       a.CodeChallenge,
+		CodeChallengeMethod: a.CodeChallengeMethod,
+	}
+}
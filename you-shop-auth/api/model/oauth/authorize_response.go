@@ -0,0 +1,23 @@
+package oauth
+
+import "github.com/TechwizsonORG/auth-service/usecase/oauth/model"
+
+type AuthorizeResponse struct {
+	ClientName        string   `json:"clientName"`
+	ClientDescription string   `json:"clientDescription"`
+	WebsiteUrl        string   `json:"websiteUrl"`
+	Scopes            []string `json:"scopes"`
+	ConsentRequired   bool     `json:"consentRequired"`
+	RedirectUri       string   `json:"redirectUri,omitempty"`
+}
+
+func FromAuthorizationResult(result model.AuthorizationResult) *AuthorizeResponse {
+	return &AuthorizeResponse{
+		ClientName:        result.ClientName,
+		ClientDescription: result.ClientDescription,
+		WebsiteUrl:        result.WebsiteUrl,
+		Scopes:            result.Scopes,
+		ConsentRequired:   result.ConsentRequired,
+		RedirectUri:       result.RedirectUri,
+	}
+}
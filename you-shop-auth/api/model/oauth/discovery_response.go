@@ -0,0 +1,42 @@
+package oauth
+
+import "github.com/TechwizsonORG/auth-service/usecase/oauth/model"
+
+// DiscoveryResponse follows OpenID Connect Discovery 1.0 section 3
+type DiscoveryResponse struct {
+	Issuer                                    string   `json:"issuer"`
+	AuthorizationEndpoint                     string   `json:"authorization_endpoint"`
+	TokenEndpoint                             string   `json:"token_endpoint"`
+	UserInfoEndpoint                          string   `json:"userinfo_endpoint"`
+	JwksUri                                   string   `json:"jwks_uri"`
+	IntrospectionEndpoint                     string   `json:"introspection_endpoint"`
+	ScopesSupported                           []string `json:"scopes_supported"`
+	ResponseTypesSupported                    []string `json:"response_types_supported"`
+	GrantTypesSupported                       []string `json:"grant_types_supported"`
+	SubjectTypesSupported                     []string `json:"subject_types_supported"`
+	IdTokenSigningAlgValuesSupported          []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported         []string `json:"token_endpoint_auth_methods_supported"`
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported             []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                           []string `json:"claims_supported"`
+}
+
+func FromDiscovery(discovery model.Discovery) *DiscoveryResponse {
+	return &DiscoveryResponse{
+		Issuer:                            discovery.Issuer,
+		AuthorizationEndpoint:             discovery.AuthorizationEndpoint,
+		TokenEndpoint:                     discovery.TokenEndpoint,
+		UserInfoEndpoint:                  discovery.UserInfoEndpoint,
+		JwksUri:                           discovery.JwksUri,
+		IntrospectionEndpoint:             discovery.IntrospectionEndpoint,
+		ScopesSupported:                   discovery.ScopesSupported,
+		ResponseTypesSupported:            discovery.ResponseTypesSupported,
+		GrantTypesSupported:               discovery.GrantTypesSupported,
+		SubjectTypesSupported:             discovery.SubjectTypesSupported,
+		IdTokenSigningAlgValuesSupported:  discovery.IdTokenSigningAlgValuesSupported,
+		TokenEndpointAuthMethodsSupported: discovery.TokenEndpointAuthMethodsSupported,
+		IntrospectionEndpointAuthMethodsSupported: discovery.IntrospectionEndpointAuthMethodsSupported,
+		CodeChallengeMethodsSupported:             discovery.CodeChallengeMethodsSupported,
+		ClaimsSupported:                           discovery.ClaimsSupported,
+	}
+}
@@ -0,0 +1,9 @@
+package oauth
+
+// IntrospectionRequest follows RFC 7662 section 2.1
+type IntrospectionRequest struct {
+	Token         string `form:"token"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientId      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
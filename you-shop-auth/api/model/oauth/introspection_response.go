@@ -0,0 +1,55 @@
+package oauth
+
+import (
+	"strings"
+
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+)
+
+// IntrospectionResponse follows RFC 7662 section 2.2, role is an extension and act follows RFC 8693 section 4.1
+type IntrospectionResponse struct {
+	Active    bool     `json:"active"`
+	Scope     string   `json:"scope,omitempty"`
+	ClientId  string   `json:"client_id,omitempty"`
+	Username  string   `json:"username,omitempty"`
+	TokenType string   `json:"token_type,omitempty"`
+	Exp       int64    `json:"exp,omitempty"`
+	Iat       int64    `json:"iat,omitempty"`
+	Nbf       int64    `json:"nbf,omitempty"`
+	Sub       string   `json:"sub,omitempty"`
+	Iss       string   `json:"iss,omitempty"`
+	Jti       string   `json:"jti,omitempty"`
+	Role      []string `json:"role,omitempty"`
+	Act       *Actor   `json:"act,omitempty"`
+}
+
+// Actor is the admin acting as the subject of an impersonation token
+type Actor struct {
+	Sub string `json:"sub"`
+}
+
+func FromTokenIntrospection(introspection token.TokenIntrospection) *IntrospectionResponse {
+	if !introspection.Active {
+		return &IntrospectionResponse{Active: false}
+	}
+	response := &IntrospectionResponse{
+		Active:    true,
+		Scope:     strings.Join(splitClaim(introspection.Scope), " "),
+		ClientId:  introspection.ClientId,
+		TokenType: introspection.TokenType.String() + "_token",
+		Exp:       introspection.ExpiresAt.Unix(),
+		Iat:       introspection.IssuedAt.Unix(),
+		Nbf:       introspection.NotBefore.Unix(),
+		Sub:       introspection.Subject,
+		Iss:       introspection.Issuer,
+		Jti:       introspection.TokenId,
+		Role:      splitClaim(introspection.Role),
+	}
+	if introspection.User != nil {
+		response.Username = introspection.User.Username
+	}
+	if introspection.ActorId != "" {
+		response.Act = &Actor{Sub: introspection.ActorId}
+	}
+	return response
+}
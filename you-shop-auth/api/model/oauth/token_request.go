@@ -0,0 +1,27 @@
+package oauth
+
+import "github.com/TechwizsonORG/auth-service/usecase/oauth/model"
+
+type TokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectUri  string `form:"redirect_uri"`
+	ClientId     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+}
+
+func (t TokenRequest) ToModel() model.TokenRequest {
+	return model.TokenRequest{
+		GrantType:    t.GrantType,
+		Code:         t.Code,
+		RedirectUri:  t.RedirectUri,
+		ClientId:     t.ClientId,
+		ClientSecret: t.ClientSecret,
+		CodeVerifier: t.CodeVerifier,
+		RefreshToken: t.RefreshToken,
+		Scope:        t.Scope,
+	}
+}
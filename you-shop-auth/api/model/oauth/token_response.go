@@ -0,0 +1,28 @@
+package oauth
+
+import "github.com/TechwizsonORG/auth-service/usecase/oauth/model"
+
+// TokenResponse follows RFC 6749 section 5.1
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// ErrorResponse follows RFC 6749 section 5.2
+type ErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+func FromTokenResult(result model.TokenResult) *TokenResponse {
+	return &TokenResponse{
+		AccessToken:  result.AccessToken,
+		TokenType:    result.TokenType,
+		ExpiresIn:    result.ExpiresIn,
+		RefreshToken: result.RefreshToken,
+		Scope:        result.Scope,
+	}
+}
@@ -0,0 +1,37 @@
+package oauth
+
+import (
+	"strings"
+
+	"github.com/TechwizsonORG/auth-service/usecase/oauth/model"
+)
+
+// UserInfoResponse follows OpenID Connect Core 1.0 section 5.3.2, role and scope are extensions
+type UserInfoResponse struct {
+	Subject           string   `json:"sub"`
+	Email             string   `json:"email"`
+	EmailVerified     bool     `json:"email_verified"`
+	PreferredUsername string   `json:"preferred_username"`
+	Picture           string   `json:"picture,omitempty"`
+	PhoneNumber       string   `json:"phone_number,omitempty"`
+	Role              []string `json:"role"`
+	Scope             string   `json:"scope,omitempty"`
+}
+
+func FromUserInfo(userInfo model.UserInfo) *UserInfoResponse {
+	return &UserInfoResponse{
+		Subject:           userInfo.User.Id.String(),
+		Email:             userInfo.User.Email,
+		EmailVerified:     userInfo.User.IsEmailVerified(),
+		PreferredUsername: userInfo.User.Username,
+		Picture:           userInfo.User.AvatarUrl,
+		PhoneNumber:       userInfo.User.PhoneNumber,
+		Role:              splitClaim(userInfo.Role),
+		Scope:             strings.Join(splitClaim(userInfo.Scope), " "),
+	}
+}
+
+// splitClaim turns the comma separated role and scope claims into a list
+func splitClaim(claim string) []string {
+	return strings.FieldsFunc(claim, func(r rune) bool { return r == ',' })
+}
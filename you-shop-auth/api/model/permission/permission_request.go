@@ -0,0 +1,23 @@
+package permission
+
+type AddRoleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Name of the inherited role, leave empty for none
+	Parent string `json:"parent"`
+}
+
+type UpdateRoleRequest struct {
+	Description string `json:"description"`
+	// Name of the inherited role, leave empty for none
+	Parent string `json:"parent"`
+}
+
+type AddScopeRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type UpdateScopeRequest struct {
+	Description string `json:"description"`
+}
@@ -0,0 +1,43 @@
+package permission
+
+import (
+	"github.com/TechwizsonORG/auth-service/entity"
+	permissionModel "github.com/TechwizsonORG/auth-service/usecase/permission/model"
+	"github.com/google/uuid"
+)
+
+type RoleResponse struct {
+	Id          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Parent      string    `json:"parent"`
+	Scopes      []string  `json:"scopes"`
+}
+
+type ScopeResponse struct {
+	Id          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+func FromRoleDetail(detail permissionModel.RoleDetail) RoleResponse {
+	response := RoleResponse{
+		Id:          detail.Role.Id,
+		Name:        detail.Role.Name,
+		Description: detail.Role.Description,
+		Parent:      detail.ParentName,
+		Scopes:      []string{},
+	}
+	for _, scope := range detail.Scopes {
+		response.Scopes = append(response.Scopes, scope.Name)
+	}
+	return response
+}
+
+func FromScope(scope entity.Scope) ScopeResponse {
+	return ScopeResponse{
+		Id:          scope.Id,
+		Name:        scope.Name,
+		Description: scope.Description,
+	}
+}
@@ -0,0 +1,20 @@
+package profile
+
+type UpdateProfileRequest struct {
+	Username    string `json:"username"`
+	PhoneNumber string `json:"phoneNumber"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+type ChangeEmailRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+	Email           string `json:"email"`
+}
+
+type DeleteAccountRequest struct {
+	CurrentPassword string `json:"currentPassword"`
+}
@@ -0,0 +1,34 @@
+package profile
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/google/uuid"
+)
+
+type ProfileResponse struct {
+	Id            uuid.UUID `json:"id"`
+	Username      string    `json:"username"`
+	Email         string    `json:"email"`
+	PhoneNumber   string    `json:"phoneNumber"`
+	AvatarUrl     string    `json:"avatarUrl"`
+	EmailVerified bool      `json:"emailVerified"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type AvatarResponse struct {
+	AvatarUrl string `json:"avatarUrl"`
+}
+
+func FromUser(user entity.User) ProfileResponse {
+	return ProfileResponse{
+		Id:            user.Id,
+		Username:      user.Username,
+		Email:         user.Email,
+		PhoneNumber:   user.PhoneNumber,
+		AvatarUrl:     user.AvatarUrl,
+		EmailVerified: user.IsEmailVerified(),
+		CreatedAt:     user.CreatedAt,
+	}
+}
@@ -0,0 +1,37 @@
+package session
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/google/uuid"
+)
+
+type SessionResponse struct {
+	Id         uuid.UUID `json:"id"`
+	ClientId   string    `json:"clientId,omitempty"`
+	UserAgent  string    `json:"userAgent"`
+	Ip         string    `json:"ip"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	// Whether the session is the one of the access token used for the request
+	Current bool `json:"current"`
+}
+
+func FromSessions(sessions []entity.Session, currentSessionId string) []SessionResponse {
+	result := make([]SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, SessionResponse{
+			Id:         session.Id,
+			ClientId:   session.ClientId,
+			UserAgent:  session.UserAgent,
+			Ip:         session.Ip,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.Id.String() == currentSessionId,
+		})
+	}
+	return result
+}
@@ -0,0 +1,6 @@
+package token
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+	Device       string `json:"device"`
+}
@@ -0,0 +1,21 @@
+package token
+
+import "github.com/TechwizsonORG/auth-service/usecase/token"
+
+type RevokeTokenRequest struct {
+	Token string `json:"token"`
+	// Defaults to access
+	Type string `json:"type" enums:"access,refresh"`
+}
+
+// TokenType maps the type name to the token type, ok is false for an unknown name
+func (r RevokeTokenRequest) TokenType() (tokenType token.TokenType, ok bool) {
+	switch r.Type {
+	case "", token.AccessToken.String():
+		return token.AccessToken, true
+	case token.RefreshToken.String():
+		return token.RefreshToken, true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,17 @@
+package twofactor
+
+type EnrollRequest struct {
+	// Lets a user who must enroll before logging in use the challenge token of the login instead of an access token,
+	// only the challenge of a login answered with enrollmentRequired is accepted
+	ChallengeToken string `json:"challengeToken"`
+}
+
+type EnableRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+}
+
+type CodeRequest struct {
+	// TOTP code or recovery code
+	Code string `json:"code"`
+}
@@ -0,0 +1,10 @@
+package twofactor
+
+type EnrollResponse struct {
+	Secret     string `json:"secret"`
+	OtpAuthUri string `json:"otpAuthUri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
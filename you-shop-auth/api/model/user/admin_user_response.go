@@ -0,0 +1,74 @@
+package user
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	userModel "github.com/TechwizsonORG/auth-service/usecase/user/model"
+	"github.com/google/uuid"
+)
+
+type AdminUserResponse struct {
+	Id            uuid.UUID `json:"id"`
+	Username      string    `json:"username"`
+	Email         string    `json:"email"`
+	PhoneNumber   string    `json:"phoneNumber"`
+	AvatarUrl     string    `json:"avatarUrl"`
+	IsActive      bool      `json:"isActive"`
+	EmailVerified bool      `json:"emailVerified"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type AdminUserDetailResponse struct {
+	AdminUserResponse
+	Roles  []string `json:"roles"`
+	Scopes []string `json:"scopes"`
+}
+
+type AdminUserPageResponse struct {
+	Items    []AdminUserResponse `json:"items"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"pageSize"`
+	Total    int                 `json:"total"`
+}
+
+func FromAdminUser(user entity.User) AdminUserResponse {
+	return AdminUserResponse{
+		Id:            user.Id,
+		Username:      user.Username,
+		Email:         user.Email,
+		PhoneNumber:   user.PhoneNumber,
+		AvatarUrl:     user.AvatarUrl,
+		IsActive:      user.IsActive,
+		EmailVerified: user.IsEmailVerified(),
+		CreatedAt:     user.CreatedAt,
+	}
+}
+
+func FromUserDetail(detail userModel.UserDetail) *AdminUserDetailResponse {
+	response := &AdminUserDetailResponse{
+		AdminUserResponse: FromAdminUser(detail.User),
+		Roles:             []string{},
+		Scopes:            []string{},
+	}
+	for _, role := range detail.Roles {
+		response.Roles = append(response.Roles, role.Name)
+	}
+	for _, scope := range detail.Scopes {
+		response.Scopes = append(response.Scopes, scope.Name)
+	}
+	return response
+}
+
+func FromUserPage(page userModel.UserPage) *AdminUserPageResponse {
+	response := &AdminUserPageResponse{
+		Items:    []AdminUserResponse{},
+		Page:     page.Page,
+		PageSize: page.PageSize,
+		Total:    page.Total,
+	}
+	for _, user := range page.Users {
+		response.Items = append(response.Items, FromAdminUser(user))
+	}
+	return response
+}
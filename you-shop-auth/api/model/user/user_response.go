@@ -11,6 +11,8 @@ type UserResponse struct {
 	Username string    `json:"username"`
 	Role     string    `json:"role"`
 	Scope    string    `json:"scope"`
+	// Admin impersonating the user, services reject it on sensitive endpoints
+	ActorId *uuid.UUID `json:"actorId,omitempty"`
 }
 
 func From(user entity.User, role, scope string) *UserResponse {
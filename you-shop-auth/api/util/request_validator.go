@@ -0,0 +1,43 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/TechwizsonORG/auth-service/api/constant"
+	"github.com/TechwizsonORG/auth-service/api/model"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/gin-gonic/gin"
+)
+
+func PaginationValidator(c *gin.Context) (bool, *err.AppError) {
+	page := c.Query(constant.PAGE_QUERY)
+	pageSize := c.Query(constant.PAGE_SIZE_QUERY)
+
+	if page != "" {
+		pageInt, atoiErr := strconv.Atoi(page)
+		if atoiErr != nil || pageInt < 1 {
+			return false, err.NewAppError(400, "Invalid page", "Page must be a positive number", []model.FailField{
+				{
+					Field:   constant.PAGE_QUERY,
+					Message: "Page must be a positive number",
+				},
+			})
+		}
+	}
+
+	if pageSize != "" {
+		pageSizeInt, atoiErr := strconv.Atoi(pageSize)
+		if atoiErr != nil || pageSizeInt < 1 || pageSizeInt > constant.MAX_PAGE_SIZE {
+			message := fmt.Sprintf("Page size must be a number between 1 and %d", constant.MAX_PAGE_SIZE)
+			return false, err.NewAppError(400, "Invalid page size", message, []model.FailField{
+				{
+					Field:   constant.PAGE_SIZE_QUERY,
+					Message: message,
+				},
+			})
+		}
+	}
+
+	return true, nil
+}
@@ -3,13 +3,15 @@
 package config
 
 import (
+	"runtime"
 	"strconv"
+	"strings"
 
 	"github.com/TechwizsonORG/auth-service/config/model"
 	"github.com/joho/godotenv"
 )
 
-func Init() (databaseConfig *model.DatabaseConfig, serverConfig *model.ServerConfig, logConfig *model.LogConfig, jwtConfig *model.JwtConfig, mode string) {
+func Init() (databaseConfig *model.DatabaseConfig, serverConfig *model.ServerConfig, logConfig *model.LogConfig, jwtConfig *model.JwtConfig, mailConfig *model.MailConfig, securityConfig *model.SecurityConfig, rabbitMqConfig *model.RabbitMqConfig, httpEndpoint *model.HttpEndpoint, notifierConfig *model.NotifierConfig, mode string) {
 	mode = "debug"
 	err := godotenv.Load(".env")
 	if err != nil {
@@ -66,11 +68,103 @@ func Init() (databaseConfig *model.DatabaseConfig, serverConfig *model.ServerCon
 		panic("Invalid JWT_DEFAULT_REFRESH_EXPIRE_TIME value")
 	}
 
+	clientAccessExpireTime, err := strconv.Atoi(envMap["JWT_CLIENT_ACCESS_EXPIRE_TIME"])
+	if err != nil {
+		panic("Invalid JWT_CLIENT_ACCESS_EXPIRE_TIME value")
+	}
+
+	// Hours, only used with the asymmetric signing algorithms
+	keyRotationInterval := envInt(envMap, "JWT_KEY_ROTATION_INTERVAL", 720)
+
 	jwtConfig = &model.JwtConfig{
 		SecretKey:                envMap["JWT_SECRET_KEY"],
 		DefaultAccessExpireTime:  defaultAccessExpireTime,
 		DefaultRefreshExpireTime: defaultRefreshExpireTime,
+		ClientAccessExpireTime:   clientAccessExpireTime,
 		Issuer:                   envMap["JWT_ISSUER"],
+		SigningAlgorithm:         envMap["JWT_SIGNING_ALGORITHM"],
+		KeyRotationInterval:      keyRotationInterval,
+	}
+	mailConfig = &model.MailConfig{
+		Driver:               envString(envMap, "MAIL_DRIVER", "file"),
+		Host:                 envMap["MAIL_HOST"],
+		Username:             envMap["MAIL_USERNAME"],
+		Password:             envMap["MAIL_PASSWORD"],
+		From:                 envMap["MAIL_FROM"],
+		OutboxDir:            envMap["MAIL_OUTBOX_DIR"],
+		PasswordResetUrl:     envMap["PASSWORD_RESET_URL"],
+		EmailVerificationUrl: envMap["EMAIL_VERIFICATION_URL"],
+	}
+	if mailConfig.Driver == "smtp" {
+		mailPort, err := strconv.Atoi(envMap["MAIL_PORT"])
+		if err != nil {
+			panic("Invalid MAIL_PORT value")
+		}
+		mailConfig.Port = mailPort
+	}
+
+	requireEmailVerification := envBool(envMap, "REQUIRE_EMAIL_VERIFICATION", false)
+	emailVerificationReleasedAt := envTime(envMap, "EMAIL_VERIFICATION_RELEASED_AT")
+	maxFailedLoginAttempts := envInt(envMap, "LOGIN_MAX_FAILED_ATTEMPTS", 5)
+	maxFailedLoginAttemptsPerIp := envInt(envMap, "LOGIN_MAX_FAILED_ATTEMPTS_PER_IP", 20)
+	loginLockoutBaseTime := envInt(envMap, "LOGIN_LOCKOUT_BASE_TIME", 30)
+	loginLockoutMaxTime := envInt(envMap, "LOGIN_LOCKOUT_MAX_TIME", 3600)
+
+	passwordHashMemory := envInt(envMap, "PASSWORD_HASH_MEMORY", 65536)
+	if passwordHashMemory <= 0 {
+		panic("Invalid PASSWORD_HASH_MEMORY value")
+	}
+
+	passwordHashIterations := envInt(envMap, "PASSWORD_HASH_ITERATIONS", 3)
+	if passwordHashIterations <= 0 {
+		panic("Invalid PASSWORD_HASH_ITERATIONS value")
+	}
+
+	passwordHashParallelism := envInt(envMap, "PASSWORD_HASH_PARALLELISM", 4)
+	if passwordHashParallelism <= 0 || passwordHashParallelism > 255 {
+		panic("Invalid PASSWORD_HASH_PARALLELISM value")
+	}
+
+	passwordHashMaxConcurrent := envInt(envMap, "PASSWORD_HASH_MAX_CONCURRENT", runtime.NumCPU())
+	if passwordHashMaxConcurrent <= 0 {
+		panic("Invalid PASSWORD_HASH_MAX_CONCURRENT value")
+	}
+
+	securityConfig = &model.SecurityConfig{
+		RequireEmailVerification:    requireEmailVerification,
+		EmailVerificationReleasedAt: emailVerificationReleasedAt,
+		MaxFailedLoginAttempts:      maxFailedLoginAttempts,
+		MaxFailedLoginAttemptsPerIp: maxFailedLoginAttemptsPerIp,
+		LoginLockoutBaseTime:        loginLockoutBaseTime,
+		LoginLockoutMaxTime:         loginLockoutMaxTime,
+		TwoFactorIssuer:             envMap["TWO_FACTOR_ISSUER"],
+		TwoFactorRequiredRoles: strings.FieldsFunc(envMap["TWO_FACTOR_REQUIRED_ROLES"], func(r rune) bool {
+			return r == ','
+		}),
+		PasswordHashMemory:        passwordHashMemory,
+		PasswordHashIterations:    passwordHashIterations,
+		PasswordHashParallelism:   passwordHashParallelism,
+		PasswordHashMaxConcurrent: passwordHashMaxConcurrent,
+	}
+
+	rabbitMqPort := envInt(envMap, "MSG_BROKER_PORT", 5672)
+	rabbitMqConfig = &model.RabbitMqConfig{
+		Host:     envMap["MSG_BROKER_HOST"],
+		Port:     rabbitMqPort,
+		Username: envMap["MSG_BROKER_USERNAME"],
+		Password: envMap["MSG_BROKER_PASSWORD"],
+		Vhost:    envMap["MSG_BROKER_VHOST"],
+	}
+
+	httpEndpoint = &model.HttpEndpoint{
+		UploadServerUrl: envMap["UPLOAD_SERVER_URL"],
+		ImageUrl:        envMap["IMAGE_URL"],
+		ClientId:        envMap["AUTH_CLIENT_ID"],
+	}
+
+	notifierConfig = &model.NotifierConfig{
+		Driver:    envString(envMap, "NOTIFIER_DRIVER", "file"),
+		OutboxDir: envMap["NOTIFIER_OUTBOX_DIR"],
 	}
-	return databaseConfig, serverConfig, logConfig, jwtConfig, mode
+	return databaseConfig, serverConfig, logConfig, jwtConfig, mailConfig, securityConfig, rabbitMqConfig, httpEndpoint, notifierConfig, mode
 }
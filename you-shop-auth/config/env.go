@@ -0,0 +1,53 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// envString reads an optional variable, the default is used when it's unset
+func envString(envMap map[string]string, key string, defaultValue string) string {
+	if value := envMap[key]; value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// envInt reads an optional integer variable, the default is used when it's unset
+func envInt(envMap map[string]string, key string, defaultValue int) int {
+	value := envMap[key]
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		panic("Invalid " + key + " value")
+	}
+	return parsed
+}
+
+// envBool reads an optional boolean variable, the default is used when it's unset
+func envBool(envMap map[string]string, key string, defaultValue bool) bool {
+	value := envMap[key]
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		panic("Invalid " + key + " value")
+	}
+	return parsed
+}
+
+// envTime reads an optional RFC 3339 time variable, the zero time is returned when it's unset
+func envTime(envMap map[string]string, key string) time.Time {
+	value := envMap[key]
+	if value == "" {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic("Invalid " + key + " value")
+	}
+	return parsed
+}
@@ -0,0 +1,9 @@
+package model
+
+type HttpEndpoint struct {
+	UploadServerUrl string
+	// Public url the stored images are served from, the avatar urls are built from it
+	ImageUrl string
+	// Client of the auth service itself, its service tokens are signed locally for outbound calls
+	ClientId string
+}
@@ -5,4 +5,10 @@ type JwtConfig struct {
 	DefaultAccessExpireTime  int
 	DefaultRefreshExpireTime int
 	Issuer                   string
+	// HS256 (default), RS256 or EdDSA
+	SigningAlgorithm string
+	// In hours
+	KeyRotationInterval int
+	// In minutes, lifetime of tokens issued with the client credentials grant
+	ClientAccessExpireTime int
 }
@@ -0,0 +1,17 @@
+package model
+
+type MailConfig struct {
+	// smtp or file
+	Driver   string
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	// Directory the file driver writes mails to
+	OutboxDir string
+	// Frontend page handling the reset, the token is appended as query parameter
+	PasswordResetUrl string
+	// Frontend page handling the email verification, the token is appended as query parameter
+	EmailVerificationUrl string
+}
@@ -0,0 +1,8 @@
+package model
+
+type NotifierConfig struct {
+	// mail or file
+	Driver string
+	// Directory the file driver writes notifications to
+	OutboxDir string
+}
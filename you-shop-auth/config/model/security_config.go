@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+type SecurityConfig struct {
+	// Reject login until the user has verified their email
+	RequireEmailVerification bool
+	// When email verification was released, users who registered before are marked verified at startup.
+	// Nobody is marked verified when it's zero
+	EmailVerificationReleasedAt time.Time
+	// Failed logins allowed before an account, or a client ip, gets locked out
+	MaxFailedLoginAttempts      int
+	MaxFailedLoginAttemptsPerIp int
+	// In seconds, the lockout doubles with every further failed login up to the max time
+	LoginLockoutBaseTime int
+	LoginLockoutMaxTime  int
+	// Shown by authenticator apps next to the account
+	TwoFactorIssuer string
+	// Users with any of these roles must log in with a second factor
+	TwoFactorRequiredRoles []string
+	// argon2id cost of new password hashes, memory is in KiB. Older hashes are upgraded on login
+	PasswordHashMemory      int
+	PasswordHashIterations  int
+	PasswordHashParallelism int
+	// Hashes computed at the same time, the others wait so a burst of logins can't exhaust the memory
+	PasswordHashMaxConcurrent int
+}
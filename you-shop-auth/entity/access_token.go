@@ -6,9 +6,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// AccessToken is only persisted once revoked, Id holds the jti claim of the token
 type AccessToken struct {
 	AuditEntity
 	Token     string
 	ClientId  uuid.UUID
+	UserId    uuid.UUID
+	ExpiresAt time.Time
 	RevokedAt time.Time
 }
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApiKey lets scripts act as the user without a login, it is limited to Scope and only its hash is stored
+type ApiKey struct {
+	AuditEntity
+	UserId uuid.UUID
+	Name   string
+	// First characters of the key so the user can tell their keys apart
+	Prefix  string
+	KeyHash string
+	// Comma separated scopes, a subset of the user's permissions when the key was created
+	Scope      string
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	RevokedAt  time.Time
+}
+
+func NewApiKey(userId uuid.UUID, name string, prefix string, keyHash string, scopes []string, expiresAt time.Time) *ApiKey {
+	return &ApiKey{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		UserId:    userId,
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		Scope:     strings.Join(scopes, ","),
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (a *ApiKey) IsRevoked() bool {
+	return !a.RevokedAt.IsZero()
+}
+
+func (a *ApiKey) IsExpired(current time.Time) bool {
+	return current.After(a.ExpiresAt)
+}
@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthAudit is an entry of the audit trail, one per published auth.* event
+type AuthAudit struct {
+	AuditEntity
+	// Routing key of the event, e.g. auth.login.succeeded
+	EventType string
+	// Nil when the event isn't tied to a known user
+	UserId uuid.UUID
+	// Admin who made the change, nil when the user acted on their own account
+	ActorId uuid.UUID
+	Ip      string
+	// Event specific data in JSON form
+	Detail     string
+	OccurredAt time.Time
+}
@@ -8,10 +8,34 @@ import (
 
 type AuthorizationCode struct {
 	AuditEntity
-	Code        string
-	ClientId    uuid.UUID
-	UserId      uuid.UUID
-	RedirectUri string
-	ExpiresAt   time.Time
-	Scopes      string
+	// SHA-256 hash of the code which was handed to the client
+	Code                string
+	ClientId            uuid.UUID
+	UserId              uuid.UUID
+	RedirectUri         string
+	ExpiresAt           time.Time
+	Scopes              string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	UsedAt              time.Time
+}
+
+func NewAuthorizationCode(codeHash string, clientId uuid.UUID, userId uuid.UUID, redirectUri string, scopes string, codeChallenge string, codeChallengeMethod string, expiresAt time.Time) *AuthorizationCode {
+	return &AuthorizationCode{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		Code:                codeHash,
+		ClientId:            clientId,
+		UserId:              userId,
+		RedirectUri:         redirectUri,
+		ExpiresAt:           expiresAt,
+		Scopes:              scopes,
+		CodeChallenge:       codeChallenge,
+		CodeChallengeMethod: codeChallengeMethod,
+	}
+}
+
+func (a *AuthorizationCode) IsExpired(current time.Time) bool {
+	return current.After(a.ExpiresAt)
 }
@@ -1,5 +1,7 @@
 package entity
 
+import "strings"
+
 type Client struct {
 	AuditEntity
 	Enabled      bool
@@ -8,5 +10,36 @@ type Client struct {
 	ClientName   string
 	Description  string
 	WebsiteUrl   string
-	RedirectUrl  string
+	// Comma separated list of allowed redirect uris
+	RedirectUrl string
+	// Space separated list of service scopes the client may request with the client credentials grant
+	Scopes string
+}
+
+// IsConfidential reports whether the client has a secret and must authenticate on the token endpoint
+func (c *Client) IsConfidential() bool {
+	return c.ClientSecret != ""
+}
+
+// IsRedirectUriAllowed checks the uri against the registered redirect uris with exact matching
+func (c *Client) IsRedirectUriAllowed(redirectUri string) bool {
+	for _, allowed := range strings.Split(c.RedirectUrl, ",") {
+		if strings.TrimSpace(allowed) == redirectUri {
+			return true
+		}
+	}
+	return false
+}
+
+// GetDefaultRedirectUri returns the registered redirect uri when the client only has one
+func (c *Client) GetDefaultRedirectUri() (string, bool) {
+	uris := strings.Split(c.RedirectUrl, ",")
+	if len(uris) != 1 || strings.TrimSpace(uris[0]) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(uris[0]), true
+}
+
+func (c *Client) GetScopes() []string {
+	return strings.Fields(c.Scopes)
 }
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Consent records the scopes a user granted to an OAuth client
+type Consent struct {
+	AuditEntity
+	UserId   uuid.UUID
+	ClientId uuid.UUID
+	// Space separated list of granted scopes
+	Scopes string
+}
+
+func NewConsent(userId uuid.UUID, clientId uuid.UUID, scopes []string) *Consent {
+	return &Consent{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		UserId:   userId,
+		ClientId: clientId,
+		Scopes:   strings.Join(scopes, " "),
+	}
+}
+
+func (c *Consent) GetScopes() []string {
+	return strings.Fields(c.Scopes)
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationToken proves the user owns the email it was mailed to
+type EmailVerificationToken struct {
+	MailedToken
+}
+
+func NewEmailVerificationToken(tokenHash string, userId uuid.UUID, expiresAt time.Time) *EmailVerificationToken {
+	return &EmailVerificationToken{
+		MailedToken: newMailedToken(tokenHash, userId, expiresAt),
+	}
+}
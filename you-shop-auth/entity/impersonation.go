@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Impersonation is an admin acting as a customer, its id is the jti of the impersonation token
+type Impersonation struct {
+	AuditEntity
+	AdminId uuid.UUID
+	UserId  uuid.UUID
+	// Why support needed to act as the user, e.g. a ticket number
+	Reason    string
+	Ip        string
+	ExpiresAt time.Time
+	// Set when the admin ended the impersonation before it expired
+	EndedAt time.Time
+}
+
+func NewImpersonation(adminId uuid.UUID, userId uuid.UUID, reason string, ip string, expiresAt time.Time) *Impersonation {
+	return &Impersonation{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		AdminId:   adminId,
+		UserId:    userId,
+		Reason:    reason,
+		Ip:        ip,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (i *Impersonation) IsEnded() bool {
+	return !i.EndedAt.IsZero()
+}
+
+func (i *Impersonation) IsActive(current time.Time) bool {
+	return !i.IsEnded() && current.Before(i.ExpiresAt)
+}
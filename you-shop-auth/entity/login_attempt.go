@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginAttempt counts the consecutive failed logins of an account or of a client ip
+type LoginAttempt struct {
+	AuditEntity
+	// user:<id> or ip:<address>, password reset requests are counted under reset-email:<email> and reset-ip:<address>
+	Key          string
+	FailedCount  int
+	LastFailedAt time.Time
+	LockedUntil  time.Time
+}
+
+func UserLoginAttemptKey(userId uuid.UUID) string {
+	return fmt.Sprintf("user:%s", userId)
+}
+
+func IpLoginAttemptKey(ip string) string {
+	return fmt.Sprintf("ip:%s", ip)
+}
+
+func EmailPasswordResetKey(email string) string {
+	return fmt.Sprintf("reset-email:%s", strings.ToLower(strings.TrimSpace(email)))
+}
+
+func IpPasswordResetKey(ip string) string {
+	return fmt.Sprintf("reset-ip:%s", ip)
+}
+
+func (l *LoginAttempt) IsLocked(current time.Time) bool {
+	return current.Before(l.LockedUntil)
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MailedToken is a single use token which was mailed to the user as a link
+type MailedToken struct {
+	AuditEntity
+	UserId uuid.UUID
+	// SHA-256 hash of the token which was mailed to the user
+	Token     string
+	ExpiresAt time.Time
+	UsedAt    time.Time
+}
+
+func newMailedToken(tokenHash string, userId uuid.UUID, expiresAt time.Time) MailedToken {
+	return MailedToken{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		UserId:    userId,
+		Token:     tokenHash,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (m *MailedToken) IsExpired(current time.Time) bool {
+	return current.After(m.ExpiresAt)
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OneTimeCode is a short numeric code sent to the user to log in without a password
+type OneTimeCode struct {
+	AuditEntity
+	UserId uuid.UUID
+	// email or sms
+	Channel string
+	// SHA-256 hash of the code which was sent to the user
+	Code           string
+	FailedAttempts int
+	ExpiresAt      time.Time
+	UsedAt         time.Time
+}
+
+func NewOneTimeCode(codeHash string, userId uuid.UUID, channel string, expiresAt time.Time) *OneTimeCode {
+	return &OneTimeCode{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		UserId:    userId,
+		Channel:   channel,
+		Code:      codeHash,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (o *OneTimeCode) IsExpired(current time.Time) bool {
+	return current.After(o.ExpiresAt)
+}
+
+func (o *OneTimeCode) IsUsed() bool {
+	return !o.UsedAt.IsZero()
+}
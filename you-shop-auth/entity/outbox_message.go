@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage is an event saved in the transaction of the change it announces. A job publishes it afterwards,
+// so the event is neither lost while the broker is down nor sent for a change which was rolled back
+type OutboxMessage struct {
+	AuditEntity
+	RoutingKey string
+	// Event in JSON form
+	Payload     string
+	PublishedAt time.Time
+}
+
+func NewOutboxMessage(routingKey string, payload any) (*OutboxMessage, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxMessage{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		RoutingKey: routingKey,
+		Payload:    string(data),
+	}, nil
+}
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PasswordResetToken struct {
+	MailedToken
+}
+
+func NewPasswordResetToken(tokenHash string, userId uuid.UUID, expiresAt time.Time) *PasswordResetToken {
+	return &PasswordResetToken{
+		MailedToken: newMailedToken(tokenHash, userId, expiresAt),
+	}
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCode lets a user sign in once without their authenticator
+type RecoveryCode struct {
+	AuditEntity
+	UserId uuid.UUID
+	// SHA-256 hash of the code which was shown to the user
+	Code   string
+	UsedAt time.Time
+}
+
+func NewRecoveryCode(userId uuid.UUID, codeHash string) *RecoveryCode {
+	return &RecoveryCode{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		UserId: userId,
+		Code:   codeHash,
+	}
+}
@@ -1,3 +1,46 @@
 package entity
 
-type RefreshToken struct{}
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefreshToken struct {
+	AuditEntity
+	UserId     uuid.UUID
+	FamilyId   uuid.UUID
+	Device     string
+	ExpiresAt  time.Time
+	RevokedAt  time.Time
+	ReplacedBy uuid.UUID
+	// Set when the token was issued to an OAuth client, the access tokens are then limited to Scope
+	ClientId string
+	Scope    string
+}
+
+// NewRefreshToken creates a refresh token that belongs to the given family.
+// Pass uuid.Nil as familyId to start a new family (a fresh login).
+func NewRefreshToken(userId uuid.UUID, familyId uuid.UUID, device string, expiresAt time.Time) *RefreshToken {
+	id := uuid.New()
+	if familyId == uuid.Nil {
+		familyId = id
+	}
+	return &RefreshToken{
+		AuditEntity: AuditEntity{
+			Id: id,
+		},
+		UserId:    userId,
+		FamilyId:  familyId,
+		Device:    device,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (r *RefreshToken) IsRevoked() bool {
+	return !r.RevokedAt.IsZero()
+}
+
+func (r *RefreshToken) IsExpired(current time.Time) bool {
+	return current.After(r.ExpiresAt)
+}
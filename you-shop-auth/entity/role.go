@@ -1,7 +1,22 @@
 package entity
 
+import "github.com/google/uuid"
+
 type Role struct {
 	AuditEntity
 	Name        string
 	Description string
+	// The role inherits its parent, e.g. admin inherits staff which inherits guest. uuid.Nil when it has none
+	ParentId uuid.UUID
+}
+
+func NewRole(name string, description string, parentId uuid.UUID) *Role {
+	return &Role{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		Name:        name,
+		Description: description,
+		ParentId:    parentId,
+	}
 }
@@ -1,7 +1,20 @@
 package entity
 
+import "github.com/google/uuid"
+
+// Scope is a permission such as product:write, granted to users directly or through their roles
 type Scope struct {
 	AuditEntity
 	Name        string
 	Description string
 }
+
+func NewScope(name string, description string) *Scope {
+	return &Scope{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		Name:        name,
+		Description: description,
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a login of the user on a device, it shares its id with the family of refresh tokens issued by the login
+type Session struct {
+	AuditEntity
+	UserId uuid.UUID
+	// Set when the session was started by an OAuth client
+	ClientId   string
+	UserAgent  string
+	Ip         string
+	LastUsedAt time.Time
+	// Moves forward every time the refresh token is rotated
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+func NewSession(refreshToken RefreshToken, ip string) *Session {
+	return &Session{
+		AuditEntity: AuditEntity{
+			Id: refreshToken.FamilyId,
+		},
+		UserId:    refreshToken.UserId,
+		ClientId:  refreshToken.ClientId,
+		UserAgent: refreshToken.Device,
+		Ip:        ip,
+		ExpiresAt: refreshToken.ExpiresAt,
+	}
+}
+
+func (s *Session) IsRevoked() bool {
+	return !s.RevokedAt.IsZero()
+}
+
+func (s *Session) IsActive(current time.Time) bool {
+	return !s.IsRevoked() && current.Before(s.ExpiresAt)
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// SigningKey is an asymmetric key pair used to sign tokens, Id is published as the `kid` header.
+// Once rotated the key is no longer used for signing but its public key stays available until ExpiresAt.
+type SigningKey struct {
+	AuditEntity
+	Algorithm  string
+	PrivateKey string
+	PublicKey  string
+	RotatedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+func (s *SigningKey) IsRotated() bool {
+	return !s.RotatedAt.IsZero()
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactor holds the TOTP secret of a user, it is pending until the first code was verified
+type TwoFactor struct {
+	AuditEntity
+	UserId uuid.UUID
+	// Base32 encoded TOTP secret
+	Secret    string
+	EnabledAt time.Time
+	// Time step of the last accepted code, a code can't be used twice
+	LastUsedStep int64
+}
+
+func NewTwoFactor(userId uuid.UUID, secret string) *TwoFactor {
+	return &TwoFactor{
+		AuditEntity: AuditEntity{
+			Id: uuid.New(),
+		},
+		UserId: userId,
+		Secret: secret,
+	}
+}
+
+func (t *TwoFactor) IsEnabled() bool {
+	return !t.EnabledAt.IsZero()
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"strings"
+	"time"
 
 	"github.com/TechwizsonORG/auth-service/err"
 	"github.com/TechwizsonORG/auth-service/util"
@@ -18,6 +19,7 @@ type User struct {
 	AvatarUrl          string
 	IsActive           bool
 	PhoneNumber        string
+	EmailVerifiedAt    time.Time
 }
 
 func CreateUser(username string, password string, email string, avatarUrl string, phoneNumber string) (*User, *err.AppError) {
@@ -39,3 +41,31 @@ func CreateUser(username string, password string, email string, avatarUrl string
 		PhoneNumber:        phoneNumber,
 	}, nil
 }
+
+func (u *User) IsEmailVerified() bool {
+	return !u.EmailVerifiedAt.IsZero()
+}
+
+func (u *User) ChangeUsername(username string) {
+	u.Username = username
+	u.NormalizedUsername = strings.ToUpper(username)
+}
+
+// ChangeEmail also drops the verification, the new email has to be verified again
+func (u *User) ChangeEmail(email string) {
+	u.Email = email
+	u.NormalizedEmail = strings.ToUpper(email)
+	u.EmailVerifiedAt = time.Time{}
+}
+
+// Anonymize strips every personal detail from a deleted user, the id is kept so audits still resolve
+func (u *User) Anonymize(deletedAt time.Time) {
+	placeholder := "deleted_" + strings.ReplaceAll(u.Id.String(), "-", "")
+	u.ChangeUsername(placeholder)
+	u.ChangeEmail(placeholder + "@deleted.invalid")
+	u.PasswordHash = ""
+	u.AvatarUrl = ""
+	u.PhoneNumber = ""
+	u.IsActive = false
+	u.DeletedAt = deletedAt
+}
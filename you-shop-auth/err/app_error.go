@@ -1,6 +1,9 @@
 package err
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type AppError struct {
 	Code    int
@@ -38,6 +41,73 @@ func NewWrongUserNameOrPasswordError() *AppError {
 	return NewAppError(401, "Wrong username or password", "Wrong username or password", nil)
 }
 
+func NewPasswordResetError(message string) *AppError {
+	return NewAppError(400, "Password Reset Error", message, nil)
+}
+
+func NewEmailVerificationError(message string) *AppError {
+	return NewAppError(400, "Email Verification Error", message, nil)
+}
+
+func NewEmailNotVerifiedError() *AppError {
+	return NewAppError(403, "Email not verified", "Email has not been verified yet", nil)
+}
+
+func NewLoginLockedError(retryAfter time.Duration) *AppError {
+	return NewAppError(429, "Too many failed logins", "Too many failed login attempts, try again later", map[string]int{
+		"retryAfter": int(retryAfter.Seconds()) + 1,
+	})
+}
+
+func NewTooManyRequestsError(message string) *AppError {
+	return NewAppError(429, "Too many requests", message, nil)
+}
+
+func NewTwoFactorError(message string) *AppError {
+	return NewAppError(400, "Two Factor Error", message, nil)
+}
+
+func NewInvalidTwoFactorCodeError() *AppError {
+	return NewAppError(401, "Invalid two factor code", "Two factor code is invalid", nil)
+}
+
+func NewOneTimeCodeError(message string) *AppError {
+	return NewAppError(400, "One Time Code Error", message, nil)
+}
+
+func NewInvalidOneTimeCodeError() *AppError {
+	return NewAppError(401, "Invalid one time code", "One time code is invalid or expired", nil)
+}
+
+func NewImpersonationError(message string) *AppError {
+	return NewAppError(400, "Impersonation Error", message, nil)
+}
+
+func NewProfileError(message string) *AppError {
+	return NewAppError(400, "Profile Error", message, nil)
+}
+
+func NewPermissionError(message string) *AppError {
+	return NewAppError(400, "Permission Error", message, nil)
+}
+
+func NewUserManagementError(message string) *AppError {
+	return NewAppError(400, "User Management Error", message, nil)
+}
+
+func NewApiKeyError(message string) *AppError {
+	return NewAppError(400, "Api Key Error", message, nil)
+}
+
+func NewNotFoundError(message string) *AppError {
+	return NewAppError(404, "Not Found", message, nil)
+}
+
 func NewUnhandledError() *AppError {
 	return NewAppError(500, "Internal Server Error", "Internal Server Error", nil)
 }
+
+// NewOAuthError creates an error whose title is an RFC 6749 error code such as invalid_grant
+func NewOAuthError(code int, errorCode string, description string) *AppError {
+	return NewAppError(code, errorCode, description, nil)
+}
@@ -0,0 +1,124 @@
+package image
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const imageUploadScope = "image:upload"
+
+type HttpImageUploader struct {
+	logger       zerolog.Logger
+	httpEndpoint model.HttpEndpoint
+	httpClient   *http.Client
+	clientRepo   usecase.ClientRepository
+	tokenService token.TokenInterface
+}
+
+func NewHttpImageUploader(logger zerolog.Logger, httpEndpoint model.HttpEndpoint, clientRepo usecase.ClientRepository, tokenService token.TokenInterface) *HttpImageUploader {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Http Image Uploader").
+		Logger()
+	return &HttpImageUploader{
+		logger:       logger,
+		httpEndpoint: httpEndpoint,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		clientRepo:   clientRepo,
+		tokenService: tokenService,
+	}
+}
+
+func (h *HttpImageUploader) Upload(ownerId uuid.UUID, fileName string, alt string, content io.Reader) (string, error) {
+	accessToken, tokenErr := h.getServiceToken()
+	if tokenErr != nil {
+		return "", tokenErr
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("image_file", fileName)
+	if err != nil {
+		return "", err
+	}
+	if _, err = io.Copy(part, content); err != nil {
+		return "", err
+	}
+	writer.WriteField("alt", alt)
+	writer.WriteField("owner_id", ownerId.String())
+	if err = writer.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/upload", h.httpEndpoint.UploadServerUrl), body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	res, err := h.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("image service answered %d", res.StatusCode)
+	}
+
+	return h.imageUrl(res.Header.Get("Location"))
+}
+
+// imageUrl turns the location the image service answered into the url clients load the image from
+func (h *HttpImageUploader) imageUrl(location string) (string, error) {
+	id, err := uuid.Parse(location[strings.LastIndex(location, "/")+1:])
+	if err != nil {
+		return "", fmt.Errorf("image service answered an invalid location %q", location)
+	}
+	if h.httpEndpoint.ImageUrl != "" {
+		return fmt.Sprintf("%s/%s", strings.TrimRight(h.httpEndpoint.ImageUrl, "/"), id), nil
+	}
+	base, err := url.Parse(h.httpEndpoint.UploadServerUrl)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(ref).String(), nil
+}
+
+// getServiceToken signs a client credentials token for the auth service itself, no need to call our own token endpoint
+func (h *HttpImageUploader) getServiceToken() (string, error) {
+	client, err := h.clientRepo.GetClientByClientId(h.httpEndpoint.ClientId)
+	if err != nil {
+		return "", err
+	}
+	if client == nil || !client.Enabled {
+		return "", fmt.Errorf("client %s of the auth service is not registered", h.httpEndpoint.ClientId)
+	}
+	if !util.ContainsAll([]string{imageUploadScope}, client.GetScopes()) {
+		return "", fmt.Errorf("client %s of the auth service lacks the %s scope", h.httpEndpoint.ClientId, imageUploadScope)
+	}
+	accessToken, appErr := h.tokenService.GenerateClientToken(*client, []string{imageUploadScope})
+	if appErr != nil {
+		return "", errors.New(appErr.Message)
+	}
+	return accessToken, nil
+}
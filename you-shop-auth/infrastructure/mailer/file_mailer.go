@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/usecase/mailer"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// FileMailer writes every mail as an .eml file into the outbox directory instead of sending it,
+// it is meant for local development and tests
+type FileMailer struct {
+	logger     zerolog.Logger
+	mailConfig model.MailConfig
+}
+
+func NewFileMailer(logger zerolog.Logger, mailConfig model.MailConfig) *FileMailer {
+	logger = logger.
+		With().
+		Str("Infrastructure", "File Mailer").
+		Logger()
+	return &FileMailer{
+		logger:     logger,
+		mailConfig: mailConfig,
+	}
+}
+
+func (m *FileMailer) Send(mail mailer.Mail) error {
+	if err := os.MkdirAll(m.mailConfig.OutboxDir, 0755); err != nil {
+		m.logger.Error().Err(err).Msg("Failed to create outbox directory")
+		return err
+	}
+	fileName := fmt.Sprintf("%s-%s.eml", util.GetCurrentUtcTime(7).Format("20060102150405"), uuid.New())
+	path := filepath.Join(m.mailConfig.OutboxDir, fileName)
+	if err := os.WriteFile(path, buildMessage(m.mailConfig.From, mail), 0644); err != nil {
+		m.logger.Error().Err(err).Msgf("Failed to write mail to %s", path)
+		return err
+	}
+	m.logger.Debug().Msgf("Mail %q written to %s", mail.Subject, path)
+	return nil
+}
@@ -0,0 +1,20 @@
+package mailer
+
+import (
+	"github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/usecase/mailer"
+	"github.com/rs/zerolog"
+)
+
+// NewMailer picks the implementation from the configured driver, an unknown or missing driver stops the service
+// instead of silently writing mails to disk
+func NewMailer(logger zerolog.Logger, mailConfig model.MailConfig) mailer.Mailer {
+	switch mailConfig.Driver {
+	case "smtp":
+		return NewSmtpMailer(logger, mailConfig)
+	case "file":
+		return NewFileMailer(logger, mailConfig)
+	default:
+		panic("Invalid MAIL_DRIVER value")
+	}
+}
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/usecase/mailer"
+	"github.com/rs/zerolog"
+)
+
+type SmtpMailer struct {
+	logger     zerolog.Logger
+	mailConfig model.MailConfig
+}
+
+func NewSmtpMailer(logger zerolog.Logger, mailConfig model.MailConfig) *SmtpMailer {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Smtp Mailer").
+		Logger()
+	return &SmtpMailer{
+		logger:     logger,
+		mailConfig: mailConfig,
+	}
+}
+
+func (m *SmtpMailer) Send(mail mailer.Mail) error {
+	var auth smtp.Auth
+	if m.mailConfig.Username != "" {
+		auth = smtp.PlainAuth("", m.mailConfig.Username, m.mailConfig.Password, m.mailConfig.Host)
+	}
+	addr := fmt.Sprintf("%s:%d", m.mailConfig.Host, m.mailConfig.Port)
+	if err := smtp.SendMail(addr, auth, m.mailConfig.From, mail.To, buildMessage(m.mailConfig.From, mail)); err != nil {
+		m.logger.Error().Err(err).Msgf("Failed to send mail to %s", strings.Join(mail.To, ","))
+		return err
+	}
+	return nil
+}
+
+func buildMessage(from string, mail mailer.Mail) []byte {
+	message := strings.Builder{}
+	message.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	message.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(mail.To, ", ")))
+	message.WriteString(fmt.Sprintf("Subject: %s\r\n", mail.Subject))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=\"UTF-8\"\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(mail.Body)
+	return []byte(message.String())
+}
@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/usecase/notifier"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// FileNotifier writes every notification as a text file into the outbox directory instead of delivering it,
+// it stands in for the email and sms providers during local development
+type FileNotifier struct {
+	logger         zerolog.Logger
+	notifierConfig model.NotifierConfig
+}
+
+func NewFileNotifier(logger zerolog.Logger, notifierConfig model.NotifierConfig) *FileNotifier {
+	logger = logger.
+		With().
+		Str("Infrastructure", "File Notifier").
+		Logger()
+	return &FileNotifier{
+		logger:         logger,
+		notifierConfig: notifierConfig,
+	}
+}
+
+func (n *FileNotifier) Supports(channel notifier.Channel) bool {
+	return true
+}
+
+func (n *FileNotifier) Notify(notification notifier.Notification) error {
+	if err := os.MkdirAll(n.notifierConfig.OutboxDir, 0755); err != nil {
+		n.logger.Error().Err(err).Msg("Failed to create outbox directory")
+		return err
+	}
+	fileName := fmt.Sprintf("%s-%s-%s.txt", util.GetCurrentUtcTime(7).Format("20060102150405"), notification.Channel, uuid.New())
+	path := filepath.Join(n.notifierConfig.OutboxDir, fileName)
+	content := fmt.Sprintf("Channel: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s", notification.Channel, notification.To, notification.Subject, notification.Body)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		n.logger.Error().Err(err).Msgf("Failed to write notification to %s", path)
+		return err
+	}
+	n.logger.Debug().Msgf("%s notification written to %s", notification.Channel, path)
+	return nil
+}
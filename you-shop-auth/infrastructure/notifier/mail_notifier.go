@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/TechwizsonORG/auth-service/usecase/mailer"
+	"github.com/TechwizsonORG/auth-service/usecase/notifier"
+	"github.com/rs/zerolog"
+)
+
+// MailNotifier delivers email notifications through the mailer, there is no sms provider behind it yet
+type MailNotifier struct {
+	logger zerolog.Logger
+	mailer mailer.Mailer
+}
+
+func NewMailNotifier(logger zerolog.Logger, mailer mailer.Mailer) *MailNotifier {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Mail Notifier").
+		Logger()
+	return &MailNotifier{
+		logger: logger,
+		mailer: mailer,
+	}
+}
+
+func (n *MailNotifier) Supports(channel notifier.Channel) bool {
+	return channel == notifier.EmailChannel
+}
+
+func (n *MailNotifier) Notify(notification notifier.Notification) error {
+	if !n.Supports(notification.Channel) {
+		return fmt.Errorf("channel %s is not supported by the mail notifier", notification.Channel)
+	}
+	return n.mailer.Send(mailer.Mail{
+		To:      []string{notification.To},
+		Subject: notification.Subject,
+		Body:    notification.Body,
+	})
+}
@@ -0,0 +1,21 @@
+package notifier
+
+import (
+	"github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/usecase/mailer"
+	"github.com/TechwizsonORG/auth-service/usecase/notifier"
+	"github.com/rs/zerolog"
+)
+
+// NewNotifier picks the implementation from the configured driver, an unknown or missing driver stops the service
+// instead of silently writing login codes to disk
+func NewNotifier(logger zerolog.Logger, notifierConfig model.NotifierConfig, mailer mailer.Mailer) notifier.Notifier {
+	switch notifierConfig.Driver {
+	case "mail":
+		return NewMailNotifier(logger, mailer)
+	case "file":
+		return NewFileNotifier(logger, notifierConfig)
+	default:
+		panic("Invalid NOTIFIER_DRIVER value")
+	}
+}
@@ -0,0 +1,141 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue"
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/rs/zerolog"
+)
+
+type DefaultMessageQueue struct {
+	rabbitMqConfig model.RabbitMqConfig
+	logger         zerolog.Logger
+	// The publishing connection and channel are opened on the first message and kept,
+	// they are opened again once the broker closed them
+	mu      sync.Mutex
+	conn    *amqp091.Connection
+	channel *amqp091.Channel
+}
+
+func NewDefaultMessageQueue(rabbitMq model.RabbitMqConfig, logger zerolog.Logger) *DefaultMessageQueue {
+	logger = logger.
+		With().
+		Str("infrastructure", "rabbitMQ").
+		Logger()
+
+	return &DefaultMessageQueue{
+		rabbitMqConfig: rabbitMq,
+		logger:         logger,
+	}
+}
+
+func (mq *DefaultMessageQueue) failOnError(err error, msg string) bool {
+	if err != nil {
+		mq.logger.Error().Err(err).Msg(msg)
+		return true
+	}
+	return false
+}
+
+// getChannel returns the publishing channel, mq.mu must be held
+func (mq *DefaultMessageQueue) getChannel() (*amqp091.Channel, error) {
+	if mq.channel != nil && !mq.channel.IsClosed() {
+		return mq.channel, nil
+	}
+	if mq.conn == nil || mq.conn.IsClosed() {
+		conn, err := amqp091.Dial(mq.rabbitMqConfig.GetAmqpServerUrl())
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect RabbitMQ: %w", err)
+		}
+		mq.conn = conn
+	}
+	ch, err := mq.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a channel: %w", err)
+	}
+	mq.channel = ch
+	return ch, nil
+}
+
+func (mq *DefaultMessageQueue) Publish(exchangeConfig messagequeue.ExchangeConfig, queueConfig messagequeue.QueueConfig, data any) (publishErr error) {
+	defer func() {
+		if r := recover(); r != nil {
+			publishErr = fmt.Errorf("publish panicked: %v", r)
+		}
+		mq.failOnError(publishErr, "failed to publish message")
+	}()
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to convert data into json: %w", err)
+	}
+
+	mq.mu.Lock()
+	defer mq.mu.Unlock()
+	ch, err := mq.getChannel()
+	if err != nil {
+		return err
+	}
+
+	err = ch.ExchangeDeclare(exchangeConfig.ExchangeName, string(exchangeConfig.Type), exchangeConfig.Durable, exchangeConfig.AutoDelete, exchangeConfig.Internal, exchangeConfig.NoWait, nil)
+	if err != nil {
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	err = ch.Publish(exchangeConfig.ExchangeName, queueConfig.RoutingKey, false, false, amqp091.Publishing{
+		ContentType: "text/plain",
+		// Kept on disk by durable queues so a broker restart doesn't lose them
+		DeliveryMode: amqp091.Persistent,
+		Body:         jsonData,
+	})
+	if err != nil {
+		return err
+	}
+	mq.logger.Debug().Msg("Published message successfully")
+	mq.logger.Trace().Msgf("value %s", jsonData)
+	return nil
+}
+
+func (mq *DefaultMessageQueue) Consume(exchangeConfig messagequeue.ExchangeConfig, queueConfig messagequeue.QueueConfig, handler func(data string) error) {
+	conn, err := amqp091.Dial(mq.rabbitMqConfig.GetAmqpServerUrl())
+	if mq.failOnError(err, "failed to connect RabbitMQ") {
+		return
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if mq.failOnError(err, "failed to open a channel") {
+		return
+	}
+	defer ch.Close()
+
+	err = ch.ExchangeDeclare(exchangeConfig.ExchangeName, string(exchangeConfig.Type), exchangeConfig.Durable, exchangeConfig.AutoDelete, exchangeConfig.Internal, exchangeConfig.NoWait, nil)
+	if mq.failOnError(err, "failed to declare exchange") {
+		return
+	}
+
+	q, err := ch.QueueDeclare(queueConfig.QueueName, queueConfig.Durable, queueConfig.DeleteUnused, queueConfig.Exclusive, queueConfig.NoWait, nil)
+	if mq.failOnError(err, "failed to declare queue") {
+		return
+	}
+
+	err = ch.QueueBind(q.Name, queueConfig.RoutingKey, exchangeConfig.ExchangeName, queueConfig.NoWait, nil)
+	if mq.failOnError(err, "Failed to bind queue") {
+		return
+	}
+
+	msgs, err := ch.Consume(queueConfig.QueueName, "", true, queueConfig.Exclusive, false, queueConfig.NoWait, nil)
+	if mq.failOnError(err, "failed to register a consumer") {
+		return
+	}
+
+	for d := range msgs {
+		if err := handler(string(d.Body)); err != nil {
+			mq.logger.Error().Err(err).Msg("")
+		}
+	}
+}
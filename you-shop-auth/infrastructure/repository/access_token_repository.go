@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type AccessTokenRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewAccessTokenRepository(db *sql.DB, logger zerolog.Logger) *AccessTokenRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Access Token Repository").
+		Logger()
+	return &AccessTokenRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (a *AccessTokenRepository) IsAccessTokenRevoked(id uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM revoked_access_token rat WHERE rat.id = $1
+		)
+	`
+	var isRevoked bool
+	if err := a.db.QueryRow(query, id).Scan(&isRevoked); err != nil {
+		return false, err
+	}
+	return isRevoked, nil
+}
+
+// RevokeAccessToken returns false when the token was already revoked
+func (a *AccessTokenRepository) RevokeAccessToken(accessToken entity.AccessToken) (bool, error) {
+	query := `
+		INSERT INTO revoked_access_token (id, user_id, expires_at, revoked_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4, $4)
+		ON CONFLICT (id) DO NOTHING
+	`
+	revokedAt := accessToken.RevokedAt
+	if revokedAt.IsZero() {
+		revokedAt = util.GetCurrentUtcTime(7)
+	}
+	result, err := a.db.Exec(query, accessToken.Id, accessToken.UserId, accessToken.ExpiresAt, revokedAt)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (a *AccessTokenRepository) DeleteExpiredAccessTokens(before time.Time) (int64, error) {
+	query := `
+		DELETE FROM revoked_access_token
+		WHERE expires_at < $1
+	`
+	result, err := a.db.Exec(query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
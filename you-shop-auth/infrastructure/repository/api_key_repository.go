@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type ApiKeyRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewApiKeyRepository(db *sql.DB, logger zerolog.Logger) *ApiKeyRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Api Key Repository").
+		Logger()
+	return &ApiKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ApiKeyRepository) GetApiKeyByHash(keyHash string) (*entity.ApiKey, error) {
+	query := `
+		SELECT
+			k.id,
+			k.created_at,
+			k.updated_at,
+			k.user_id,
+			k.name,
+			k.prefix,
+			k.key_hash,
+			k.scope,
+			k.expires_at,
+			k.last_used_at,
+			k.revoked_at
+		FROM api_key k
+		WHERE k.key_hash = $1
+	`
+	var apiKey entity.ApiKey
+	var lastUsedAt, revokedAt sql.NullTime
+	scanErr := r.db.QueryRow(query, keyHash).Scan(
+		&apiKey.Id,
+		&apiKey.CreatedAt,
+		&apiKey.UpdatedAt,
+		&apiKey.UserId,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.KeyHash,
+		&apiKey.Scope,
+		&apiKey.ExpiresAt,
+		&lastUsedAt,
+		&revokedAt,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	apiKey.LastUsedAt = lastUsedAt.Time
+	apiKey.RevokedAt = revokedAt.Time
+	return &apiKey, nil
+}
+
+// GetApiKeysByUserId returns the keys which aren't revoked, expired ones included so the user can clean them up
+func (r *ApiKeyRepository) GetApiKeysByUserId(userId uuid.UUID) ([]entity.ApiKey, error) {
+	query := `
+		SELECT
+			k.id,
+			k.created_at,
+			k.updated_at,
+			k.user_id,
+			k.name,
+			k.prefix,
+			k.scope,
+			k.expires_at,
+			k.last_used_at
+		FROM api_key k
+		WHERE k.user_id = $1 AND k.revoked_at IS NULL
+		ORDER BY k.created_at DESC
+	`
+	rows, err := r.db.Query(query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys := []entity.ApiKey{}
+	for rows.Next() {
+		var apiKey entity.ApiKey
+		var lastUsedAt sql.NullTime
+		if err := rows.Scan(
+			&apiKey.Id,
+			&apiKey.CreatedAt,
+			&apiKey.UpdatedAt,
+			&apiKey.UserId,
+			&apiKey.Name,
+			&apiKey.Prefix,
+			&apiKey.Scope,
+			&apiKey.ExpiresAt,
+			&lastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		apiKey.LastUsedAt = lastUsedAt.Time
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return apiKeys, rows.Err()
+}
+
+func (r *ApiKeyRepository) AddApiKey(apiKey entity.ApiKey) (entity.ApiKey, error) {
+	query := `
+		INSERT INTO api_key (id, user_id, name, prefix, key_hash, scope, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	current := util.GetCurrentUtcTime(7)
+	result, err := r.db.Exec(query, apiKey.Id, apiKey.UserId, apiKey.Name, apiKey.Prefix, apiKey.KeyHash, apiKey.Scope, apiKey.ExpiresAt, current, current)
+	if err != nil {
+		return entity.ApiKey{}, err
+	}
+	if rows, err := result.RowsAffected(); rows == 0 || err != nil {
+		return entity.ApiKey{}, errors.New("api key haven't been created")
+	}
+	apiKey.CreatedAt = current
+	apiKey.UpdatedAt = current
+	return apiKey, nil
+}
+
+func (r *ApiKeyRepository) TouchApiKey(id uuid.UUID, lastUsedAt time.Time) error {
+	query := `
+		UPDATE api_key
+		SET last_used_at = $1
+		WHERE id = $2
+	`
+	_, err := r.db.Exec(query, lastUsedAt, id)
+	return err
+}
+
+// RevokeApiKey only revokes a key of the given user
+func (r *ApiKeyRepository) RevokeApiKey(userId uuid.UUID, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE api_key
+		SET
+			revoked_at = $1,
+			updated_at = $1
+		WHERE id = $2 AND user_id = $3 AND revoked_at IS NULL
+	`
+	result, err := r.db.Exec(query, util.GetCurrentUtcTime(7), id, userId)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *ApiKeyRepository) RevokeUserApiKeys(userId uuid.UUID) error {
+	query := `
+		UPDATE api_key
+		SET
+			revoked_at = $1,
+			updated_at = $1
+		WHERE user_id = $2 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, util.GetCurrentUtcTime(7), userId)
+	return err
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type AuthAuditRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewAuthAuditRepository(db *sql.DB, logger zerolog.Logger) *AuthAuditRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Auth Audit Repository").
+		Logger()
+	return &AuthAuditRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AuthAuditRepository) AddAuthAudit(authAudit entity.AuthAudit) error {
+	query := `
+		INSERT INTO auth_audit (id, event_type, user_id, actor_id, ip, detail, occurred_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7, $7)
+	`
+	_, err := r.db.Exec(
+		query,
+		authAudit.Id,
+		authAudit.EventType,
+		nullUuid(authAudit.UserId),
+		nullUuid(authAudit.ActorId),
+		authAudit.Ip,
+		authAudit.Detail,
+		authAudit.OccurredAt,
+	)
+	return err
+}
+
+// GetAuthAudits pages through the audit trail, the latest first. A nil userId or a zero time disables its filter
+func (r *AuthAuditRepository) GetAuthAudits(userId uuid.UUID, from time.Time, to time.Time, pageIndex int, pageSize int) ([]entity.AuthAudit, error) {
+	query := `
+		SELECT
+			a.id,
+			a.created_at,
+			a.updated_at,
+			a.event_type,
+			a.user_id,
+			a.actor_id,
+			a.ip,
+			a.detail,
+			a.occurred_at
+		FROM auth_audit a
+		WHERE ($1::uuid IS NULL OR a.user_id = $1)
+			AND ($2::timestamp IS NULL OR a.occurred_at >= $2)
+			AND ($3::timestamp IS NULL OR a.occurred_at < $3)
+		ORDER BY a.occurred_at DESC, a.id
+		LIMIT $5
+		OFFSET $4
+	`
+	rows, err := r.db.Query(query, nullUuid(userId), nullTime(from), nullTime(to), pageIndex*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	authAudits := []entity.AuthAudit{}
+	for rows.Next() {
+		var authAudit entity.AuthAudit
+		var auditUserId, actorId uuid.NullUUID
+		if err := rows.Scan(
+			&authAudit.Id,
+			&authAudit.CreatedAt,
+			&authAudit.UpdatedAt,
+			&authAudit.EventType,
+			&auditUserId,
+			&actorId,
+			&authAudit.Ip,
+			&authAudit.Detail,
+			&authAudit.OccurredAt,
+		); err != nil {
+			return nil, err
+		}
+		authAudit.UserId = auditUserId.UUID
+		authAudit.ActorId = actorId.UUID
+		authAudits = append(authAudits, authAudit)
+	}
+	return authAudits, rows.Err()
+}
+
+func (r *AuthAuditRepository) CountAuthAudits(userId uuid.UUID, from time.Time, to time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM auth_audit a
+		WHERE ($1::uuid IS NULL OR a.user_id = $1)
+			AND ($2::timestamp IS NULL OR a.occurred_at >= $2)
+			AND ($3::timestamp IS NULL OR a.occurred_at < $3)
+	`
+	var count int
+	err := r.db.QueryRow(query, nullUuid(userId), nullTime(from), nullTime(to)).Scan(&count)
+	return count, err
+}
+
+func nullUuid(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type AuthorizationCodeRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewAuthorizationCodeRepository(db *sql.DB, logger zerolog.Logger) *AuthorizationCodeRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Authorization Code Repository").
+		Logger()
+	return &AuthorizationCodeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *AuthorizationCodeRepository) GetAuthorizationCode(code string) (*entity.AuthorizationCode, error) {
+	query := `
+		SELECT
+			ac.id,
+			ac.created_at,
+			ac.updated_at,
+			ac.code,
+			ac.client_id,
+			ac.user_id,
+			ac.redirect_uri,
+			ac.expires_at,
+			ac.scopes,
+			ac.code_challenge,
+			ac.code_challenge_method,
+			ac.used_at
+		FROM authorization_code ac
+		WHERE ac.code = $1
+	`
+	var authorizationCode entity.AuthorizationCode
+	var usedAt sql.NullTime
+	scanErr := r.db.QueryRow(query, code).Scan(
+		&authorizationCode.Id,
+		&authorizationCode.CreatedAt,
+		&authorizationCode.UpdatedAt,
+		&authorizationCode.Code,
+		&authorizationCode.ClientId,
+		&authorizationCode.UserId,
+		&authorizationCode.RedirectUri,
+		&authorizationCode.ExpiresAt,
+		&authorizationCode.Scopes,
+		&authorizationCode.CodeChallenge,
+		&authorizationCode.CodeChallengeMethod,
+		&usedAt,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	authorizationCode.UsedAt = usedAt.Time
+	return &authorizationCode, nil
+}
+
+func (r *AuthorizationCodeRepository) AddAuthorizationCode(code entity.AuthorizationCode) (entity.AuthorizationCode, error) {
+	query := `
+		INSERT INTO authorization_code (id, code, client_id, user_id, redirect_uri, expires_at, scopes, code_challenge, code_challenge_method, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+	`
+	current := util.GetCurrentUtcTime(7)
+	_, err := r.db.Exec(query, code.Id, code.Code, code.ClientId, code.UserId, code.RedirectUri, code.ExpiresAt, code.Scopes, code.CodeChallenge, code.CodeChallengeMethod, current)
+	if err != nil {
+		return entity.AuthorizationCode{}, err
+	}
+	code.CreatedAt = current
+	code.UpdatedAt = current
+	return code, nil
+}
+
+// UseAuthorizationCode marks the code as used, false means the code was already used
+func (r *AuthorizationCodeRepository) UseAuthorizationCode(id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE authorization_code
+		SET
+			used_at = $1,
+			updated_at = $1
+		WHERE id = $2 AND used_at IS NULL
+	`
+	result, err := r.db.Exec(query, util.GetCurrentUtcTime(7), id)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
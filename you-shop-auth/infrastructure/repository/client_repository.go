@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type ClientRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewClientRepository(db *sql.DB, logger zerolog.Logger) *ClientRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Client Repository").
+		Logger()
+	return &ClientRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const selectClientQuery = `
+	SELECT
+		c.id,
+		c.created_at,
+		c.updated_at,
+		c.enabled,
+		c.client_id,
+		COALESCE(c.client_secret, ''),
+		c.client_name,
+		COALESCE(c.description, ''),
+		COALESCE(c.website_url, ''),
+		c.redirect_url,
+		COALESCE(c.scopes, '')
+	FROM client c
+`
+
+func (r *ClientRepository) scanClient(row *sql.Row) (*entity.Client, error) {
+	var client entity.Client
+	scanErr := row.Scan(
+		&client.Id,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+		&client.Enabled,
+		&client.ClientId,
+		&client.ClientSecret,
+		&client.ClientName,
+		&client.Description,
+		&client.WebsiteUrl,
+		&client.RedirectUrl,
+		&client.Scopes,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	return &client, nil
+}
+
+func (r *ClientRepository) GetClientByID(id uuid.UUID) (*entity.Client, error) {
+	query := selectClientQuery + `
+		WHERE c.id = $1 AND c.deleted_at IS NULL
+	`
+	return r.scanClient(r.db.QueryRow(query, id))
+}
+
+func (r *ClientRepository) GetClientByClientId(clientId string) (*entity.Client, error) {
+	query := selectClientQuery + `
+		WHERE c.client_id = $1 AND c.deleted_at IS NULL
+	`
+	return r.scanClient(r.db.QueryRow(query, clientId))
+}
+
+func (r *ClientRepository) AddClient(client entity.Client) (entity.Client, error) {
+	query := `
+		INSERT INTO client (id, enabled, client_id, client_secret, client_name, description, website_url, redirect_url, scopes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $10)
+	`
+	current := util.GetCurrentUtcTime(7)
+	_, err := r.db.Exec(query, client.Id, client.Enabled, client.ClientId, client.ClientSecret, client.ClientName, client.Description, client.WebsiteUrl, client.RedirectUrl, client.Scopes, current)
+	if err != nil {
+		return entity.Client{}, err
+	}
+	client.CreatedAt = current
+	client.UpdatedAt = current
+	return client, nil
+}
+
+func (r *ClientRepository) DeleteClient(id uuid.UUID) error {
+	query := `
+		UPDATE client
+		SET
+			deleted_at = $1,
+			updated_at = $1
+		WHERE id = $2
+	`
+	_, err := r.db.Exec(query, util.GetCurrentUtcTime(7), id)
+	return err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type ConsentRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewConsentRepository(db *sql.DB, logger zerolog.Logger) *ConsentRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Consent Repository").
+		Logger()
+	return &ConsentRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ConsentRepository) GetConsent(userId uuid.UUID, clientId uuid.UUID) (*entity.Consent, error) {
+	query := `
+		SELECT
+			uc.id,
+			uc.created_at,
+			uc.updated_at,
+			uc.user_id,
+			uc.client_id,
+			uc.scopes
+		FROM user_consent uc
+		WHERE uc.user_id = $1 AND uc.client_id = $2
+	`
+	var consent entity.Consent
+	scanErr := r.db.QueryRow(query, userId, clientId).Scan(
+		&consent.Id,
+		&consent.CreatedAt,
+		&consent.UpdatedAt,
+		&consent.UserId,
+		&consent.ClientId,
+		&consent.Scopes,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	return &consent, nil
+}
+
+func (r *ConsentRepository) SaveConsent(consent entity.Consent) (entity.Consent, error) {
+	query := `
+		INSERT INTO user_consent (id, user_id, client_id, scopes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (user_id, client_id) DO UPDATE
+		SET
+			scopes = EXCLUDED.scopes,
+			updated_at = EXCLUDED.updated_at
+	`
+	current := util.GetCurrentUtcTime(7)
+	_, err := r.db.Exec(query, consent.Id, consent.UserId, consent.ClientId, consent.Scopes, current)
+	if err != nil {
+		return entity.Consent{}, err
+	}
+	consent.UpdatedAt = current
+	return consent, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type EmailVerificationTokenRepository struct {
+	tokens mailedTokenRepository
+	logger zerolog.Logger
+}
+
+func NewEmailVerificationTokenRepository(db *sql.DB, logger zerolog.Logger) *EmailVerificationTokenRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Email Verification Token Repository").
+		Logger()
+	return &EmailVerificationTokenRepository{
+		tokens: mailedTokenRepository{db: db, table: "email_verification_token"},
+		logger: logger,
+	}
+}
+
+func (r *EmailVerificationTokenRepository) GetEmailVerificationToken(token string) (*entity.EmailVerificationToken, error) {
+	mailedToken, err := r.tokens.getToken(token)
+	if err != nil || mailedToken == nil {
+		return nil, err
+	}
+	return &entity.EmailVerificationToken{MailedToken: *mailedToken}, nil
+}
+
+func (r *EmailVerificationTokenRepository) AddEmailVerificationToken(token entity.EmailVerificationToken) (entity.EmailVerificationToken, error) {
+	mailedToken, err := r.tokens.addToken(token.MailedToken)
+	if err != nil {
+		return entity.EmailVerificationToken{}, err
+	}
+	return entity.EmailVerificationToken{MailedToken: mailedToken}, nil
+}
+
+func (r *EmailVerificationTokenRepository) UseEmailVerificationToken(id uuid.UUID) (bool, error) {
+	return r.tokens.useToken(id)
+}
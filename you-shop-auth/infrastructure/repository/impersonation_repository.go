@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type ImpersonationRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewImpersonationRepository(db *sql.DB, logger zerolog.Logger) *ImpersonationRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Impersonation Repository").
+		Logger()
+	return &ImpersonationRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *ImpersonationRepository) GetImpersonationById(id uuid.UUID) (*entity.Impersonation, error) {
+	query := `
+		SELECT
+			i.id,
+			i.created_at,
+			i.updated_at,
+			i.admin_id,
+			i.user_id,
+			i.reason,
+			i.ip,
+			i.expires_at,
+			i.ended_at
+		FROM impersonation i
+		WHERE i.id = $1
+	`
+	var impersonation entity.Impersonation
+	var endedAt sql.NullTime
+	scanErr := r.db.QueryRow(query, id).Scan(
+		&impersonation.Id,
+		&impersonation.CreatedAt,
+		&impersonation.UpdatedAt,
+		&impersonation.AdminId,
+		&impersonation.UserId,
+		&impersonation.Reason,
+		&impersonation.Ip,
+		&impersonation.ExpiresAt,
+		&endedAt,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	impersonation.EndedAt = endedAt.Time
+	return &impersonation, nil
+}
+
+// GetImpersonations pages through the impersonations, the latest first. A nil adminId or userId disables its filter
+func (r *ImpersonationRepository) GetImpersonations(adminId uuid.UUID, userId uuid.UUID, pageIndex int, pageSize int) ([]entity.Impersonation, error) {
+	query := `
+		SELECT
+			i.id,
+			i.created_at,
+			i.updated_at,
+			i.admin_id,
+			i.user_id,
+			i.reason,
+			i.ip,
+			i.expires_at,
+			i.ended_at
+		FROM impersonation i
+		WHERE ($1::uuid IS NULL OR i.admin_id = $1)
+			AND ($2::uuid IS NULL OR i.user_id = $2)
+		ORDER BY i.created_at DESC, i.id
+		LIMIT $4
+		OFFSET $3
+	`
+	rows, err := r.db.Query(query, nullUuid(adminId), nullUuid(userId), pageIndex*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	impersonations := []entity.Impersonation{}
+	for rows.Next() {
+		var impersonation entity.Impersonation
+		var endedAt sql.NullTime
+		if err := rows.Scan(
+			&impersonation.Id,
+			&impersonation.CreatedAt,
+			&impersonation.UpdatedAt,
+			&impersonation.AdminId,
+			&impersonation.UserId,
+			&impersonation.Reason,
+			&impersonation.Ip,
+			&impersonation.ExpiresAt,
+			&endedAt,
+		); err != nil {
+			return nil, err
+		}
+		impersonation.EndedAt = endedAt.Time
+		impersonations = append(impersonations, impersonation)
+	}
+	return impersonations, rows.Err()
+}
+
+func (r *ImpersonationRepository) CountImpersonations(adminId uuid.UUID, userId uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM impersonation i
+		WHERE ($1::uuid IS NULL OR i.admin_id = $1)
+			AND ($2::uuid IS NULL OR i.user_id = $2)
+	`
+	var count int
+	err := r.db.QueryRow(query, nullUuid(adminId), nullUuid(userId)).Scan(&count)
+	return count, err
+}
+
+func (r *ImpersonationRepository) AddImpersonation(impersonation entity.Impersonation) error {
+	query := `
+		INSERT INTO impersonation (id, admin_id, user_id, reason, ip, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+	`
+	_, err := r.db.Exec(
+		query,
+		impersonation.Id,
+		impersonation.AdminId,
+		impersonation.UserId,
+		impersonation.Reason,
+		impersonation.Ip,
+		impersonation.ExpiresAt,
+		util.GetCurrentUtcTime(7),
+	)
+	return err
+}
+
+// EndImpersonation returns false when the impersonation doesn't exist or was already ended
+func (r *ImpersonationRepository) EndImpersonation(id uuid.UUID, endedAt time.Time) (bool, error) {
+	query := `
+		UPDATE impersonation
+		SET
+			ended_at = $1,
+			updated_at = $1
+		WHERE id = $2 AND ended_at IS NULL
+	`
+	result, err := r.db.Exec(query, endedAt, id)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
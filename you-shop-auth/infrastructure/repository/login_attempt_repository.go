@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type LoginAttemptRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewLoginAttemptRepository(db *sql.DB, logger zerolog.Logger) *LoginAttemptRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Login Attempt Repository").
+		Logger()
+	return &LoginAttemptRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *LoginAttemptRepository) GetLoginAttempt(key string) (*entity.LoginAttempt, error) {
+	query := `
+		SELECT
+			la.id,
+			la.created_at,
+			la.updated_at,
+			la.key,
+			la.failed_count,
+			la.last_failed_at,
+			la.locked_until
+		FROM login_attempt la
+		WHERE la.key = $1
+	`
+	var loginAttempt entity.LoginAttempt
+	var lockedUntil sql.NullTime
+	scanErr := r.db.QueryRow(query, key).Scan(
+		&loginAttempt.Id,
+		&loginAttempt.CreatedAt,
+		&loginAttempt.UpdatedAt,
+		&loginAttempt.Key,
+		&loginAttempt.FailedCount,
+		&loginAttempt.LastFailedAt,
+		&lockedUntil,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	loginAttempt.LockedUntil = lockedUntil.Time
+	return &loginAttempt, nil
+}
+
+// AddFailedLoginAttempt increments the failed count of the key atomically and returns it,
+// the count starts over when the previous failure happened before resetBefore
+func (r *LoginAttemptRepository) AddFailedLoginAttempt(key string, current time.Time, resetBefore time.Time) (int, error) {
+	query := `
+		INSERT INTO login_attempt (id, key, failed_count, last_failed_at, created_at, updated_at)
+		VALUES ($1, $2, 1, $3, $3, $3)
+		ON CONFLICT (key) DO UPDATE
+		SET
+			failed_count = CASE
+				WHEN login_attempt.last_failed_at < $4 THEN 1
+				ELSE login_attempt.failed_count + 1
+			END,
+			last_failed_at = EXCLUDED.last_failed_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING failed_count
+	`
+	var failedCount int
+	if err := r.db.QueryRow(query, uuid.New(), key, current, resetBefore).Scan(&failedCount); err != nil {
+		return 0, err
+	}
+	return failedCount, nil
+}
+
+func (r *LoginAttemptRepository) LockLoginAttempt(key string, lockedUntil time.Time) error {
+	query := `
+		UPDATE login_attempt
+		SET
+			locked_until = $1,
+			updated_at = $2
+		WHERE key = $3
+	`
+	_, err := r.db.Exec(query, lockedUntil, util.GetCurrentUtcTime(7), key)
+	return err
+}
+
+func (r *LoginAttemptRepository) DeleteLoginAttempt(key string) error {
+	query := `
+		DELETE FROM login_attempt
+		WHERE key = $1
+	`
+	_, err := r.db.Exec(query, key)
+	return err
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+)
+
+// mailedTokenRepository holds the queries shared by the tables of mailed tokens, they only differ by name
+type mailedTokenRepository struct {
+	db    *sql.DB
+	table string
+}
+
+func (r mailedTokenRepository) getToken(token string) (*entity.MailedToken, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			t.id,
+			t.created_at,
+			t.updated_at,
+			t.user_id,
+			t.token,
+			t.expires_at,
+			t.used_at
+		FROM %s t
+		WHERE t.token = $1
+	`, r.table)
+	var mailedToken entity.MailedToken
+	var usedAt sql.NullTime
+	scanErr := r.db.QueryRow(query, token).Scan(
+		&mailedToken.Id,
+		&mailedToken.CreatedAt,
+		&mailedToken.UpdatedAt,
+		&mailedToken.UserId,
+		&mailedToken.Token,
+		&mailedToken.ExpiresAt,
+		&usedAt,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	mailedToken.UsedAt = usedAt.Time
+	return &mailedToken, nil
+}
+
+func (r mailedTokenRepository) addToken(token entity.MailedToken) (entity.MailedToken, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, user_id, token, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+	`, r.table)
+	current := util.GetCurrentUtcTime(7)
+	_, err := r.db.Exec(query, token.Id, token.UserId, token.Token, token.ExpiresAt, current)
+	if err != nil {
+		return entity.MailedToken{}, err
+	}
+	token.CreatedAt = current
+	token.UpdatedAt = current
+	return token, nil
+}
+
+// useToken marks every pending token of the user as used so older mails can't be
+// replayed, false means the given token was already used
+func (r mailedTokenRepository) useToken(id uuid.UUID) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE %[1]s
+		SET
+			used_at = $1,
+			updated_at = $1
+		WHERE used_at IS NULL AND user_id = (
+			SELECT user_id FROM %[1]s WHERE id = $2 AND used_at IS NULL
+		)
+	`, r.table)
+	result, err := r.db.Exec(query, util.GetCurrentUtcTime(7), id)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type OneTimeCodeRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewOneTimeCodeRepository(db *sql.DB, logger zerolog.Logger) *OneTimeCodeRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "One Time Code Repository").
+		Logger()
+	return &OneTimeCodeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *OneTimeCodeRepository) GetLatestOneTimeCode(userId uuid.UUID) (*entity.OneTimeCode, error) {
+	query := `
+		SELECT
+			otc.id,
+			otc.created_at,
+			otc.updated_at,
+			otc.user_id,
+			otc.channel,
+			otc.code,
+			otc.failed_attempts,
+			otc.expires_at,
+			otc.used_at
+		FROM one_time_code otc
+		WHERE otc.user_id = $1
+		ORDER BY otc.created_at DESC
+		LIMIT 1
+	`
+	var oneTimeCode entity.OneTimeCode
+	var usedAt sql.NullTime
+	scanErr := r.db.QueryRow(query, userId).Scan(
+		&oneTimeCode.Id,
+		&oneTimeCode.CreatedAt,
+		&oneTimeCode.UpdatedAt,
+		&oneTimeCode.UserId,
+		&oneTimeCode.Channel,
+		&oneTimeCode.Code,
+		&oneTimeCode.FailedAttempts,
+		&oneTimeCode.ExpiresAt,
+		&usedAt,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	oneTimeCode.UsedAt = usedAt.Time
+	return &oneTimeCode, nil
+}
+
+// ReplaceOneTimeCode deletes every code of the user then stores the given one, so only the latest code sent works
+func (r *OneTimeCodeRepository) ReplaceOneTimeCode(oneTimeCode entity.OneTimeCode) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM one_time_code WHERE user_id = $1`, oneTimeCode.UserId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := `
+		INSERT INTO one_time_code (id, user_id, channel, code, failed_attempts, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, 0, $5, $6, $6)
+	`
+	_, err = tx.Exec(query, oneTimeCode.Id, oneTimeCode.UserId, oneTimeCode.Channel, oneTimeCode.Code, oneTimeCode.ExpiresAt, util.GetCurrentUtcTime(7))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// AddFailedOneTimeCodeAttempt counts a wrong guess of the code and returns the failed attempts so far
+func (r *OneTimeCodeRepository) AddFailedOneTimeCodeAttempt(id uuid.UUID) (int, error) {
+	query := `
+		UPDATE one_time_code
+		SET
+			failed_attempts = failed_attempts + 1,
+			updated_at = $1
+		WHERE id = $2
+		RETURNING failed_attempts
+	`
+	var failedAttempts int
+	if err := r.db.QueryRow(query, util.GetCurrentUtcTime(7), id).Scan(&failedAttempts); err != nil {
+		return 0, err
+	}
+	return failedAttempts, nil
+}
+
+// UseOneTimeCode marks the code as used, false means it was already used
+func (r *OneTimeCodeRepository) UseOneTimeCode(id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE one_time_code
+		SET
+			used_at = $1,
+			updated_at = $1
+		WHERE id = $2 AND used_at IS NULL
+	`
+	result, err := r.db.Exec(query, util.GetCurrentUtcTime(7), id)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type OutboxRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewOutboxRepository(db *sql.DB, logger zerolog.Logger) *OutboxRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Outbox Repository").
+		Logger()
+	return &OutboxRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetPendingOutboxMessages returns the messages which aren't published yet, the oldest first
+func (r *OutboxRepository) GetPendingOutboxMessages(limit int) ([]entity.OutboxMessage, error) {
+	query := `
+		SELECT
+			o.id,
+			o.created_at,
+			o.updated_at,
+			o.routing_key,
+			o.payload
+		FROM outbox_message o
+		WHERE o.published_at IS NULL
+		ORDER BY o.created_at
+		LIMIT $1
+	`
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := []entity.OutboxMessage{}
+	for rows.Next() {
+		var outboxMessage entity.OutboxMessage
+		if scanErr := rows.Scan(&outboxMessage.Id, &outboxMessage.CreatedAt, &outboxMessage.UpdatedAt, &outboxMessage.RoutingKey, &outboxMessage.Payload); scanErr != nil {
+			return nil, scanErr
+		}
+		result = append(result, outboxMessage)
+	}
+	return result, rows.Err()
+}
+
+func (r *OutboxRepository) MarkOutboxMessagePublished(id uuid.UUID, publishedAt time.Time) error {
+	query := `
+		UPDATE outbox_message
+		SET
+			published_at = $1,
+			updated_at = $1
+		WHERE id = $2
+	`
+	_, err := r.db.Exec(query, publishedAt, id)
+	return err
+}
+
+// addOutboxMessage saves the message in the transaction of the change it announces
+func addOutboxMessage(tx *sql.Tx, outboxMessage entity.OutboxMessage, current time.Time) error {
+	query := `
+		INSERT INTO outbox_message (id, routing_key, payload, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`
+	_, err := tx.Exec(query, outboxMessage.Id, outboxMessage.RoutingKey, outboxMessage.Payload, current)
+	return err
+}
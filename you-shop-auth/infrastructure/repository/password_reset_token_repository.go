@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type PasswordResetTokenRepository struct {
+	tokens mailedTokenRepository
+	logger zerolog.Logger
+}
+
+func NewPasswordResetTokenRepository(db *sql.DB, logger zerolog.Logger) *PasswordResetTokenRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Password Reset Token Repository").
+		Logger()
+	return &PasswordResetTokenRepository{
+		tokens: mailedTokenRepository{db: db, table: "password_reset_token"},
+		logger: logger,
+	}
+}
+
+func (r *PasswordResetTokenRepository) GetPasswordResetToken(token string) (*entity.PasswordResetToken, error) {
+	mailedToken, err := r.tokens.getToken(token)
+	if err != nil || mailedToken == nil {
+		return nil, err
+	}
+	return &entity.PasswordResetToken{MailedToken: *mailedToken}, nil
+}
+
+func (r *PasswordResetTokenRepository) AddPasswordResetToken(token entity.PasswordResetToken) (entity.PasswordResetToken, error) {
+	mailedToken, err := r.tokens.addToken(token.MailedToken)
+	if err != nil {
+		return entity.PasswordResetToken{}, err
+	}
+	return entity.PasswordResetToken{MailedToken: mailedToken}, nil
+}
+
+func (r *PasswordResetTokenRepository) UsePasswordResetToken(id uuid.UUID) (bool, error) {
+	return r.tokens.useToken(id)
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type RecoveryCodeRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewRecoveryCodeRepository(db *sql.DB, logger zerolog.Logger) *RecoveryCodeRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Recovery Code Repository").
+		Logger()
+	return &RecoveryCodeRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ReplaceRecoveryCodes deletes every recovery code of the user then stores the given ones
+func (r *RecoveryCodeRepository) ReplaceRecoveryCodes(userId uuid.UUID, recoveryCodes []entity.RecoveryCode) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM recovery_code WHERE user_id = $1`, userId)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	query := `
+		INSERT INTO recovery_code (id, user_id, code, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	current := util.GetCurrentUtcTime(7)
+	for _, recoveryCode := range recoveryCodes {
+		_, err = stmt.Exec(recoveryCode.Id, userId, recoveryCode.Code, current)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// UseRecoveryCode marks the code as used, false means the user has no such unused code
+func (r *RecoveryCodeRepository) UseRecoveryCode(userId uuid.UUID, code string) (bool, error) {
+	query := `
+		UPDATE recovery_code
+		SET
+			used_at = $1,
+			updated_at = $1
+		WHERE user_id = $2 AND code = $3 AND used_at IS NULL
+	`
+	result, err := r.db.Exec(query, util.GetCurrentUtcTime(7), userId, code)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type RefreshTokenRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewRefreshTokenRepository(db *sql.DB, logger zerolog.Logger) *RefreshTokenRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Refresh Token Repository").
+		Logger()
+	return &RefreshTokenRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *RefreshTokenRepository) GetRefreshTokenById(id uuid.UUID) (*entity.RefreshToken, error) {
+	query := `
+		SELECT
+			rt.id,
+			rt.created_at,
+			rt.updated_at,
+			rt.user_id,
+			rt.family_id,
+			rt.device,
+			rt.expires_at,
+			rt.revoked_at,
+			rt.replaced_by,
+			COALESCE(rt.client_id, ''),
+			COALESCE(rt.scope, '')
+		FROM refresh_token rt
+		WHERE rt.id = $1
+	`
+	var refreshToken entity.RefreshToken
+	var revokedAt sql.NullTime
+	var replacedBy uuid.NullUUID
+	scanErr := r.db.QueryRow(query, id).Scan(
+		&refreshToken.Id,
+		&refreshToken.CreatedAt,
+		&refreshToken.UpdatedAt,
+		&refreshToken.UserId,
+		&refreshToken.FamilyId,
+		&refreshToken.Device,
+		&refreshToken.ExpiresAt,
+		&revokedAt,
+		&replacedBy,
+		&refreshToken.ClientId,
+		&refreshToken.Scope,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	refreshToken.RevokedAt = revokedAt.Time
+	refreshToken.ReplacedBy = replacedBy.UUID
+	return &refreshToken, nil
+}
+
+func (r *RefreshTokenRepository) AddRefreshToken(refreshToken entity.RefreshToken) (entity.RefreshToken, error) {
+	query := `
+		INSERT INTO refresh_token (id, user_id, family_id, device, expires_at, client_id, scope, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), $8, $9)
+	`
+	stmt, err := r.db.Prepare(query)
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+	defer stmt.Close()
+
+	current := util.GetCurrentUtcTime(7)
+	result, err := stmt.Exec(refreshToken.Id, refreshToken.UserId, refreshToken.FamilyId, refreshToken.Device, refreshToken.ExpiresAt, refreshToken.ClientId, refreshToken.Scope, current, current)
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+	if rows, err := result.RowsAffected(); rows == 0 || err != nil {
+		return entity.RefreshToken{}, errors.New("refresh token haven't been created")
+	}
+	refreshToken.CreatedAt = current
+	refreshToken.UpdatedAt = current
+	return refreshToken, nil
+}
+
+// RotateRefreshToken marks the token as used and replaced by another one.
+// It returns false when the token has already been revoked, which means the
+// token is being reused.
+func (r *RefreshTokenRepository) RotateRefreshToken(id uuid.UUID, replacedBy uuid.UUID) (bool, error) {
+	query := `
+		UPDATE refresh_token
+		SET
+			revoked_at = $1,
+			replaced_by = $2,
+			updated_at = $1
+		WHERE id = $3 AND revoked_at IS NULL
+	`
+	result, err := r.db.Exec(query, util.GetCurrentUtcTime(7), replacedBy, id)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *RefreshTokenRepository) RevokeRefreshTokenFamily(familyId uuid.UUID) error {
+	query := `
+		UPDATE refresh_token
+		SET
+			revoked_at = $1,
+			updated_at = $1
+		WHERE family_id = $2 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, util.GetCurrentUtcTime(7), familyId)
+	return err
+}
+
+func (r *RefreshTokenRepository) RevokeUserRefreshTokens(userId uuid.UUID) error {
+	query := `
+		UPDATE refresh_token
+		SET
+			revoked_at = $1,
+			updated_at = $1
+		WHERE user_id = $2 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, util.GetCurrentUtcTime(7), userId)
+	return err
+}
+
+func (r *RefreshTokenRepository) DeleteExpiredRefreshTokens(before time.Time) (int64, error) {
+	query := `
+		DELETE FROM refresh_token
+		WHERE expires_at < $1
+	`
+	result, err := r.db.Exec(query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
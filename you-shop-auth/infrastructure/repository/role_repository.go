@@ -2,8 +2,10 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 
 	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
@@ -47,3 +49,196 @@ func (r *RoleRepository) GetRolesByUserId(userId uuid.UUID) []entity.Role {
 	}
 	return result
 }
+
+// GetEffectiveRolesByUserId returns the roles of the user along with every role they inherit
+func (r *RoleRepository) GetEffectiveRolesByUserId(userId uuid.UUID) []entity.Role {
+	query := `
+		WITH RECURSIVE effective_role AS (
+			SELECT r.id, r.name, r.parent_id FROM "role" r
+			JOIN user_role ur ON r.id = ur.role_id
+			WHERE ur.user_id = $1
+			UNION
+			SELECT p.id, p.name, p.parent_id FROM "role" p
+			JOIN effective_role er ON p.id = er.parent_id
+		)
+		SELECT er.id, er.name FROM effective_role er
+	`
+	rows, queryErr := r.db.Query(query, userId)
+	var result = []entity.Role{}
+	if queryErr != nil {
+		r.logger.Error().Err(queryErr).Msg("")
+		return result
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var role entity.Role
+		scanErr := rows.Scan(&role.Id, &role.Name)
+		if scanErr != nil {
+			return result
+		}
+		result = append(result, role)
+	}
+	return result
+}
+
+func (r *RoleRepository) GetRoles() ([]entity.Role, error) {
+	query := `
+		SELECT
+			r.id,
+			r.created_at,
+			r.updated_at,
+			r.name,
+			COALESCE(r.description, ''),
+			r.parent_id
+		FROM "role" r
+		ORDER BY r.name
+	`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := []entity.Role{}
+	for rows.Next() {
+		var role entity.Role
+		var parentId uuid.NullUUID
+		if err := rows.Scan(&role.Id, &role.CreatedAt, &role.UpdatedAt, &role.Name, &role.Description, &parentId); err != nil {
+			return nil, err
+		}
+		role.ParentId = parentId.UUID
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+func (r *RoleRepository) GetRoleByName(name string) (*entity.Role, error) {
+	query := `
+		SELECT
+			r.id,
+			r.created_at,
+			r.updated_at,
+			r.name,
+			COALESCE(r.description, ''),
+			r.parent_id
+		FROM "role" r
+		WHERE r.name = $1
+	`
+	var role entity.Role
+	var parentId uuid.NullUUID
+	scanErr := r.db.QueryRow(query, name).Scan(&role.Id, &role.CreatedAt, &role.UpdatedAt, &role.Name, &role.Description, &parentId)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	role.ParentId = parentId.UUID
+	return &role, nil
+}
+
+func (r *RoleRepository) AddRole(role entity.Role) (entity.Role, error) {
+	query := `
+		INSERT INTO "role" (id, name, description, parent_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+	`
+	current := util.GetCurrentUtcTime(7)
+	_, err := r.db.Exec(query, role.Id, role.Name, role.Description, uuid.NullUUID{UUID: role.ParentId, Valid: role.ParentId != uuid.Nil}, current)
+	if err != nil {
+		return entity.Role{}, err
+	}
+	role.CreatedAt = current
+	role.UpdatedAt = current
+	return role, nil
+}
+
+func (r *RoleRepository) UpdateRole(role entity.Role) (entity.Role, error) {
+	query := `
+		UPDATE "role"
+		SET
+			description = $1,
+			parent_id = $2,
+			updated_at = $3
+		WHERE id = $4
+	`
+	current := util.GetCurrentUtcTime(7)
+	_, err := r.db.Exec(query, role.Description, uuid.NullUUID{UUID: role.ParentId, Valid: role.ParentId != uuid.Nil}, current, role.Id)
+	if err != nil {
+		return entity.Role{}, err
+	}
+	role.UpdatedAt = current
+	return role, nil
+}
+
+// DeleteRole also takes the role away from its users
+func (r *RoleRepository) DeleteRole(id uuid.UUID) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, query := range []string{
+		`DELETE FROM role_scope WHERE role_id = $1`,
+		`DELETE FROM user_role WHERE role_id = $1`,
+		`DELETE FROM "role" WHERE id = $1`,
+	} {
+		if _, err = tx.Exec(query, id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *RoleRepository) AddUserRole(userId uuid.UUID, roleId uuid.UUID) error {
+	query := `
+		INSERT INTO user_role (user_id, role_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.db.Exec(query, userId, roleId)
+	return err
+}
+
+// RemoveUserRole returns false when the user didn't have the role
+func (r *RoleRepository) RemoveUserRole(userId uuid.UUID, roleId uuid.UUID) (bool, error) {
+	query := `
+		DELETE FROM user_role
+		WHERE user_id = $1 AND role_id = $2
+	`
+	result, err := r.db.Exec(query, userId, roleId)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *RoleRepository) AddRoleScope(roleId uuid.UUID, scopeId uuid.UUID) error {
+	query := `
+		INSERT INTO role_scope (role_id, scope_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.db.Exec(query, roleId, scopeId)
+	return err
+}
+
+// RemoveRoleScope returns false when the role didn't have the scope
+func (r *RoleRepository) RemoveRoleScope(roleId uuid.UUID, scopeId uuid.UUID) (bool, error) {
+	query := `
+		DELETE FROM role_scope
+		WHERE role_id = $1 AND scope_id = $2
+	`
+	result, err := r.db.Exec(query, roleId, scopeId)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 
 	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
@@ -47,3 +49,193 @@ func (s *ScopeRepository) GetScopesByUserId(userId uuid.UUID) []entity.Scope {
 	}
 	return result
 }
+
+// GetPermissionsByUserId returns the scopes of the user along with the scopes of every role they have or inherit
+func (s *ScopeRepository) GetPermissionsByUserId(userId uuid.UUID) []entity.Scope {
+	query := `
+		WITH RECURSIVE effective_role AS (
+			SELECT r.id, r.parent_id FROM "role" r
+			JOIN user_role ur ON r.id = ur.role_id
+			WHERE ur.user_id = $1
+			UNION
+			SELECT p.id, p.parent_id FROM "role" p
+			JOIN effective_role er ON p.id = er.parent_id
+		)
+		SELECT s.id, s.name FROM "scope" s
+		JOIN user_scope us ON s.id = us.scope_id
+		WHERE us.user_id = $1
+		UNION
+		SELECT s.id, s.name FROM "scope" s
+		JOIN role_scope rs ON s.id = rs.scope_id
+		JOIN effective_role er ON rs.role_id = er.id
+	`
+	rows, queryErr := s.db.Query(query, userId)
+	var result = []entity.Scope{}
+	if queryErr != nil {
+		s.logger.Error().Err(queryErr).Msg("")
+		return result
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var scope entity.Scope
+		scanErr := rows.Scan(&scope.Id, &scope.Name)
+		if scanErr != nil {
+			return result
+		}
+		result = append(result, scope)
+	}
+	return result
+}
+
+func (s *ScopeRepository) GetScopesByRoleId(roleId uuid.UUID) []entity.Scope {
+	query := `
+		SELECT s.id, s.name FROM "scope" s
+		JOIN role_scope rs ON s.id = rs.scope_id
+		WHERE rs.role_id = $1
+		ORDER BY s.name
+	`
+	rows, queryErr := s.db.Query(query, roleId)
+	var result = []entity.Scope{}
+	if queryErr != nil {
+		s.logger.Error().Err(queryErr).Msg("")
+		return result
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var scope entity.Scope
+		scanErr := rows.Scan(&scope.Id, &scope.Name)
+		if scanErr != nil {
+			return result
+		}
+		result = append(result, scope)
+	}
+	return result
+}
+
+func (s *ScopeRepository) GetScopes() ([]entity.Scope, error) {
+	query := `
+		SELECT
+			s.id,
+			s.created_at,
+			s.updated_at,
+			s.name,
+			COALESCE(s.description, '')
+		FROM "scope" s
+		ORDER BY s.name
+	`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	scopes := []entity.Scope{}
+	for rows.Next() {
+		var scope entity.Scope
+		if err := rows.Scan(&scope.Id, &scope.CreatedAt, &scope.UpdatedAt, &scope.Name, &scope.Description); err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, rows.Err()
+}
+
+func (s *ScopeRepository) GetScopeByName(name string) (*entity.Scope, error) {
+	query := `
+		SELECT
+			s.id,
+			s.created_at,
+			s.updated_at,
+			s.name,
+			COALESCE(s.description, '')
+		FROM "scope" s
+		WHERE s.name = $1
+	`
+	var scope entity.Scope
+	scanErr := s.db.QueryRow(query, name).Scan(&scope.Id, &scope.CreatedAt, &scope.UpdatedAt, &scope.Name, &scope.Description)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	return &scope, nil
+}
+
+func (s *ScopeRepository) AddScope(scope entity.Scope) (entity.Scope, error) {
+	query := `
+		INSERT INTO "scope" (id, name, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+	`
+	current := util.GetCurrentUtcTime(7)
+	_, err := s.db.Exec(query, scope.Id, scope.Name, scope.Description, current)
+	if err != nil {
+		return entity.Scope{}, err
+	}
+	scope.CreatedAt = current
+	scope.UpdatedAt = current
+	return scope, nil
+}
+
+func (s *ScopeRepository) UpdateScope(scope entity.Scope) (entity.Scope, error) {
+	query := `
+		UPDATE "scope"
+		SET
+			description = $1,
+			updated_at = $2
+		WHERE id = $3
+	`
+	current := util.GetCurrentUtcTime(7)
+	_, err := s.db.Exec(query, scope.Description, current, scope.Id)
+	if err != nil {
+		return entity.Scope{}, err
+	}
+	scope.UpdatedAt = current
+	return scope, nil
+}
+
+// DeleteScope also takes the scope away from the users and roles which have it
+func (s *ScopeRepository) DeleteScope(id uuid.UUID) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, query := range []string{
+		`DELETE FROM role_scope WHERE scope_id = $1`,
+		`DELETE FROM user_scope WHERE scope_id = $1`,
+		`DELETE FROM "scope" WHERE id = $1`,
+	} {
+		if _, err = tx.Exec(query, id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (s *ScopeRepository) AddUserScope(userId uuid.UUID, scopeId uuid.UUID) error {
+	query := `
+		INSERT INTO user_scope (user_id, scope_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := s.db.Exec(query, userId, scopeId)
+	return err
+}
+
+// RemoveUserScope returns false when the user didn't have the scope
+func (s *ScopeRepository) RemoveUserScope(userId uuid.UUID, scopeId uuid.UUID) (bool, error) {
+	query := `
+		DELETE FROM user_scope
+		WHERE user_id = $1 AND scope_id = $2
+	`
+	result, err := s.db.Exec(query, userId, scopeId)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
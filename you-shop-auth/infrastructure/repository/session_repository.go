@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type SessionRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewSessionRepository(db *sql.DB, logger zerolog.Logger) *SessionRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Session Repository").
+		Logger()
+	return &SessionRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *SessionRepository) GetSessionById(id uuid.UUID) (*entity.Session, error) {
+	query := `
+		SELECT
+			s.id,
+			s.created_at,
+			s.updated_at,
+			s.user_id,
+			COALESCE(s.client_id, ''),
+			s.user_agent,
+			s.ip,
+			s.last_used_at,
+			s.expires_at,
+			s.revoked_at
+		FROM session s
+		WHERE s.id = $1
+	`
+	var session entity.Session
+	var revokedAt sql.NullTime
+	scanErr := r.db.QueryRow(query, id).Scan(
+		&session.Id,
+		&session.CreatedAt,
+		&session.UpdatedAt,
+		&session.UserId,
+		&session.ClientId,
+		&session.UserAgent,
+		&session.Ip,
+		&session.LastUsedAt,
+		&session.ExpiresAt,
+		&revokedAt,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	session.RevokedAt = revokedAt.Time
+	return &session, nil
+}
+
+// GetActiveSessionsByUserId returns the sessions which are neither revoked nor expired, the last used first
+func (r *SessionRepository) GetActiveSessionsByUserId(userId uuid.UUID, current time.Time) ([]entity.Session, error) {
+	query := `
+		SELECT
+			s.id,
+			s.created_at,
+			s.updated_at,
+			s.user_id,
+			COALESCE(s.client_id, ''),
+			s.user_agent,
+			s.ip,
+			s.last_used_at,
+			s.expires_at
+		FROM session s
+		WHERE s.user_id = $1 AND s.revoked_at IS NULL AND s.expires_at > $2
+		ORDER BY s.last_used_at DESC
+	`
+	rows, queryErr := r.db.Query(query, userId, current)
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer rows.Close()
+
+	sessions := []entity.Session{}
+	for rows.Next() {
+		var session entity.Session
+		if scanErr := rows.Scan(
+			&session.Id,
+			&session.CreatedAt,
+			&session.UpdatedAt,
+			&session.UserId,
+			&session.ClientId,
+			&session.UserAgent,
+			&session.Ip,
+			&session.LastUsedAt,
+			&session.ExpiresAt,
+		); scanErr != nil {
+			return nil, scanErr
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *SessionRepository) AddSession(session entity.Session) (entity.Session, error) {
+	query := `
+		INSERT INTO session (id, user_id, client_id, user_agent, ip, last_used_at, expires_at, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9)
+	`
+	current := util.GetCurrentUtcTime(7)
+	result, err := r.db.Exec(query, session.Id, session.UserId, session.ClientId, session.UserAgent, session.Ip, current, session.ExpiresAt, current, current)
+	if err != nil {
+		return entity.Session{}, err
+	}
+	if rows, err := result.RowsAffected(); rows == 0 || err != nil {
+		return entity.Session{}, errors.New("session haven't been created")
+	}
+	session.LastUsedAt = current
+	session.CreatedAt = current
+	session.UpdatedAt = current
+	return session, nil
+}
+
+// TouchSession records a use of the session, it lives as long as its latest refresh token
+func (r *SessionRepository) TouchSession(id uuid.UUID, userAgent string, ip string, expiresAt time.Time) error {
+	query := `
+		UPDATE session
+		SET
+			user_agent = $1,
+			ip = $2,
+			expires_at = $3,
+			last_used_at = $4,
+			updated_at = $4
+		WHERE id = $5 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, userAgent, ip, expiresAt, util.GetCurrentUtcTime(7), id)
+	return err
+}
+
+func (r *SessionRepository) RevokeSession(id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE session
+		SET
+			revoked_at = $1,
+			updated_at = $1
+		WHERE id = $2 AND revoked_at IS NULL
+	`
+	result, err := r.db.Exec(query, util.GetCurrentUtcTime(7), id)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *SessionRepository) RevokeUserSessions(userId uuid.UUID) error {
+	query := `
+		UPDATE session
+		SET
+			revoked_at = $1,
+			updated_at = $1
+		WHERE user_id = $2 AND revoked_at IS NULL
+	`
+	_, err := r.db.Exec(query, util.GetCurrentUtcTime(7), userId)
+	return err
+}
+
+func (r *SessionRepository) DeleteExpiredSessions(before time.Time) (int64, error) {
+	query := `
+		DELETE FROM session
+		WHERE expires_at < $1
+	`
+	result, err := r.db.Exec(query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
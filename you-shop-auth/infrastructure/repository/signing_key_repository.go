@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type SigningKeyRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewSigningKeyRepository(db *sql.DB, logger zerolog.Logger) *SigningKeyRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Signing Key Repository").
+		Logger()
+	return &SigningKeyRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// GetPublishedSigningKeys returns keys which are still in use or whose tokens may not be expired yet, newest first
+func (s *SigningKeyRepository) GetPublishedSigningKeys(current time.Time) ([]entity.SigningKey, error) {
+	query := `
+		SELECT
+			sk.id,
+			sk.created_at,
+			sk.updated_at,
+			sk.algorithm,
+			sk.private_key,
+			sk.public_key,
+			sk.rotated_at,
+			sk.expires_at
+		FROM signing_key sk
+		WHERE sk.expires_at IS NULL OR sk.expires_at > $1
+		ORDER BY sk.created_at DESC
+	`
+	rows, queryErr := s.db.Query(query, current)
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer rows.Close()
+
+	result := []entity.SigningKey{}
+	for rows.Next() {
+		var signingKey entity.SigningKey
+		var rotatedAt, expiresAt sql.NullTime
+		scanErr := rows.Scan(
+			&signingKey.Id,
+			&signingKey.CreatedAt,
+			&signingKey.UpdatedAt,
+			&signingKey.Algorithm,
+			&signingKey.PrivateKey,
+			&signingKey.PublicKey,
+			&rotatedAt,
+			&expiresAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		signingKey.RotatedAt = rotatedAt.Time
+		signingKey.ExpiresAt = expiresAt.Time
+		result = append(result, signingKey)
+	}
+	return result, rows.Err()
+}
+
+func (s *SigningKeyRepository) AddSigningKey(signingKey entity.SigningKey) (entity.SigningKey, error) {
+	query := `
+		INSERT INTO signing_key (id, algorithm, private_key, public_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+	`
+	current := util.GetCurrentUtcTime(7)
+	_, err := s.db.Exec(query, signingKey.Id, signingKey.Algorithm, signingKey.PrivateKey, signingKey.PublicKey, current)
+	if err != nil {
+		return entity.SigningKey{}, err
+	}
+	signingKey.CreatedAt = current
+	signingKey.UpdatedAt = current
+	return signingKey, nil
+}
+
+func (s *SigningKeyRepository) RotateSigningKey(id uuid.UUID, rotatedAt time.Time, expiresAt time.Time) error {
+	query := `
+		UPDATE signing_key
+		SET
+			rotated_at = $1,
+			expires_at = $2,
+			updated_at = $1
+		WHERE id = $3 AND rotated_at IS NULL
+	`
+	_, err := s.db.Exec(query, rotatedAt, expiresAt, id)
+	return err
+}
+
+func (s *SigningKeyRepository) DeleteExpiredSigningKeys(before time.Time) (int64, error) {
+	query := `
+		DELETE FROM signing_key
+		WHERE expires_at < $1
+	`
+	result, err := s.db.Exec(query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
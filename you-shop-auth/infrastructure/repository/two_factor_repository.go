@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type TwoFactorRepository struct {
+	db     *sql.DB
+	logger zerolog.Logger
+}
+
+func NewTwoFactorRepository(db *sql.DB, logger zerolog.Logger) *TwoFactorRepository {
+	logger = logger.
+		With().
+		Str("Infrastructure", "Two Factor Repository").
+		Logger()
+	return &TwoFactorRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *TwoFactorRepository) GetTwoFactorByUserId(userId uuid.UUID) (*entity.TwoFactor, error) {
+	query := `
+		SELECT
+			tf.id,
+			tf.created_at,
+			tf.updated_at,
+			tf.user_id,
+			tf.secret,
+			tf.enabled_at,
+			tf.last_used_step
+		FROM user_two_factor tf
+		WHERE tf.user_id = $1
+	`
+	var twoFactor entity.TwoFactor
+	var enabledAt sql.NullTime
+	scanErr := r.db.QueryRow(query, userId).Scan(
+		&twoFactor.Id,
+		&twoFactor.CreatedAt,
+		&twoFactor.UpdatedAt,
+		&twoFactor.UserId,
+		&twoFactor.Secret,
+		&enabledAt,
+		&twoFactor.LastUsedStep,
+	)
+	if scanErr != nil {
+		if errors.Is(scanErr, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, scanErr
+	}
+	twoFactor.EnabledAt = enabledAt.Time
+	return &twoFactor, nil
+}
+
+// SaveTwoFactor stores a pending secret, replacing the previous one of the user
+func (r *TwoFactorRepository) SaveTwoFactor(twoFactor entity.TwoFactor) (entity.TwoFactor, error) {
+	query := `
+		INSERT INTO user_two_factor (id, user_id, secret, last_used_step, created_at, updated_at)
+		VALUES ($1, $2, $3, 0, $4, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET
+			secret = EXCLUDED.secret,
+			enabled_at = NULL,
+			last_used_step = 0,
+			updated_at = EXCLUDED.updated_at
+	`
+	current := util.GetCurrentUtcTime(7)
+	_, err := r.db.Exec(query, twoFactor.Id, twoFactor.UserId, twoFactor.Secret, current)
+	if err != nil {
+		return entity.TwoFactor{}, err
+	}
+	twoFactor.CreatedAt = current
+	twoFactor.UpdatedAt = current
+	return twoFactor, nil
+}
+
+func (r *TwoFactorRepository) EnableTwoFactor(userId uuid.UUID, enabledAt time.Time) error {
+	query := `
+		UPDATE user_two_factor
+		SET
+			enabled_at = $1,
+			updated_at = $1
+		WHERE user_id = $2
+	`
+	_, err := r.db.Exec(query, enabledAt, userId)
+	return err
+}
+
+// UseTwoFactorStep records the time step of an accepted code, false means a code of this
+// step or a later one was already accepted
+func (r *TwoFactorRepository) UseTwoFactorStep(userId uuid.UUID, step int64) (bool, error) {
+	query := `
+		UPDATE user_two_factor
+		SET
+			last_used_step = $1,
+			updated_at = $2
+		WHERE user_id = $3 AND last_used_step < $1
+	`
+	result, err := r.db.Exec(query, step, util.GetCurrentUtcTime(7), userId)
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *TwoFactorRepository) DeleteTwoFactor(userId uuid.UUID) error {
+	query := `
+		DELETE FROM user_two_factor
+		WHERE user_id = $1
+	`
+	_, err := r.db.Exec(query, userId)
+	return err
+}
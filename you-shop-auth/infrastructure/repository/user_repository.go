@@ -3,6 +3,8 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/TechwizsonORG/auth-service/entity"
 	"github.com/TechwizsonORG/auth-service/util"
@@ -38,11 +40,13 @@ func (u UserRepository) GetUserByEmailOrUsername(email string, username string)
 			u.password_hash,
 			u.avatar_url,
 			u.is_active,
-			u.phone_number
+			u.phone_number,
+			u.email_verified_at
 		FROM users u
 		WHERE (u.email = $1 OR u.username = $2) AND u.is_active = true
 	`
 	var user entity.User
+	var emailVerifiedAt sql.NullTime
 	error := u.db.QueryRow(query, email, username).Scan(
 		&user.Id,
 		&user.CreatedAt,
@@ -53,6 +57,7 @@ func (u UserRepository) GetUserByEmailOrUsername(email string, username string)
 		&user.AvatarUrl,
 		&user.IsActive,
 		&user.PhoneNumber,
+		&emailVerifiedAt,
 	)
 
 	if error != nil {
@@ -61,9 +66,11 @@ func (u UserRepository) GetUserByEmailOrUsername(email string, username string)
 		}
 		return nil, nil
 	}
+	user.EmailVerifiedAt = emailVerifiedAt.Time
 	return &user, nil
 }
 
+// GetUserByID returns nil when the user doesn't exist or was deactivated
 func (u UserRepository) GetUserByID(id uuid.UUID) (*entity.User, error) {
 	query := `
 		SELECT 
@@ -75,11 +82,13 @@ func (u UserRepository) GetUserByID(id uuid.UUID) (*entity.User, error) {
 			u.password_hash,
 			u.avatar_url,
 			u.is_active,
-			u.phone_number
+			u.phone_number,
+			u.email_verified_at
 		FROM users u
 		WHERE u.id = $1 AND u.is_active = true
 	`
 	var user entity.User
+	var emailVerifiedAt sql.NullTime
 	error := u.db.QueryRow(query, id.String()).Scan(
 		&user.Id,
 		&user.CreatedAt,
@@ -90,15 +99,131 @@ func (u UserRepository) GetUserByID(id uuid.UUID) (*entity.User, error) {
 		&user.AvatarUrl,
 		&user.IsActive,
 		&user.PhoneNumber,
+		&emailVerifiedAt,
 	)
 
 	if error != nil {
+		if errors.Is(error, sql.ErrNoRows) {
+			return nil, nil
+		}
 		return nil, error
 	}
+	user.EmailVerifiedAt = emailVerifiedAt.Time
 	return &user, nil
 
 }
 
+// GetUserByIDIncludeInactive is GetUserByID for administration, deactivated users are returned too
+func (u UserRepository) GetUserByIDIncludeInactive(id uuid.UUID) (*entity.User, error) {
+	query := `
+		SELECT 
+			u.id,
+			u.created_at,
+			u.updated_at,
+			u.username,
+			u.email,
+			u.password_hash,
+			u.avatar_url,
+			u.is_active,
+			u.phone_number,
+			u.email_verified_at
+		FROM users u
+		WHERE u.id = $1
+	`
+	var user entity.User
+	var emailVerifiedAt sql.NullTime
+	error := u.db.QueryRow(query, id.String()).Scan(
+		&user.Id,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.Username,
+		&user.Email,
+		&user.PasswordHash,
+		&user.AvatarUrl,
+		&user.IsActive,
+		&user.PhoneNumber,
+		&emailVerifiedAt,
+	)
+
+	if error != nil {
+		if errors.Is(error, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, error
+	}
+	user.EmailVerifiedAt = emailVerifiedAt.Time
+	return &user, nil
+}
+
+// searchUsersCondition matches the username or email containing $1, which must be escaped with escapeLike
+const searchUsersCondition = `$1 = '' OR UPPER(u.username) LIKE '%' || UPPER($1) || '%' ESCAPE '\' OR u.normalized_email LIKE '%' || UPPER($1) || '%' ESCAPE '\'`
+
+// escapeLike makes the wildcards of a search term match literally
+func escapeLike(search string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(search)
+}
+
+// GetUsers pages through every user, active or not, whose username or email contains search
+func (u UserRepository) GetUsers(search string, pageIndex int, pageSize int) ([]entity.User, error) {
+	query := `
+		SELECT 
+			u.id,
+			u.created_at,
+			u.updated_at,
+			u.username,
+			u.email,
+			u.password_hash,
+			u.avatar_url,
+			u.is_active,
+			u.phone_number,
+			u.email_verified_at
+		FROM users u
+		WHERE ` + searchUsersCondition + `
+		ORDER BY u.created_at DESC, u.id
+		LIMIT $3
+		OFFSET $2
+	`
+	rows, err := u.db.Query(query, escapeLike(search), pageIndex*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []entity.User{}
+	for rows.Next() {
+		var user entity.User
+		var emailVerifiedAt sql.NullTime
+		if err := rows.Scan(
+			&user.Id,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.Username,
+			&user.Email,
+			&user.PasswordHash,
+			&user.AvatarUrl,
+			&user.IsActive,
+			&user.PhoneNumber,
+			&emailVerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		user.EmailVerifiedAt = emailVerifiedAt.Time
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+func (u UserRepository) CountUsers(search string) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM users u
+		WHERE ` + searchUsersCondition + `
+	`
+	var count int
+	err := u.db.QueryRow(query, escapeLike(search)).Scan(&count)
+	return count, err
+}
+
 func (u UserRepository) AddUser(user entity.User) (entity.User, error) {
 	query := `
 		INSERT INTO users (id, username, normalized_username, password_hash, email, normalized_email, avatar_url, is_active, phone_number)
@@ -123,13 +248,15 @@ func (u UserRepository) AddUser(user entity.User) (entity.User, error) {
 
 func (u UserRepository) UpdateUser(user entity.User) (entity.User, error) {
 	query := `
-		UPDATE user
+		UPDATE users
 		SET
 			avatar_url = $1,
 			is_active = $2,
 			phone_number = $3,
-			updated_at = $4
-		WHERE id = $5
+			updated_at = $4,
+			username = $5,
+			normalized_username = UPPER($5)
+		WHERE id = $6
 		RETURNING updated_at
 	`
 	smtp, err := u.db.Prepare(query)
@@ -140,10 +267,167 @@ func (u UserRepository) UpdateUser(user entity.User) (entity.User, error) {
 	defer smtp.Close()
 
 	err = smtp.
-		QueryRow(user.AvatarUrl, user.IsActive, user.PhoneNumber, util.GetCurrentUtcTime(7), user.Id.String()).
+		QueryRow(user.AvatarUrl, user.IsActive, user.PhoneNumber, util.GetCurrentUtcTime(7), user.Username, user.Id.String()).
 		Scan(&user.UpdatedAt)
 	if err != nil {
 		return entity.User{}, err
 	}
 	return user, nil
 }
+
+func (u UserRepository) UpdatePassword(id uuid.UUID, passwordHash string) error {
+	query := `
+		UPDATE users
+		SET
+			password_hash = $1,
+			updated_at = $2
+		WHERE id = $3
+	`
+	_, err := u.db.Exec(query, passwordHash, util.GetCurrentUtcTime(7), id.String())
+	return err
+}
+
+// UpdateEmail also clears email_verified_at, the new email has to be verified again
+// UpdateEmail marks the pending verification tokens as used in the same transaction,
+// links mailed to the old address can't verify the new one
+func (u UserRepository) UpdateEmail(id uuid.UUID, email string, normalizedEmail string) error {
+	query := `
+		UPDATE users
+		SET
+			email = $1,
+			normalized_email = $2,
+			email_verified_at = NULL,
+			updated_at = $3
+		WHERE id = $4
+	`
+	tokenQuery := `
+		UPDATE email_verification_token
+		SET
+			used_at = $1,
+			updated_at = $1
+		WHERE user_id = $2 AND used_at IS NULL
+	`
+	tx, err := u.db.Begin()
+	if err != nil {
+		return err
+	}
+	current := util.GetCurrentUtcTime(7)
+	if _, err = tx.Exec(query, email, normalizedEmail, current, id.String()); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err = tx.Exec(tokenQuery, current, id.String()); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (u UserRepository) VerifyEmail(id uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET
+			email_verified_at = $1,
+			updated_at = $1
+		WHERE id = $2 AND email_verified_at IS NULL
+	`
+	_, err := u.db.Exec(query, util.GetCurrentUtcTime(7), id.String())
+	return err
+}
+
+// VerifyLegacyEmails marks the emails of users who registered before email verification was released as verified,
+// they never got a verification token. Users who registered since are never touched, even when their token wasn't saved
+func (u UserRepository) VerifyLegacyEmails(releasedAt time.Time) (int64, error) {
+	query := `
+		UPDATE users
+		SET
+			email_verified_at = created_at,
+			updated_at = $1
+		WHERE email_verified_at IS NULL AND deleted_at IS NULL AND created_at < $2 AND NOT EXISTS (
+			SELECT 1 FROM email_verification_token evt WHERE evt.user_id = users.id
+		)
+	`
+	result, err := u.db.Exec(query, util.GetCurrentUtcTime(7), releasedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// SetUserActive returns false when the user doesn't exist, deleted users can't be activated again
+func (u UserRepository) SetUserActive(id uuid.UUID, isActive bool) (bool, error) {
+	query := `
+		UPDATE users
+		SET
+			is_active = $1,
+			updated_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+	result, err := u.db.Exec(query, isActive, util.GetCurrentUtcTime(7), id.String())
+	if err != nil {
+		return false, err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// DeleteUser overwrites the personal columns with the values of an anonymized user and marks it deleted.
+// The same transaction erases the personal data kept around the account and saves the outbox message announcing it
+func (u UserRepository) DeleteUser(user entity.User, loginAttemptKeys []string, outboxMessage entity.OutboxMessage) error {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return err
+	}
+	query := `
+		UPDATE users
+		SET
+			username = $1,
+			normalized_username = $2,
+			email = $3,
+			normalized_email = $4,
+			password_hash = $5,
+			avatar_url = $6,
+			phone_number = $7,
+			is_active = false,
+			email_verified_at = NULL,
+			deleted_at = $8,
+			updated_at = $8
+		WHERE id = $9
+	`
+	_, err = tx.Exec(query, user.Username, user.NormalizedUsername, user.Email, user.NormalizedEmail, user.PasswordHash, user.AvatarUrl, user.PhoneNumber, user.DeletedAt, user.Id.String())
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	erasures := []string{
+		`UPDATE auth_audit SET detail = '{}', ip = '' WHERE user_id = $1`,
+		`UPDATE session SET ip = '', user_agent = '' WHERE user_id = $1`,
+		`UPDATE refresh_token SET device = '' WHERE user_id = $1`,
+		`DELETE FROM api_key WHERE user_id = $1`,
+		`DELETE FROM recovery_code WHERE user_id = $1`,
+		`DELETE FROM user_two_factor WHERE user_id = $1`,
+		`DELETE FROM one_time_code WHERE user_id = $1`,
+	}
+	for _, erasure := range erasures {
+		if _, err = tx.Exec(erasure, user.Id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	for _, key := range loginAttemptKeys {
+		if _, err = tx.Exec(`DELETE FROM login_attempt WHERE key = $1`, key); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err = addOutboxMessage(tx, outboxMessage, user.DeletedAt); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
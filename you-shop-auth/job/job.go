@@ -0,0 +1,73 @@
+package job
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/background"
+	"github.com/TechwizsonORG/auth-service/usecase/key"
+	"github.com/TechwizsonORG/auth-service/usecase/outbox"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/rs/zerolog"
+)
+
+type Job struct {
+	logger zerolog.Logger
+}
+
+func NewJob(logger zerolog.Logger) *Job {
+	jobLogger := logger.
+		With().
+		Str("Background", "Job").
+		Logger()
+	return &Job{logger: jobLogger}
+}
+
+func (j *Job) CleanupExpiredTokens(tokenService token.TokenInterface, interval time.Duration) background.JobFunc {
+	return func() {
+		cleanup := func() {
+			j.logger.Debug().Msg("Cleanup expired tokens from background job")
+			if cleanupErr := tokenService.CleanupExpiredTokens(); cleanupErr != nil {
+				j.logger.Error().Err(cleanupErr).Msg("")
+			}
+		}
+		cleanup()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			cleanup()
+		}
+	}
+}
+
+func (j *Job) RotateSigningKey(keyService key.KeyInterface, interval time.Duration) background.JobFunc {
+	return func() {
+		rotate := func() {
+			j.logger.Debug().Msg("Rotate signing key from background job")
+			if rotateErr := keyService.RotateKeyIfDue(); rotateErr != nil {
+				j.logger.Error().Err(rotateErr).Msg("")
+			}
+		}
+		rotate()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			rotate()
+		}
+	}
+}
+
+func (j *Job) PublishOutbox(outboxService outbox.OutboxInterface, interval time.Duration) background.JobFunc {
+	return func() {
+		publish := func() {
+			if publishErr := outboxService.PublishPending(); publishErr != nil {
+				j.logger.Error().Err(publishErr).Msg("")
+			}
+		}
+		publish()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			publish()
+		}
+	}
+}
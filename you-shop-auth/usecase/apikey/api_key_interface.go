@@ -0,0 +1,19 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/google/uuid"
+)
+
+// ApiKeyInterface manages the personal api keys of the users, the keys are validated by the token service
+type ApiKeyInterface interface {
+	// GetApiKeys returns the keys which aren't revoked, expired ones included
+	GetApiKeys(userId uuid.UUID) ([]entity.ApiKey, *err.AppError)
+	// CreateApiKey returns the plain key along with the saved one, the plain key can't be read again.
+	// Every scope must be a permission of the user
+	CreateApiKey(userId uuid.UUID, name string, scopes []string, expiresAt time.Time) (string, *entity.ApiKey, *err.AppError)
+	RevokeApiKey(userId uuid.UUID, apiKeyId uuid.UUID) *err.AppError
+}
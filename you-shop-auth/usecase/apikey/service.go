@@ -0,0 +1,112 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/audit"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue/event"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// Lets secret scanners recognize leaked keys
+	apiKeyPrefix        = "ysk_"
+	apiKeyDisplayChars  = 12
+	maxApiKeyLifetime   = 365 * 24 * time.Hour
+	maxApiKeyNameLength = 100
+)
+
+type Service struct {
+	logger       zerolog.Logger
+	apiKeyRepo   usecase.ApiKeyRepository
+	scopeRepo    usecase.ScopeRepository
+	auditService audit.AuditInterface
+}
+
+func NewApiKeyService(logger zerolog.Logger, apiKeyRepo usecase.ApiKeyRepository, scopeRepo usecase.ScopeRepository, auditService audit.AuditInterface) *Service {
+	logger = logger.
+		With().
+		Str("Service", "Api Key").
+		Logger()
+	return &Service{
+		logger:       logger,
+		apiKeyRepo:   apiKeyRepo,
+		scopeRepo:    scopeRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *Service) GetApiKeys(userId uuid.UUID) ([]entity.ApiKey, *err.AppError) {
+	apiKeys, getErr := s.apiKeyRepo.GetApiKeysByUserId(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return apiKeys, nil
+}
+
+func (s *Service) CreateApiKey(userId uuid.UUID, name string, scopes []string, expiresAt time.Time) (string, *entity.ApiKey, *err.AppError) {
+	if name == "" || len(name) > maxApiKeyNameLength {
+		return "", nil, err.NewApiKeyError("Name is required and can't be longer than 100 characters")
+	}
+	if len(scopes) == 0 {
+		return "", nil, err.NewApiKeyError("At least one scope is required")
+	}
+	current := util.GetCurrentUtcTime(7)
+	if !expiresAt.After(current) || expiresAt.After(current.Add(maxApiKeyLifetime)) {
+		return "", nil, err.NewApiKeyError("Expiration must be in the future and within a year")
+	}
+	permissions := []string{}
+	for _, permission := range s.scopeRepo.GetPermissionsByUserId(userId) {
+		permissions = append(permissions, permission.Name)
+	}
+	if !util.ContainsAll(scopes, permissions) {
+		return "", nil, err.NewApiKeyError("Api keys can only carry scopes the user holds")
+	}
+
+	random, generateErr := util.GenerateRandomToken(32)
+	if generateErr != nil {
+		s.logger.Error().Err(generateErr).Msg("")
+		return "", nil, err.NewUnhandledError()
+	}
+	key := apiKeyPrefix + random
+	apiKey, addErr := s.apiKeyRepo.AddApiKey(*entity.NewApiKey(userId, name, key[:apiKeyDisplayChars], util.HashToken(key), scopes, expiresAt))
+	if addErr != nil {
+		s.logger.Error().Err(addErr).Msg("")
+		return "", nil, err.NewUnhandledError()
+	}
+	s.auditService.Record(event.AuthEvent{
+		Type:   event.AuthApiKeyCreated,
+		UserId: userId,
+		Detail: event.ApiKeyDetail{
+			ApiKeyId: apiKey.Id,
+			Name:     apiKey.Name,
+			Scope:    apiKey.Scope,
+		},
+	})
+	return key, &apiKey, nil
+}
+
+func (s *Service) RevokeApiKey(userId uuid.UUID, apiKeyId uuid.UUID) *err.AppError {
+	revoked, revokeErr := s.apiKeyRepo.RevokeApiKey(userId, apiKeyId)
+	if revokeErr != nil {
+		s.logger.Error().Err(revokeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !revoked {
+		return err.NewNotFoundError("Api key not found")
+	}
+	s.auditService.Record(event.AuthEvent{
+		Type:   event.AuthApiKeyRevoked,
+		UserId: userId,
+		Detail: event.ApiKeyDetail{
+			ApiKeyId: apiKeyId,
+		},
+	})
+	return nil
+}
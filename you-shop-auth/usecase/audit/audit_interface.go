@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/audit/model"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue/event"
+	"github.com/google/uuid"
+)
+
+type AuditInterface interface {
+	// Record saves the event in the audit trail then publishes it, failures are only logged so they never break the audited action
+	Record(authEvent event.AuthEvent)
+	// GetAudits pages through the audit trail, the latest first. A nil userId or a zero time disables its filter
+	GetAudits(userId uuid.UUID, from time.Time, to time.Time, page int, pageSize int) (*model.AuditPage, *err.AppError)
+	// GetImpersonations pages through the impersonations, the latest first. A nil adminId or userId disables its filter
+	GetImpersonations(adminId uuid.UUID, userId uuid.UUID, page int, pageSize int) (*model.ImpersonationPage, *err.AppError)
+}
@@ -0,0 +1,10 @@
+package model
+
+import "github.com/TechwizsonORG/auth-service/entity"
+
+type AuditPage struct {
+	Audits   []entity.AuthAudit
+	Page     int
+	PageSize int
+	Total    int
+}
@@ -0,0 +1,10 @@
+package model
+
+import "github.com/TechwizsonORG/auth-service/entity"
+
+type ImpersonationPage struct {
+	Impersonations []entity.Impersonation
+	Page           int
+	PageSize       int
+	Total          int
+}
@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/background"
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/audit/model"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue/event"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type Service struct {
+	logger            zerolog.Logger
+	authAuditRepo     usecase.AuthAuditRepository
+	impersonationRepo usecase.ImpersonationRepository
+	msgQueue          messagequeue.MessageQueue
+}
+
+func NewAuditService(logger zerolog.Logger, authAuditRepo usecase.AuthAuditRepository, impersonationRepo usecase.ImpersonationRepository, msgQueue messagequeue.MessageQueue) *Service {
+	logger = logger.
+		With().
+		Str("Service", "Audit").
+		Logger()
+	return &Service{
+		logger:            logger,
+		authAuditRepo:     authAuditRepo,
+		impersonationRepo: impersonationRepo,
+		msgQueue:          msgQueue,
+	}
+}
+
+func (s *Service) Record(authEvent event.AuthEvent) {
+	authEvent.OccurredAt = util.GetCurrentUtcTime(7)
+	authAudit := entity.AuthAudit{
+		AuditEntity: entity.AuditEntity{
+			Id: uuid.New(),
+		},
+		EventType:  authEvent.Type,
+		UserId:     authEvent.UserId,
+		ActorId:    authEvent.ActorId,
+		Ip:         authEvent.Ip,
+		Detail:     "{}",
+		OccurredAt: authEvent.OccurredAt,
+	}
+	if authEvent.Detail != nil {
+		detail, marshalErr := json.Marshal(authEvent.Detail)
+		if marshalErr != nil {
+			s.logger.Error().Err(marshalErr).Msgf("Failed to marshal detail of %s", authEvent.Type)
+		} else {
+			authAudit.Detail = string(detail)
+		}
+	}
+	if addErr := s.authAuditRepo.AddAuthAudit(authAudit); addErr != nil {
+		s.logger.Error().Err(addErr).Msgf("Failed to save %s audit of user %s", authEvent.Type, authEvent.UserId)
+	}
+
+	background.Go(s.logger, func() {
+		publishErr := s.msgQueue.Publish(
+			*messagequeue.NewDefaultExchangeConfig("you_shop", messagequeue.Topic),
+			*messagequeue.NewDefaultQueueConfig("", authEvent.Type),
+			authEvent,
+		)
+		if publishErr != nil {
+			s.logger.Error().Err(publishErr).Msgf("Failed to publish %s of user %s", authEvent.Type, authEvent.UserId)
+		}
+	})
+}
+
+func (s *Service) GetAudits(userId uuid.UUID, from time.Time, to time.Time, page int, pageSize int) (*model.AuditPage, *err.AppError) {
+	audits, getErr := s.authAuditRepo.GetAuthAudits(userId, from, to, page-1, pageSize)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	total, countErr := s.authAuditRepo.CountAuthAudits(userId, from, to)
+	if countErr != nil {
+		s.logger.Error().Err(countErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return &model.AuditPage{
+		Audits:   audits,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}, nil
+}
+
+func (s *Service) GetImpersonations(adminId uuid.UUID, userId uuid.UUID, page int, pageSize int) (*model.ImpersonationPage, *err.AppError) {
+	impersonations, getErr := s.impersonationRepo.GetImpersonations(adminId, userId, page-1, pageSize)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	total, countErr := s.impersonationRepo.CountImpersonations(adminId, userId)
+	if countErr != nil {
+		s.logger.Error().Err(countErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return &model.ImpersonationPage{
+		Impersonations: impersonations,
+		Page:           page,
+		PageSize:       pageSize,
+		Total:          total,
+	}, nil
+}
@@ -3,9 +3,39 @@ package auth
 import (
 	"github.com/TechwizsonORG/auth-service/entity"
 	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/auth/model"
+	"github.com/google/uuid"
 )
 
 type AuthInterface interface {
-	Login(email string, username string, password string) (u *entity.User, accessToken string, refreshToken string, e *err.AppError)
+	// Login locks the account and the client ip out for a while once they have failed too many times.
+	// Users with two factor authentication only get a challenge token to complete with CompleteTwoFactorLogin
+	Login(email string, username string, password string, device string, ip string) (*model.LoginResult, *err.AppError)
+	// CompleteTwoFactorLogin issues the tokens once the code of the challenged user is verified
+	CompleteTwoFactorLogin(challengeToken string, code string, device string, ip string) (*model.LoginResult, *err.AppError)
+	// RequestOneTimeCode sends a short lived login code by email or sms, unknown users are silently ignored
+	RequestOneTimeCode(email string, username string, channel string) *err.AppError
+	// LoginWithOneTimeCode logs in with a code from RequestOneTimeCode, a code only survives a few wrong guesses.
+	// The same lockout and two factor challenge as Login apply
+	LoginWithOneTimeCode(email string, username string, code string, device string, ip string) (*model.LoginResult, *err.AppError)
+	// VerifyPassword checks the current password of a signed in user, wrong passwords count toward the login lockout
+	// and a locked out user gets an error
+	VerifyPassword(user *entity.User, password string, ip string) (bool, *err.AppError)
+	// UnlockUser clears the failed logins of the user, lifting any lockout
+	UnlockUser(userId uuid.UUID) *err.AppError
+	// Logout revokes the access token and, when given, the refresh token family of the session
+	Logout(accessToken string, refreshToken string) *err.AppError
 	Register(email string, username string, password string, phoneNumber string) (*entity.User, *err.AppError)
+	// VerifyEmail marks the email of the token owner as verified
+	VerifyEmail(verificationToken string) *err.AppError
+	// ResendVerificationEmail mails a new verification link in the background, unknown or verified emails are silently ignored
+	ResendVerificationEmail(email string) *err.AppError
+	// VerifyLegacyEmails marks users who registered before email verification was released as verified,
+	// so requiring verification doesn't lock them out. It does nothing until the release time is configured
+	VerifyLegacyEmails() *err.AppError
+	// ForgotPassword mails a single use reset link to the user in the background, unknown emails are silently ignored.
+	// Requests are throttled per email silently and per ip with an error
+	ForgotPassword(email string, ip string) *err.AppError
+	// ResetPassword sets a new password with a token from ForgotPassword and ends every session of the user
+	ResetPassword(resetToken string, password string) *err.AppError
 }
@@ -0,0 +1,13 @@
+package model
+
+import "github.com/TechwizsonORG/auth-service/entity"
+
+type LoginResult struct {
+	User         *entity.User
+	AccessToken  string
+	RefreshToken string
+	// Set instead of the tokens when the login has to be completed with a two factor code
+	ChallengeToken string
+	// The user has to enroll two factor authentication before completing the login
+	EnrollmentRequired bool
+}
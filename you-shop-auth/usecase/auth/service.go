@@ -1,56 +1,472 @@
 package auth
 
 import (
+	"crypto/subtle"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/background"
+	configModel "github.com/TechwizsonORG/auth-service/config/model"
 	"github.com/TechwizsonORG/auth-service/entity"
 	"github.com/TechwizsonORG/auth-service/err"
 	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/audit"
+	"github.com/TechwizsonORG/auth-service/usecase/auth/model"
+	"github.com/TechwizsonORG/auth-service/usecase/mailer"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue/event"
+	"github.com/TechwizsonORG/auth-service/usecase/notifier"
 	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/TechwizsonORG/auth-service/usecase/twofactor"
 	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
 
+const (
+	passwordResetTokenExpireTime     = 30 * time.Minute
+	emailVerificationTokenExpireTime = 24 * time.Hour
+	// Failed logins older than this are forgotten
+	failedLoginResetTime  = 24 * time.Hour
+	oneTimeCodeDigits     = 6
+	oneTimeCodeExpireTime = 10 * time.Minute
+	// Another code can't be sent before this, it keeps users from being flooded with codes
+	oneTimeCodeResendTime = time.Minute
+	// Wrong guesses before the code has to be requested again
+	oneTimeCodeMaxAttempts = 5
+	// Password reset mails sent to one email, and requests accepted from one ip, until they stop for the window
+	passwordResetMaxRequestsPerEmail = 3
+	passwordResetMaxRequestsPerIp    = 10
+	passwordResetRequestWindow       = time.Hour
+)
+
 type Service struct {
-	logger       zerolog.Logger
-	userRepo     usecase.UserRepository
-	tokenService token.TokenInterface
+	logger                     zerolog.Logger
+	userRepo                   usecase.UserRepository
+	tokenService               token.TokenInterface
+	passwordResetTokenRepo     usecase.PasswordResetTokenRepository
+	emailVerificationTokenRepo usecase.EmailVerificationTokenRepository
+	loginAttemptRepo           usecase.LoginAttemptRepository
+	oneTimeCodeRepo            usecase.OneTimeCodeRepository
+	twoFactorService           twofactor.TwoFactorInterface
+	auditService               audit.AuditInterface
+	mailer                     mailer.Mailer
+	notifier                   notifier.Notifier
+	mailConfig                 configModel.MailConfig
+	securityConfig             configModel.SecurityConfig
 }
 
-func NewAuthService(logger zerolog.Logger, userRepo usecase.UserRepository, tokenService token.TokenInterface) *Service {
+func NewAuthService(logger zerolog.Logger, userRepo usecase.UserRepository, tokenService token.TokenInterface, passwordResetTokenRepo usecase.PasswordResetTokenRepository, emailVerificationTokenRepo usecase.EmailVerificationTokenRepository, loginAttemptRepo usecase.LoginAttemptRepository, oneTimeCodeRepo usecase.OneTimeCodeRepository, twoFactorService twofactor.TwoFactorInterface, auditService audit.AuditInterface, mailer mailer.Mailer, notifier notifier.Notifier, mailConfig configModel.MailConfig, securityConfig configModel.SecurityConfig) *Service {
 	logger = logger.
 		With().
 		Str("Service", "Auth").
 		Logger()
 	return &Service{
-		logger:       logger,
-		userRepo:     userRepo,
-		tokenService: tokenService,
+		logger:                     logger,
+		userRepo:                   userRepo,
+		tokenService:               tokenService,
+		passwordResetTokenRepo:     passwordResetTokenRepo,
+		emailVerificationTokenRepo: emailVerificationTokenRepo,
+		loginAttemptRepo:           loginAttemptRepo,
+		oneTimeCodeRepo:            oneTimeCodeRepo,
+		twoFactorService:           twoFactorService,
+		auditService:               auditService,
+		mailer:                     mailer,
+		notifier:                   notifier,
+		mailConfig:                 mailConfig,
+		securityConfig:             securityConfig,
 	}
 }
 
-func (s *Service) Login(email string, username string, password string) (u *entity.User, accessToken string, refreshToken string, e *err.AppError) {
+func (s *Service) Login(email string, username string, password string, device string, ip string) (*model.LoginResult, *err.AppError) {
+	failedLogin := event.LoginFailedEvent{
+		Email:    email,
+		Username: username,
+		Ip:       ip,
+	}
+	if e := s.checkLockout(entity.IpLoginAttemptKey(ip), failedLogin); e != nil {
+		return nil, e
+	}
+
 	user, error := s.userRepo.GetUserByEmailOrUsername(email, username)
 	if error != nil {
 		s.logger.Err(error).Msg("")
-		return nil, "", "", err.NewWrongUserNameOrPasswordError()
+		return nil, err.NewWrongUserNameOrPasswordError()
+	}
+	if user == nil {
+		failedLogin.Reason = "user_not_found"
+		s.addFailedLogin(nil, failedLogin)
+		return nil, err.NewWrongUserNameOrPasswordError()
+	}
+	failedLogin.UserId = user.Id
+	if e := s.checkLockout(entity.UserLoginAttemptKey(user.Id), failedLogin); e != nil {
+		return nil, e
 	}
 	if !util.VerifyPassword(password, user.PasswordHash) {
-		return nil, "", "", err.NewWrongUserNameOrPasswordError()
+		failedLogin.Reason = "wrong_password"
+		s.addFailedLogin(user, failedLogin)
+		return nil, err.NewWrongUserNameOrPasswordError()
+	}
+	if s.securityConfig.RequireEmailVerification && !user.IsEmailVerified() {
+		return nil, err.NewEmailNotVerifiedError()
+	}
+	s.rehashPassword(user, password)
+	return s.completeLogin(user, device, ip, "password")
+}
+
+// RequestOneTimeCode sends a login code to the email or the phone number of the user. It answers the same whether the
+// user exists or not, the code is sent in the background so neither a delivery failure nor the response time tells
+func (s *Service) RequestOneTimeCode(email string, username string, channel string) *err.AppError {
+	switch notifier.Channel(channel) {
+	case notifier.EmailChannel, notifier.SmsChannel:
+	default:
+		return err.NewOneTimeCodeError("Channel must be email or sms")
+	}
+	if !s.notifier.Supports(notifier.Channel(channel)) {
+		return err.NewOneTimeCodeError(fmt.Sprintf("Login codes can't be sent by %s", channel))
+	}
+	background.Go(s.logger, func() {
+		s.sendOneTimeCode(email, username, notifier.Channel(channel))
+	})
+	return nil
+}
+
+func (s *Service) sendOneTimeCode(email string, username string, channel notifier.Channel) {
+	user, getErr := s.userRepo.GetUserByEmailOrUsername(email, username)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return
+	}
+	if user == nil {
+		s.logger.Debug().Msgf("One time code requested for unknown user %s%s", email, username)
+		return
+	}
+
+	destination := user.Email
+	if channel == notifier.SmsChannel {
+		destination = user.PhoneNumber
+	}
+	if destination == "" {
+		s.logger.Debug().Msgf("User %s has nothing to send a one time code to by %s", user.Id, channel)
+		return
+	}
+
+	current := util.GetCurrentUtcTime(7)
+	latestCode, getErr := s.oneTimeCodeRepo.GetLatestOneTimeCode(user.Id)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return
+	}
+	if latestCode != nil && !latestCode.IsUsed() && current.Sub(latestCode.CreatedAt) < oneTimeCodeResendTime {
+		s.logger.Debug().Msgf("One time code of user %s was sent less than %s ago", user.Id, oneTimeCodeResendTime)
+		return
+	}
+
+	code, generateErr := util.GenerateNumericCode(oneTimeCodeDigits)
+	if generateErr != nil {
+		s.logger.Error().Err(generateErr).Msg("")
+		return
+	}
+	oneTimeCode := entity.NewOneTimeCode(util.HashToken(code), user.Id, string(channel), current.Add(oneTimeCodeExpireTime))
+	if replaceErr := s.oneTimeCodeRepo.ReplaceOneTimeCode(*oneTimeCode); replaceErr != nil {
+		s.logger.Error().Err(replaceErr).Msg("")
+		return
+	}
+
+	notification := notifier.Notification{
+		Channel: channel,
+		To:      destination,
+		Subject: "Your login code",
+		Body: fmt.Sprintf(
+			"Your You Shop login code is %s, it expires in %d minutes. Don't share it with anyone.",
+			code,
+			int(oneTimeCodeExpireTime.Minutes()),
+		),
+	}
+	if notifyErr := s.notifier.Notify(notification); notifyErr != nil {
+		s.logger.Error().Err(notifyErr).Msgf("Failed to send one time code to user %s", user.Id)
+	}
+}
+
+func (s *Service) LoginWithOneTimeCode(email string, username string, code string, device string, ip string) (*model.LoginResult, *err.AppError) {
+	failedLogin := event.LoginFailedEvent{
+		Email:    email,
+		Username: username,
+		Ip:       ip,
+	}
+	if e := s.checkLockout(entity.IpLoginAttemptKey(ip), failedLogin); e != nil {
+		return nil, e
+	}
+
+	user, getErr := s.userRepo.GetUserByEmailOrUsername(email, username)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if user == nil {
+		failedLogin.Reason = "user_not_found"
+		s.addFailedLogin(nil, failedLogin)
+		return nil, err.NewInvalidOneTimeCodeError()
+	}
+	failedLogin.UserId = user.Id
+	if e := s.checkLockout(entity.UserLoginAttemptKey(user.Id), failedLogin); e != nil {
+		return nil, e
 	}
 
-	accessToken, e = s.tokenService.GenerateToken(user.Id, token.AccessToken)
+	oneTimeCode, getErr := s.oneTimeCodeRepo.GetLatestOneTimeCode(user.Id)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if oneTimeCode == nil || oneTimeCode.IsUsed() || oneTimeCode.IsExpired(util.GetCurrentUtcTime(7)) || oneTimeCode.FailedAttempts >= oneTimeCodeMaxAttempts {
+		failedLogin.Reason = "wrong_one_time_code"
+		s.addFailedLogin(user, failedLogin)
+		return nil, err.NewInvalidOneTimeCodeError()
+	}
+	if subtle.ConstantTimeCompare([]byte(util.HashToken(code)), []byte(oneTimeCode.Code)) != 1 {
+		if _, addErr := s.oneTimeCodeRepo.AddFailedOneTimeCodeAttempt(oneTimeCode.Id); addErr != nil {
+			s.logger.Error().Err(addErr).Msgf("Failed to count wrong one time code of user %s", user.Id)
+		}
+		failedLogin.Reason = "wrong_one_time_code"
+		s.addFailedLogin(user, failedLogin)
+		return nil, err.NewInvalidOneTimeCodeError()
+	}
+	ok, useErr := s.oneTimeCodeRepo.UseOneTimeCode(oneTimeCode.Id)
+	if useErr != nil {
+		s.logger.Error().Err(useErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if !ok {
+		return nil, err.NewInvalidOneTimeCodeError()
+	}
 
+	if !user.IsEmailVerified() && notifier.Channel(oneTimeCode.Channel) == notifier.EmailChannel {
+		// The code reached the inbox, which proves the email belongs to the user
+		if verifyErr := s.userRepo.VerifyEmail(user.Id); verifyErr != nil {
+			s.logger.Error().Err(verifyErr).Msg("")
+			return nil, err.NewUnhandledError()
+		}
+		user.EmailVerifiedAt = util.GetCurrentUtcTime(7)
+	}
+	if s.securityConfig.RequireEmailVerification && !user.IsEmailVerified() {
+		return nil, err.NewEmailNotVerifiedError()
+	}
+	return s.completeLogin(user, device, ip, "one_time_code")
+}
+
+// completeLogin challenges users with two factor authentication once the first factor passed, the others get their tokens
+func (s *Service) completeLogin(user *entity.User, device string, ip string, method string) (*model.LoginResult, *err.AppError) {
+	enabled, required, e := s.twoFactorService.GetStatus(user.Id)
 	if e != nil {
-		return nil, "", "", e
+		return nil, e
+	}
+	if enabled || required {
+		challengeType := token.TwoFactorChallengeToken
+		if !enabled {
+			challengeType = token.TwoFactorEnrollmentToken
+		}
+		challengeToken, e := s.tokenService.GenerateChallengeToken(user.Id, challengeType)
+		if e != nil {
+			return nil, e
+		}
+		return &model.LoginResult{
+			User:               user,
+			ChallengeToken:     challengeToken,
+			EnrollmentRequired: !enabled,
+		}, nil
 	}
+	return s.issueTokens(user, device, ip, method)
+}
 
-	refreshToken, e = s.tokenService.GenerateToken(user.Id, token.RefreshToken)
+func (s *Service) CompleteTwoFactorLogin(challengeToken string, code string, device string, ip string) (*model.LoginResult, *err.AppError) {
+	userId, e := s.tokenService.ValidateChallengeToken(challengeToken, token.TwoFactorChallengeToken)
+	if e != nil {
+		return nil, e
+	}
+	failedLogin := event.LoginFailedEvent{
+		UserId: userId,
+		Ip:     ip,
+	}
+	if e = s.checkLockout(entity.UserLoginAttemptKey(userId), failedLogin); e != nil {
+		return nil, e
+	}
+	user, getErr := s.userRepo.GetUserByID(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if user == nil {
+		return nil, err.NewInvalidTwoFactorCodeError()
+	}
+	if e = s.twoFactorService.Verify(userId, code); e != nil {
+		if e.Code == 401 {
+			failedLogin.Email = user.Email
+			failedLogin.Username = user.Username
+			failedLogin.Reason = "wrong_two_factor_code"
+			s.addFailedLogin(user, failedLogin)
+		}
+		return nil, e
+	}
+	if e = s.tokenService.UseChallengeToken(challengeToken, token.TwoFactorChallengeToken); e != nil {
+		return nil, e
+	}
+	return s.issueTokens(user, device, ip, "two_factor")
+}
+
+// issueTokens ends a successful login, the failed logins of the user are forgotten
+func (s *Service) issueTokens(user *entity.User, device string, ip string, method string) (*model.LoginResult, *err.AppError) {
+	if deleteErr := s.loginAttemptRepo.DeleteLoginAttempt(entity.UserLoginAttemptKey(user.Id)); deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("Failed to reset failed logins")
+	}
+
+	accessToken, refreshToken, e := s.tokenService.GenerateSessionTokens(user.Id, device, ip)
 
 	if e != nil {
-		return nil, "", "", e
+		return nil, e
+	}
+
+	s.auditService.Record(event.AuthEvent{
+		Type:   event.AuthLoginSucceeded,
+		UserId: user.Id,
+		Ip:     ip,
+		Detail: event.LoginSucceededDetail{
+			Device: device,
+			Method: method,
+		},
+	})
+
+	return &model.LoginResult{
+		User:         user,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// rehashPassword upgrades a legacy or outdated password hash while the plain password is at hand,
+// a failure only keeps the old hash around until the next login
+func (s *Service) rehashPassword(user *entity.User, password string) {
+	if !util.NeedsRehash(user.PasswordHash) {
+		return
+	}
+	passwordHash, hashErr := util.HashPassword(password)
+	if hashErr != nil {
+		s.logger.Error().Err(hashErr).Msg("Failed to rehash password")
+		return
+	}
+	if updateErr := s.userRepo.UpdatePassword(user.Id, passwordHash); updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("Failed to save rehashed password")
+		return
 	}
+	user.PasswordHash = passwordHash
+	s.logger.Info().Msgf("Password hash of user %s was upgraded", user.Id)
+}
 
-	return user, accessToken, refreshToken, nil
+// checkLockout rejects the login while the key is locked out, the rejected login is still reported
+func (s *Service) checkLockout(key string, failedLogin event.LoginFailedEvent) *err.AppError {
+	loginAttempt, getErr := s.loginAttemptRepo.GetLoginAttempt(key)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	current := util.GetCurrentUtcTime(7)
+	if loginAttempt == nil || !loginAttempt.IsLocked(current) {
+		return nil
+	}
+	failedLogin.Reason = "locked_out"
+	failedLogin.FailedCount = loginAttempt.FailedCount
+	failedLogin.LockedUntil = loginAttempt.LockedUntil
+	s.recordFailedLogin(failedLogin)
+	return err.NewLoginLockedError(loginAttempt.LockedUntil.Sub(current))
 }
+
+// addFailedLogin counts the failure against the client ip and, when known, the user then reports it
+func (s *Service) addFailedLogin(user *entity.User, failedLogin event.LoginFailedEvent) {
+	failedLogin.FailedCount, failedLogin.LockedUntil = s.addFailedLoginAttempt(entity.IpLoginAttemptKey(failedLogin.Ip), s.securityConfig.MaxFailedLoginAttemptsPerIp)
+	if user != nil {
+		failedLogin.FailedCount, failedLogin.LockedUntil = s.addFailedLoginAttempt(entity.UserLoginAttemptKey(user.Id), s.securityConfig.MaxFailedLoginAttempts)
+	}
+	s.recordFailedLogin(failedLogin)
+}
+
+func (s *Service) addFailedLoginAttempt(key string, maxAttempts int) (failedCount int, lockedUntil time.Time) {
+	current := util.GetCurrentUtcTime(7)
+	failedCount, addErr := s.loginAttemptRepo.AddFailedLoginAttempt(key, current, current.Add(-failedLoginResetTime))
+	if addErr != nil {
+		s.logger.Error().Err(addErr).Msgf("Failed to count failed login of %s", key)
+		return 0, time.Time{}
+	}
+	if failedCount < maxAttempts {
+		return failedCount, time.Time{}
+	}
+
+	lockedUntil = current.Add(s.getLockoutTime(failedCount - maxAttempts))
+	if lockErr := s.loginAttemptRepo.LockLoginAttempt(key, lockedUntil); lockErr != nil {
+		s.logger.Error().Err(lockErr).Msgf("Failed to lock %s out", key)
+	}
+	s.logger.Warn().Msgf("%s locked out until %s after %d failed logins", key, lockedUntil, failedCount)
+	return failedCount, lockedUntil
+}
+
+// getLockoutTime doubles the base lockout time for every failed login past the limit
+func (s *Service) getLockoutTime(exceededAttempts int) time.Duration {
+	lockoutTime := time.Second * time.Duration(s.securityConfig.LoginLockoutBaseTime)
+	maxLockoutTime := time.Second * time.Duration(s.securityConfig.LoginLockoutMaxTime)
+	for i := 0; i < exceededAttempts && lockoutTime < maxLockoutTime; i++ {
+		lockoutTime *= 2
+	}
+	if lockoutTime > maxLockoutTime {
+		return maxLockoutTime
+	}
+	return lockoutTime
+}
+
+func (s *Service) recordFailedLogin(failedLogin event.LoginFailedEvent) {
+	s.auditService.Record(event.AuthEvent{
+		Type:   event.AuthLoginFailed,
+		UserId: failedLogin.UserId,
+		Ip:     failedLogin.Ip,
+		Detail: failedLogin,
+	})
+}
+
+// VerifyPassword checks the password of a signed in user. Wrong passwords count toward the login lockout,
+// so a stolen access token can't be used to guess it
+func (s *Service) VerifyPassword(user *entity.User, password string, ip string) (bool, *err.AppError) {
+	failedLogin := event.LoginFailedEvent{
+		UserId:   user.Id,
+		Email:    user.Email,
+		Username: user.Username,
+		Ip:       ip,
+	}
+	if e := s.checkLockout(entity.UserLoginAttemptKey(user.Id), failedLogin); e != nil {
+		return false, e
+	}
+	if !util.VerifyPassword(password, user.PasswordHash) {
+		failedLogin.Reason = "wrong_current_password"
+		s.addFailedLogin(user, failedLogin)
+		return false, nil
+	}
+	return true, nil
+}
+
+func (s *Service) UnlockUser(userId uuid.UUID) *err.AppError {
+	if deleteErr := s.loginAttemptRepo.DeleteLoginAttempt(entity.UserLoginAttemptKey(userId)); deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	return nil
+}
+
+func (s *Service) Logout(accessToken string, refreshToken string) *err.AppError {
+	if e := s.tokenService.RevokeToken(accessToken, token.AccessToken); e != nil {
+		return e
+	}
+	if refreshToken == "" {
+		return nil
+	}
+	return s.tokenService.RevokeToken(refreshToken, token.RefreshToken)
+}
+
 func (s *Service) Register(email string, username string, password string, phoneNumber string) (*entity.User, *err.AppError) {
 
 	user, error := s.userRepo.GetUserByEmailOrUsername(email, "")
@@ -85,5 +501,206 @@ func (s *Service) Register(email string, username string, password string, phone
 		return nil, err.NewCreateUserError("Error occurred", nil)
 	}
 
+	s.auditService.Record(event.AuthEvent{
+		Type:   event.AuthUserRegistered,
+		UserId: createdUser.Id,
+	})
+
+	// The user can ask for another mail, registration shouldn't fail because of the mail server
+	if e := s.sendVerificationEmail(createdUser); e != nil {
+		s.logger.Warn().Msgf("Couldn't send verification email to user %s", createdUser.Id)
+	}
 	return &createdUser, nil
 }
+
+func (s *Service) VerifyEmail(verificationToken string) *err.AppError {
+	invalidToken := err.NewEmailVerificationError("Verification token is invalid or expired")
+
+	emailVerificationToken, getErr := s.emailVerificationTokenRepo.GetEmailVerificationToken(util.HashToken(verificationToken))
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if emailVerificationToken == nil || emailVerificationToken.IsExpired(util.GetCurrentUtcTime(7)) {
+		return invalidToken
+	}
+	ok, useErr := s.emailVerificationTokenRepo.UseEmailVerificationToken(emailVerificationToken.Id)
+	if useErr != nil {
+		s.logger.Error().Err(useErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !ok {
+		return invalidToken
+	}
+	if verifyErr := s.userRepo.VerifyEmail(emailVerificationToken.UserId); verifyErr != nil {
+		s.logger.Error().Err(verifyErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	return nil
+}
+
+// ResendVerificationEmail answers the same whether the email is registered or not, the mail is sent in the background
+// so neither a mail failure nor the response time tells
+func (s *Service) ResendVerificationEmail(email string) *err.AppError {
+	background.Go(s.logger, func() {
+		user, getErr := s.userRepo.GetUserByEmailOrUsername(email, "")
+		if getErr != nil {
+			s.logger.Error().Err(getErr).Msg("")
+			return
+		}
+		if user == nil || user.IsEmailVerified() {
+			return
+		}
+		if e := s.sendVerificationEmail(*user); e != nil {
+			s.logger.Warn().Msgf("Couldn't resend verification email to user %s", user.Id)
+		}
+	})
+	return nil
+}
+
+func (s *Service) VerifyLegacyEmails() *err.AppError {
+	if s.securityConfig.EmailVerificationReleasedAt.IsZero() {
+		return nil
+	}
+	verified, updateErr := s.userRepo.VerifyLegacyEmails(s.securityConfig.EmailVerificationReleasedAt)
+	if updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if verified > 0 {
+		s.logger.Info().Msgf("Marked the emails of %d users who registered before email verification as verified", verified)
+	}
+	return nil
+}
+
+func (s *Service) sendVerificationEmail(user entity.User) *err.AppError {
+	verificationToken, generateErr := util.GenerateRandomToken(32)
+	if generateErr != nil {
+		s.logger.Error().Err(generateErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	emailVerificationToken := entity.NewEmailVerificationToken(util.HashToken(verificationToken), user.Id, util.GetCurrentUtcTime(7).Add(emailVerificationTokenExpireTime))
+	if _, addErr := s.emailVerificationTokenRepo.AddEmailVerificationToken(*emailVerificationToken); addErr != nil {
+		s.logger.Error().Err(addErr).Msg("")
+		return err.NewUnhandledError()
+	}
+
+	mail := mailer.Mail{
+		To:      []string{user.Email},
+		Subject: "Verify your email",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nWelcome to You Shop! Please confirm your email with the link below, it expires in %d hours:\n%s\n",
+			user.Username,
+			int(emailVerificationTokenExpireTime.Hours()),
+			buildTokenLink(s.mailConfig.EmailVerificationUrl, verificationToken),
+		),
+	}
+	if sendErr := s.mailer.Send(mail); sendErr != nil {
+		s.logger.Error().Err(sendErr).Msgf("Failed to send verification mail to user %s", user.Id)
+		return err.NewUnhandledError()
+	}
+	return nil
+}
+
+// ForgotPassword answers the same whether the email is registered or not, the mail is sent in the background
+// so the response time doesn't tell either
+func (s *Service) ForgotPassword(email string, ip string) *err.AppError {
+	if s.countPasswordResetRequest(entity.IpPasswordResetKey(ip)) > passwordResetMaxRequestsPerIp {
+		return err.NewTooManyRequestsError("Too many password reset requests, try again later")
+	}
+	background.Go(s.logger, func() {
+		s.sendPasswordResetMail(email)
+	})
+	return nil
+}
+
+func (s *Service) sendPasswordResetMail(email string) {
+	if s.countPasswordResetRequest(entity.EmailPasswordResetKey(email)) > passwordResetMaxRequestsPerEmail {
+		s.logger.Warn().Msgf("Password reset mails to %s are throttled", email)
+		return
+	}
+	user, getErr := s.userRepo.GetUserByEmailOrUsername(email, "")
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return
+	}
+	if user == nil {
+		s.logger.Debug().Msgf("Password reset requested for unknown email %s", email)
+		return
+	}
+
+	resetToken, generateErr := util.GenerateRandomToken(32)
+	if generateErr != nil {
+		s.logger.Error().Err(generateErr).Msg("")
+		return
+	}
+	passwordResetToken := entity.NewPasswordResetToken(util.HashToken(resetToken), user.Id, util.GetCurrentUtcTime(7).Add(passwordResetTokenExpireTime))
+	if _, addErr := s.passwordResetTokenRepo.AddPasswordResetToken(*passwordResetToken); addErr != nil {
+		s.logger.Error().Err(addErr).Msg("")
+		return
+	}
+
+	mail := mailer.Mail{
+		To:      []string{user.Email},
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to reset your password, it expires in %d minutes:\n%s\n\nIf you didn't ask for a password reset, you can ignore this email.\n",
+			user.Username,
+			int(passwordResetTokenExpireTime.Minutes()),
+			buildTokenLink(s.mailConfig.PasswordResetUrl, resetToken),
+		),
+	}
+	if sendErr := s.mailer.Send(mail); sendErr != nil {
+		s.logger.Error().Err(sendErr).Msgf("Failed to send password reset mail to user %s", user.Id)
+	}
+}
+
+// countPasswordResetRequest counts the request against the key within the window, a failure to count lets it through
+func (s *Service) countPasswordResetRequest(key string) int {
+	current := util.GetCurrentUtcTime(7)
+	count, addErr := s.loginAttemptRepo.AddFailedLoginAttempt(key, current, current.Add(-passwordResetRequestWindow))
+	if addErr != nil {
+		s.logger.Error().Err(addErr).Msgf("Failed to count password reset request of %s", key)
+		return 0
+	}
+	return count
+}
+
+func (s *Service) ResetPassword(resetToken string, password string) *err.AppError {
+	if password == "" {
+		return err.NewPasswordResetError("Password is required")
+	}
+	invalidToken := err.NewPasswordResetError("Reset token is invalid or expired")
+
+	passwordResetToken, getErr := s.passwordResetTokenRepo.GetPasswordResetToken(util.HashToken(resetToken))
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if passwordResetToken == nil || passwordResetToken.IsExpired(util.GetCurrentUtcTime(7)) {
+		return invalidToken
+	}
+	// Hash first so a failure doesn't burn the token
+	passwordHash, hashErr := util.HashPassword(password)
+	if hashErr != nil {
+		s.logger.Error().Err(hashErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	ok, useErr := s.passwordResetTokenRepo.UsePasswordResetToken(passwordResetToken.Id)
+	if useErr != nil {
+		s.logger.Error().Err(useErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !ok {
+		return invalidToken
+	}
+	if updateErr := s.userRepo.UpdatePassword(passwordResetToken.UserId, passwordHash); updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	return s.tokenService.RevokeUserTokens(passwordResetToken.UserId, "password_reset")
+}
+
+func buildTokenLink(baseUrl string, token string) string {
+	return fmt.Sprintf("%s?token=%s", baseUrl, url.QueryEscape(token))
+}
@@ -0,0 +1,12 @@
+package image
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+type ImageUploader interface {
+	// Upload stores the image in the image service and returns the url it is served from
+	Upload(ownerId uuid.UUID, fileName string, alt string, content io.Reader) (string, error)
+}
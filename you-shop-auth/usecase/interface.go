@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"time"
+
 	"github.com/TechwizsonORG/auth-service/entity"
 	"github.com/google/uuid"
 )
@@ -10,11 +12,21 @@ import (
 type UserReader interface {
 	GetUserByID(id uuid.UUID) (*entity.User, error)
 	GetUserByEmailOrUsername(email string, username string) (*entity.User, error)
+	GetUserByIDIncludeInactive(id uuid.UUID) (*entity.User, error)
+	GetUsers(search string, pageIndex int, pageSize int) ([]entity.User, error)
+	CountUsers(search string) (int, error)
 }
 
 type UserWriter interface {
 	AddUser(user entity.User) (entity.User, error)
 	UpdateUser(user entity.User) (entity.User, error)
+	UpdatePassword(id uuid.UUID, passwordHash string) error
+	UpdateEmail(id uuid.UUID, email string, normalizedEmail string) error
+	VerifyEmail(id uuid.UUID) error
+	VerifyLegacyEmails(releasedAt time.Time) (int64, error)
+	SetUserActive(id uuid.UUID, isActive bool) (bool, error)
+	// DeleteUser erases the personal data of the anonymized user and saves the outbox message in one transaction
+	DeleteUser(user entity.User, loginAttemptKeys []string, outboxMessage entity.OutboxMessage) error
 }
 
 type UserRepository interface {
@@ -25,25 +37,125 @@ type UserRepository interface {
 // Role section
 type RoleReader interface {
 	GetRolesByUserId(userId uuid.UUID) []entity.Role
+	GetEffectiveRolesByUserId(userId uuid.UUID) []entity.Role
+	GetRoles() ([]entity.Role, error)
+	GetRoleByName(name string) (*entity.Role, error)
+}
+
+type RoleWriter interface {
+	AddRole(role entity.Role) (entity.Role, error)
+	UpdateRole(role entity.Role) (entity.Role, error)
+	DeleteRole(id uuid.UUID) error
+	AddUserRole(userId uuid.UUID, roleId uuid.UUID) error
+	RemoveUserRole(userId uuid.UUID, roleId uuid.UUID) (bool, error)
+	AddRoleScope(roleId uuid.UUID, scopeId uuid.UUID) error
+	RemoveRoleScope(roleId uuid.UUID, scopeId uuid.UUID) (bool, error)
 }
 
 type RoleRepository interface {
 	RoleReader
+	RoleWriter
 }
 
 // Scope section
 type ScopeReader interface {
 	GetScopesByUserId(userId uuid.UUID) []entity.Scope
+	GetPermissionsByUserId(userId uuid.UUID) []entity.Scope
+	GetScopesByRoleId(roleId uuid.UUID) []entity.Scope
+	GetScopes() ([]entity.Scope, error)
+	GetScopeByName(name string) (*entity.Scope, error)
+}
+
+type ScopeWriter interface {
+	AddScope(scope entity.Scope) (entity.Scope, error)
+	UpdateScope(scope entity.Scope) (entity.Scope, error)
+	DeleteScope(id uuid.UUID) error
+	AddUserScope(userId uuid.UUID, scopeId uuid.UUID) error
+	RemoveUserScope(userId uuid.UUID, scopeId uuid.UUID) (bool, error)
 }
 
 type ScopeRepository interface {
 	ScopeReader
+	ScopeWriter
+}
+
+// Refresh Token section
+
+type RefreshTokenReader interface {
+	GetRefreshTokenById(id uuid.UUID) (*entity.RefreshToken, error)
+}
+
+type RefreshTokenWriter interface {
+	AddRefreshToken(refreshToken entity.RefreshToken) (entity.RefreshToken, error)
+	RotateRefreshToken(id uuid.UUID, replacedBy uuid.UUID) (bool, error)
+	RevokeRefreshTokenFamily(familyId uuid.UUID) error
+	RevokeUserRefreshTokens(userId uuid.UUID) error
+	DeleteExpiredRefreshTokens(before time.Time) (int64, error)
+}
+
+type RefreshTokenRepository interface {
+	RefreshTokenReader
+	RefreshTokenWriter
+}
+
+// Session section
+
+type SessionReader interface {
+	GetSessionById(id uuid.UUID) (*entity.Session, error)
+	GetActiveSessionsByUserId(userId uuid.UUID, current time.Time) ([]entity.Session, error)
+}
+
+type SessionWriter interface {
+	AddSession(session entity.Session) (entity.Session, error)
+	TouchSession(id uuid.UUID, userAgent string, ip string, expiresAt time.Time) error
+	RevokeSession(id uuid.UUID) (bool, error)
+	RevokeUserSessions(userId uuid.UUID) error
+	DeleteExpiredSessions(before time.Time) (int64, error)
+}
+
+type SessionRepository interface {
+	SessionReader
+	SessionWriter
+}
+
+// Access Token section
+
+type AccessTokenReader interface {
+	IsAccessTokenRevoked(id uuid.UUID) (bool, error)
+}
+
+type AccessTokenWriter interface {
+	RevokeAccessToken(accessToken entity.AccessToken) (bool, error)
+	DeleteExpiredAccessTokens(before time.Time) (int64, error)
+}
+
+type AccessTokenRepository interface {
+	AccessTokenReader
+	AccessTokenWriter
+}
+
+// Signing Key section
+
+type SigningKeyReader interface {
+	GetPublishedSigningKeys(current time.Time) ([]entity.SigningKey, error)
+}
+
+type SigningKeyWriter interface {
+	AddSigningKey(signingKey entity.SigningKey) (entity.SigningKey, error)
+	RotateSigningKey(id uuid.UUID, rotatedAt time.Time, expiresAt time.Time) error
+	DeleteExpiredSigningKeys(before time.Time) (int64, error)
+}
+
+type SigningKeyRepository interface {
+	SigningKeyReader
+	SigningKeyWriter
 }
 
 // Client section
 
 type ClientReader interface {
-	GetClientByID(id uuid.UUID) (entity.Client, error)
+	GetClientByID(id uuid.UUID) (*entity.Client, error)
+	GetClientByClientId(clientId string) (*entity.Client, error)
 }
 
 type ClientWriter interface {
@@ -59,14 +171,185 @@ type ClientRepository interface {
 // Authorization Code section
 
 type AuthorizationCodeReader interface {
-	GetAuthorizationCode(code string) (entity.AuthorizationCode, error)
+	GetAuthorizationCode(code string) (*entity.AuthorizationCode, error)
 }
 
 type AuthorizationCodeWriter interface {
 	AddAuthorizationCode(code entity.AuthorizationCode) (entity.AuthorizationCode, error)
+	UseAuthorizationCode(id uuid.UUID) (bool, error)
 }
 
 type AuthorizationCodeRepository interface {
 	AuthorizationCodeReader
 	AuthorizationCodeWriter
 }
+
+// Consent section
+
+type ConsentReader interface {
+	GetConsent(userId uuid.UUID, clientId uuid.UUID) (*entity.Consent, error)
+}
+
+type ConsentWriter interface {
+	SaveConsent(consent entity.Consent) (entity.Consent, error)
+}
+
+type ConsentRepository interface {
+	ConsentReader
+	ConsentWriter
+}
+
+// Password Reset Token section
+
+type PasswordResetTokenReader interface {
+	GetPasswordResetToken(token string) (*entity.PasswordResetToken, error)
+}
+
+type PasswordResetTokenWriter interface {
+	AddPasswordResetToken(token entity.PasswordResetToken) (entity.PasswordResetToken, error)
+	UsePasswordResetToken(id uuid.UUID) (bool, error)
+}
+
+type PasswordResetTokenRepository interface {
+	PasswordResetTokenReader
+	PasswordResetTokenWriter
+}
+
+// Email Verification Token section
+
+type EmailVerificationTokenReader interface {
+	GetEmailVerificationToken(token string) (*entity.EmailVerificationToken, error)
+}
+
+type EmailVerificationTokenWriter interface {
+	AddEmailVerificationToken(token entity.EmailVerificationToken) (entity.EmailVerificationToken, error)
+	UseEmailVerificationToken(id uuid.UUID) (bool, error)
+}
+
+type EmailVerificationTokenRepository interface {
+	EmailVerificationTokenReader
+	EmailVerificationTokenWriter
+}
+
+// One Time Code section
+
+type OneTimeCodeReader interface {
+	GetLatestOneTimeCode(userId uuid.UUID) (*entity.OneTimeCode, error)
+}
+
+type OneTimeCodeWriter interface {
+	ReplaceOneTimeCode(oneTimeCode entity.OneTimeCode) error
+	AddFailedOneTimeCodeAttempt(id uuid.UUID) (int, error)
+	UseOneTimeCode(id uuid.UUID) (bool, error)
+}
+
+type OneTimeCodeRepository interface {
+	OneTimeCodeReader
+	OneTimeCodeWriter
+}
+
+// Login Attempt section
+
+type LoginAttemptReader interface {
+	GetLoginAttempt(key string) (*entity.LoginAttempt, error)
+}
+
+type LoginAttemptWriter interface {
+	AddFailedLoginAttempt(key string, current time.Time, resetBefore time.Time) (int, error)
+	LockLoginAttempt(key string, lockedUntil time.Time) error
+	DeleteLoginAttempt(key string) error
+}
+
+type LoginAttemptRepository interface {
+	LoginAttemptReader
+	LoginAttemptWriter
+}
+
+// Two Factor section
+
+type TwoFactorReader interface {
+	GetTwoFactorByUserId(userId uuid.UUID) (*entity.TwoFactor, error)
+}
+
+type TwoFactorWriter interface {
+	SaveTwoFactor(twoFactor entity.TwoFactor) (entity.TwoFactor, error)
+	EnableTwoFactor(userId uuid.UUID, enabledAt time.Time) error
+	UseTwoFactorStep(userId uuid.UUID, step int64) (bool, error)
+	DeleteTwoFactor(userId uuid.UUID) error
+}
+
+type TwoFactorRepository interface {
+	TwoFactorReader
+	TwoFactorWriter
+}
+
+// Recovery Code section
+
+type RecoveryCodeWriter interface {
+	ReplaceRecoveryCodes(userId uuid.UUID, recoveryCodes []entity.RecoveryCode) error
+	UseRecoveryCode(userId uuid.UUID, code string) (bool, error)
+}
+
+type RecoveryCodeRepository interface {
+	RecoveryCodeWriter
+}
+
+// Auth Audit section
+
+type AuthAuditReader interface {
+	GetAuthAudits(userId uuid.UUID, from time.Time, to time.Time, pageIndex int, pageSize int) ([]entity.AuthAudit, error)
+	CountAuthAudits(userId uuid.UUID, from time.Time, to time.Time) (int, error)
+}
+
+type AuthAuditWriter interface {
+	AddAuthAudit(authAudit entity.AuthAudit) error
+}
+
+type AuthAuditRepository interface {
+	AuthAuditReader
+	AuthAuditWriter
+}
+
+// Impersonation section
+
+type ImpersonationReader interface {
+	GetImpersonationById(id uuid.UUID) (*entity.Impersonation, error)
+	GetImpersonations(adminId uuid.UUID, userId uuid.UUID, pageIndex int, pageSize int) ([]entity.Impersonation, error)
+	CountImpersonations(adminId uuid.UUID, userId uuid.UUID) (int, error)
+}
+
+type ImpersonationWriter interface {
+	AddImpersonation(impersonation entity.Impersonation) error
+	EndImpersonation(id uuid.UUID, endedAt time.Time) (bool, error)
+}
+
+type ImpersonationRepository interface {
+	ImpersonationReader
+	ImpersonationWriter
+}
+
+// Api Key section
+
+type ApiKeyReader interface {
+	GetApiKeyByHash(keyHash string) (*entity.ApiKey, error)
+	GetApiKeysByUserId(userId uuid.UUID) ([]entity.ApiKey, error)
+}
+
+type ApiKeyWriter interface {
+	AddApiKey(apiKey entity.ApiKey) (entity.ApiKey, error)
+	TouchApiKey(id uuid.UUID, lastUsedAt time.Time) error
+	RevokeApiKey(userId uuid.UUID, id uuid.UUID) (bool, error)
+	RevokeUserApiKeys(userId uuid.UUID) error
+}
+
+type ApiKeyRepository interface {
+	ApiKeyReader
+	ApiKeyWriter
+}
+
+// Outbox section
+
+type OutboxRepository interface {
+	GetPendingOutboxMessages(limit int) ([]entity.OutboxMessage, error)
+	MarkOutboxMessagePublished(id uuid.UUID, publishedAt time.Time) error
+}
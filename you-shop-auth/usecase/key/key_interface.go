@@ -0,0 +1,23 @@
+package key
+
+import (
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/key/model"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signer holds everything needed to sign a token with the current key
+type Signer struct {
+	Kid    string
+	Method jwt.SigningMethod
+	Key    interface{}
+}
+
+type KeyInterface interface {
+	GetSigner() (*Signer, *err.AppError)
+	// Keyfunc resolves the verification key of a token by its `kid` header
+	Keyfunc(token *jwt.Token) (interface{}, error)
+	GetJsonWebKeySet() (*model.JsonWebKeySet, *err.AppError)
+	// RotateKeyIfDue replaces the current signing key once it is older than the configured rotation interval
+	RotateKeyIfDue() *err.AppError
+}
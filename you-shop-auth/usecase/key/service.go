@@ -0,0 +1,355 @@
+package key
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	configModel "github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/key/model"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// minReloadInterval bounds how often an unknown kid can reload the keys from the database
+const minReloadInterval = 10 * time.Second
+
+type parsedKey struct {
+	entity     entity.SigningKey
+	method     jwt.SigningMethod
+	privateKey crypto.PrivateKey
+	publicKey  crypto.PublicKey
+}
+
+type Service struct {
+	logger    zerolog.Logger
+	jwtConfig configModel.JwtConfig
+	repo      usecase.SigningKeyRepository
+	method    jwt.SigningMethod
+
+	mu      sync.RWMutex
+	current *parsedKey
+	keys    map[string]*parsedKey
+	// Tokens signed with the shared secret are accepted until then after switching to asymmetric keys
+	legacyUntil time.Time
+	lastReload  time.Time
+}
+
+func NewKeyService(logger zerolog.Logger, jwtConfig configModel.JwtConfig, repo usecase.SigningKeyRepository) *Service {
+	logger = logger.
+		With().
+		Str("service", "key").
+		Logger()
+	var method jwt.SigningMethod
+	switch jwtConfig.SigningAlgorithm {
+	case jwt.SigningMethodRS256.Alg():
+		method = jwt.SigningMethodRS256
+	case jwt.SigningMethodEdDSA.Alg():
+		method = jwt.SigningMethodEdDSA
+	default:
+		method = jwt.SigningMethodHS256
+	}
+	return &Service{
+		logger:    logger,
+		jwtConfig: jwtConfig,
+		repo:      repo,
+		method:    method,
+		keys:      map[string]*parsedKey{},
+	}
+}
+
+func (s *Service) isSymmetric() bool {
+	return s.method == jwt.SigningMethodHS256
+}
+
+func (s *Service) GetSigner() (*Signer, *err.AppError) {
+	if s.isSymmetric() {
+		return &Signer{
+			Method: s.method,
+			Key:    []byte(s.jwtConfig.SecretKey),
+		}, nil
+	}
+
+	s.mu.RLock()
+	current := s.current
+	s.mu.RUnlock()
+	if current == nil {
+		if loadErr := s.loadKeys(); loadErr != nil {
+			return nil, loadErr
+		}
+		s.mu.RLock()
+		current = s.current
+		s.mu.RUnlock()
+	}
+	if current == nil {
+		if rotateErr := s.rotateKey(); rotateErr != nil {
+			return nil, rotateErr
+		}
+		s.mu.RLock()
+		current = s.current
+		s.mu.RUnlock()
+	}
+	return &Signer{
+		Kid:    current.entity.Id.String(),
+		Method: current.method,
+		Key:    current.privateKey,
+	}, nil
+}
+
+func (s *Service) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if token.Method != jwt.SigningMethodHS256 || s.jwtConfig.SecretKey == "" {
+			return nil, errors.New("missing kid header")
+		}
+		if s.isSymmetric() {
+			return []byte(s.jwtConfig.SecretKey), nil
+		}
+		// Tokens signed before asymmetric keys were enabled don't carry a kid,
+		// they are only valid until the longest token lifetime has passed since the first key
+		s.mu.RLock()
+		legacyUntil := s.legacyUntil
+		s.mu.RUnlock()
+		if util.GetCurrentUtcTime(7).Before(legacyUntil) {
+			return []byte(s.jwtConfig.SecretKey), nil
+		}
+		return nil, errors.New("shared secret tokens are no longer accepted")
+	}
+
+	s.mu.RLock()
+	parsed, ok := s.keys[kid]
+	canReload := time.Since(s.lastReload) >= minReloadInterval
+	s.mu.RUnlock()
+	if !ok && canReload {
+		// The key may have been rotated by another instance
+		if loadErr := s.loadKeys(); loadErr != nil {
+			return nil, loadErr
+		}
+		s.mu.RLock()
+		parsed, ok = s.keys[kid]
+		s.mu.RUnlock()
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown kid %s", kid)
+	}
+	if token.Method.Alg() != parsed.method.Alg() {
+		return nil, fmt.Errorf("invalid signing method %s", token.Method.Alg())
+	}
+	return parsed.publicKey, nil
+}
+
+func (s *Service) GetJsonWebKeySet() (*model.JsonWebKeySet, *err.AppError) {
+	result := &model.JsonWebKeySet{Keys: []model.JsonWebKey{}}
+	if s.isSymmetric() {
+		return result, nil
+	}
+	if loadErr := s.loadKeys(); loadErr != nil {
+		return nil, loadErr
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for kid, parsed := range s.keys {
+		jsonWebKey := model.JsonWebKey{
+			Kid: kid,
+			Use: "sig",
+			Alg: parsed.method.Alg(),
+		}
+		switch publicKey := parsed.publicKey.(type) {
+		case *rsa.PublicKey:
+			jsonWebKey.Kty = "RSA"
+			jsonWebKey.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jsonWebKey.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case ed25519.PublicKey:
+			jsonWebKey.Kty = "OKP"
+			jsonWebKey.Crv = "Ed25519"
+			jsonWebKey.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		}
+		result.Keys = append(result.Keys, jsonWebKey)
+	}
+	return result, nil
+}
+
+func (s *Service) RotateKeyIfDue() *err.AppError {
+	if s.isSymmetric() {
+		return nil
+	}
+	if loadErr := s.loadKeys(); loadErr != nil {
+		return loadErr
+	}
+
+	current := util.GetCurrentUtcTime(7)
+	if _, deleteErr := s.repo.DeleteExpiredSigningKeys(current); deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("Failed to delete expired signing keys")
+	}
+
+	s.mu.RLock()
+	currentKey := s.current
+	s.mu.RUnlock()
+	rotationInterval := time.Hour * time.Duration(s.jwtConfig.KeyRotationInterval)
+	if currentKey != nil && current.Before(currentKey.entity.CreatedAt.Add(rotationInterval)) {
+		return nil
+	}
+	return s.rotateKey()
+}
+
+// rotateKey creates a new signing key and keeps the previous ones published
+// until every token signed by them has expired
+func (s *Service) rotateKey() *err.AppError {
+	s.mu.RLock()
+	previousKeys := []entity.SigningKey{}
+	for _, parsed := range s.keys {
+		if !parsed.entity.IsRotated() {
+			previousKeys = append(previousKeys, parsed.entity)
+		}
+	}
+	s.mu.RUnlock()
+
+	signingKey, generateErr := generateSigningKey(s.method)
+	if generateErr != nil {
+		s.logger.Error().Err(generateErr).Msg("Failed to generate signing key")
+		return err.NewUnhandledError()
+	}
+	if _, addErr := s.repo.AddSigningKey(*signingKey); addErr != nil {
+		s.logger.Error().Err(addErr).Msg("Failed to save signing key")
+		return err.NewUnhandledError()
+	}
+
+	current := util.GetCurrentUtcTime(7)
+	maxTokenLifetime := s.maxTokenLifetime()
+	for _, previous := range previousKeys {
+		if rotateErr := s.repo.RotateSigningKey(previous.Id, current, current.Add(maxTokenLifetime)); rotateErr != nil {
+			s.logger.Error().Err(rotateErr).Msgf("Failed to rotate signing key %s", previous.Id)
+		}
+	}
+	s.logger.Info().Msgf("Signing key rotated, new kid %s", signingKey.Id)
+	return s.loadKeys()
+}
+
+func (s *Service) maxTokenLifetime() time.Duration {
+	maxTokenLifetime := time.Hour * time.Duration(s.jwtConfig.DefaultRefreshExpireTime)
+	if accessLifetime := time.Minute * time.Duration(s.jwtConfig.DefaultAccessExpireTime); accessLifetime > maxTokenLifetime {
+		maxTokenLifetime = accessLifetime
+	}
+	return maxTokenLifetime
+}
+
+func (s *Service) loadKeys() *err.AppError {
+	s.mu.Lock()
+	s.lastReload = time.Now()
+	s.mu.Unlock()
+	signingKeys, getErr := s.repo.GetPublishedSigningKeys(util.GetCurrentUtcTime(7))
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("Failed to get signing keys")
+		return err.NewUnhandledError()
+	}
+
+	var current *parsedKey
+	var oldestCreatedAt time.Time
+	keys := map[string]*parsedKey{}
+	for _, signingKey := range signingKeys {
+		if oldestCreatedAt.IsZero() || signingKey.CreatedAt.Before(oldestCreatedAt) {
+			oldestCreatedAt = signingKey.CreatedAt
+		}
+		parsed, parseErr := parseSigningKey(signingKey)
+		if parseErr != nil {
+			s.logger.Error().Err(parseErr).Msgf("Failed to parse signing key %s", signingKey.Id)
+			continue
+		}
+		keys[signingKey.Id.String()] = parsed
+		// keys are ordered newest first
+		if current == nil && !signingKey.IsRotated() && parsed.method.Alg() == s.method.Alg() {
+			current = parsed
+		}
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.current = current
+	if !oldestCreatedAt.IsZero() {
+		s.legacyUntil = oldestCreatedAt.Add(s.maxTokenLifetime())
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+func generateSigningKey(method jwt.SigningMethod) (*entity.SigningKey, error) {
+	var privateKey crypto.PrivateKey
+	var publicKey crypto.PublicKey
+	switch method {
+	case jwt.SigningMethodRS256:
+		rsaKey, generateErr := rsa.GenerateKey(rand.Reader, 2048)
+		if generateErr != nil {
+			return nil, generateErr
+		}
+		privateKey, publicKey = rsaKey, &rsaKey.PublicKey
+	case jwt.SigningMethodEdDSA:
+		edPublicKey, edPrivateKey, generateErr := ed25519.GenerateKey(rand.Reader)
+		if generateErr != nil {
+			return nil, generateErr
+		}
+		privateKey, publicKey = edPrivateKey, edPublicKey
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %s", method.Alg())
+	}
+
+	privateDer, marshalErr := x509.MarshalPKCS8PrivateKey(privateKey)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	publicDer, marshalErr := x509.MarshalPKIXPublicKey(publicKey)
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	return &entity.SigningKey{
+		AuditEntity: entity.AuditEntity{
+			Id: uuid.New(),
+		},
+		Algorithm:  method.Alg(),
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDer})),
+		PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDer})),
+	}, nil
+}
+
+func parseSigningKey(signingKey entity.SigningKey) (*parsedKey, error) {
+	method := jwt.GetSigningMethod(signingKey.Algorithm)
+	if method == nil {
+		return nil, fmt.Errorf("unsupported signing algorithm %s", signingKey.Algorithm)
+	}
+	privateBlock, _ := pem.Decode([]byte(signingKey.PrivateKey))
+	if privateBlock == nil {
+		return nil, errors.New("invalid private key")
+	}
+	privateKey, parseErr := x509.ParsePKCS8PrivateKey(privateBlock.Bytes)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	publicBlock, _ := pem.Decode([]byte(signingKey.PublicKey))
+	if publicBlock == nil {
+		return nil, errors.New("invalid public key")
+	}
+	publicKey, parseErr := x509.ParsePKIXPublicKey(publicBlock.Bytes)
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	return &parsedKey{
+		entity:     signingKey,
+		method:     method,
+		privateKey: privateKey,
+		publicKey:  publicKey,
+	}, nil
+}
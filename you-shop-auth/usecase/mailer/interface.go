@@ -0,0 +1,12 @@
+package mailer
+
+type Mail struct {
+	To      []string
+	Subject string
+	// Plain text body
+	Body string
+}
+
+type Mailer interface {
+	Send(mail Mail) error
+}
@@ -0,0 +1,64 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuthUserRegistered = "auth.user.registered"
+	AuthUserDeleted    = "auth.user.deleted"
+	AuthLoginSucceeded = "auth.login.succeeded"
+	AuthLoginFailed    = "auth.login.failed"
+	AuthTokenRevoked   = "auth.token.revoked"
+	AuthRoleChanged    = "auth.role.changed"
+	AuthApiKeyCreated  = "auth.apikey.created"
+	AuthApiKeyRevoked  = "auth.apikey.revoked"
+	// The actor of impersonation events is the admin acting as the user
+	AuthImpersonationStarted = "auth.impersonation.started"
+	AuthImpersonationEnded   = "auth.impersonation.ended"
+)
+
+// AuthEvent is published on the you_shop exchange with its type as routing key
+type AuthEvent struct {
+	Type string `json:"type"`
+	// Nil when the event isn't tied to a known user
+	UserId uuid.UUID `json:"userId"`
+	// Admin who made the change, nil when the user acted on their own account
+	ActorId    uuid.UUID `json:"actorId"`
+	Ip         string    `json:"ip"`
+	Detail     any       `json:"detail,omitempty"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
+
+type LoginSucceededDetail struct {
+	Device string `json:"device"`
+	// password or two_factor
+	Method string `json:"method"`
+}
+
+type TokenRevokedDetail struct {
+	// access, refresh, session or all
+	TokenType string `json:"tokenType"`
+	// Nil when every session of the user was revoked
+	SessionId uuid.UUID `json:"sessionId"`
+	Reason    string    `json:"reason"`
+}
+
+type RoleChangedDetail struct {
+	Role string `json:"role"`
+	// assigned or removed
+	Action string `json:"action"`
+}
+
+type ApiKeyDetail struct {
+	ApiKeyId uuid.UUID `json:"apiKeyId"`
+	Name     string    `json:"name,omitempty"`
+	Scope    string    `json:"scope,omitempty"`
+}
+
+type ImpersonationDetail struct {
+	ImpersonationId uuid.UUID `json:"impersonationId"`
+	Reason          string    `json:"reason,omitempty"`
+}
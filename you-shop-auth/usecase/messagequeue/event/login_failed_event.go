@@ -0,0 +1,19 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoginFailedEvent is the detail of auth.login.failed events
+type LoginFailedEvent struct {
+	// Nil when no account matched the given email or username
+	UserId      uuid.UUID `json:"userId"`
+	Email       string    `json:"email"`
+	Username    string    `json:"username"`
+	Ip          string    `json:"ip"`
+	Reason      string    `json:"reason"`
+	FailedCount int       `json:"failedCount"`
+	LockedUntil time.Time `json:"lockedUntil"`
+}
@@ -0,0 +1,18 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const UserDeleted = "user.deleted"
+
+// UserDeletedEvent is published on the you_shop exchange once an account is anonymized, services holding
+// personal data of the user replace the user id with the pseudonym and drop what identifies the person
+type UserDeletedEvent struct {
+	UserId uuid.UUID `json:"userId"`
+	// Random id standing in for the user, the same one is used by every service
+	Pseudonym uuid.UUID `json:"pseudonym"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
@@ -0,0 +1,23 @@
+package notifier
+
+type Channel string
+
+const (
+	EmailChannel Channel = "email"
+	SmsChannel   Channel = "sms"
+)
+
+type Notification struct {
+	Channel Channel
+	// Email address or phone number depending on the channel
+	To      string
+	Subject string
+	// Plain text message, the subject is dropped by channels without one
+	Body string
+}
+
+type Notifier interface {
+	Notify(notification Notification) error
+	// Supports tells whether the notifier can deliver through the channel
+	Supports(channel Channel) bool
+}
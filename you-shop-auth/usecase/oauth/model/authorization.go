@@ -0,0 +1,22 @@
+package model
+
+type AuthorizationRequest struct {
+	ResponseType string
+	ClientId     string
+	RedirectUri  string
+	// Space separated list of requested scopes
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+type AuthorizationResult struct {
+	ClientName        string
+	ClientDescription string
+	WebsiteUrl        string
+	Scopes            []string
+	ConsentRequired   bool
+	// Set once the user has consented, contains either the code or the error
+	RedirectUri string
+}
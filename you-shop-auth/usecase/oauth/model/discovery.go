@@ -0,0 +1,20 @@
+package model
+
+// Discovery is the OpenID Provider Metadata, see OpenID Connect Discovery 1.0 section 3
+type Discovery struct {
+	Issuer                                    string
+	AuthorizationEndpoint                     string
+	TokenEndpoint                             string
+	UserInfoEndpoint                          string
+	JwksUri                                   string
+	IntrospectionEndpoint                     string
+	ScopesSupported                           []string
+	ResponseTypesSupported                    []string
+	GrantTypesSupported                       []string
+	SubjectTypesSupported                     []string
+	IdTokenSigningAlgValuesSupported          []string
+	TokenEndpointAuthMethodsSupported         []string
+	IntrospectionEndpointAuthMethodsSupported []string
+	CodeChallengeMethodsSupported             []string
+	ClaimsSupported                           []string
+}
@@ -0,0 +1,30 @@
+package model
+
+const (
+	AuthorizationCodeGrant = "authorization_code"
+	RefreshTokenGrant      = "refresh_token"
+	ClientCredentialsGrant = "client_credentials"
+)
+
+type TokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectUri  string
+	ClientId     string
+	ClientSecret string
+	CodeVerifier string
+	RefreshToken string
+	// Space separated list of requested scopes
+	Scope  string
+	Device string
+	Ip     string
+}
+
+type TokenResult struct {
+	AccessToken  string
+	TokenType    string
+	ExpiresIn    int
+	RefreshToken string
+	// Space separated list of granted scopes
+	Scope string
+}
@@ -0,0 +1,10 @@
+package model
+
+import "github.com/TechwizsonORG/auth-service/entity"
+
+type UserInfo struct {
+	User entity.User
+	// Comma separated, as carried by the access token
+	Role  string
+	Scope string
+}
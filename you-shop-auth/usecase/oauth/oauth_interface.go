@@ -0,0 +1,24 @@
+package oauth
+
+import (
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/oauth/model"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/google/uuid"
+)
+
+type OAuthInterface interface {
+	// Authorize validates the authorization request of the logged in user. The authorization code is issued
+	// right away when the user already consented to every requested scope, otherwise ConsentRequired is set.
+	Authorize(userId uuid.UUID, request model.AuthorizationRequest) (*model.AuthorizationResult, *err.AppError)
+	// Consent records the decision of the user and issues the authorization code when approved
+	Consent(userId uuid.UUID, request model.AuthorizationRequest, approved bool) (*model.AuthorizationResult, *err.AppError)
+	// Token implements the token endpoint of the supported grants
+	Token(request model.TokenRequest) (*model.TokenResult, *err.AppError)
+	// GetDiscovery describes the provider, the endpoints are built from the issuer which must be the public url of the auth api
+	GetDiscovery() model.Discovery
+	// UserInfo returns the owner of the access token, tokens of the client credentials grant have none
+	UserInfo(accessToken string) (*model.UserInfo, *err.AppError)
+	// Introspect implements RFC 7662, only confidential clients may call it
+	Introspect(clientId string, clientSecret string, tokenString string, tokenTypeHint string) (*token.TokenIntrospection, *err.AppError)
+}
@@ -0,0 +1,389 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	configModel "github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/oauth/model"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const (
+	authorizationCodeExpireTime = 10 * time.Minute
+	codeChallengeMethodS256     = "S256"
+)
+
+type Service struct {
+	logger                zerolog.Logger
+	jwtConfig             configModel.JwtConfig
+	clientRepo            usecase.ClientRepository
+	authorizationCodeRepo usecase.AuthorizationCodeRepository
+	consentRepo           usecase.ConsentRepository
+	scopeRepo             usecase.ScopeRepository
+	tokenService          token.TokenInterface
+}
+
+func NewOAuthService(logger zerolog.Logger, jwtConfig configModel.JwtConfig, clientRepo usecase.ClientRepository, authorizationCodeRepo usecase.AuthorizationCodeRepository, consentRepo usecase.ConsentRepository, scopeRepo usecase.ScopeRepository, tokenService token.TokenInterface) *Service {
+	logger = logger.
+		With().
+		Str("Service", "OAuth").
+		Logger()
+	return &Service{
+		logger:                logger,
+		jwtConfig:             jwtConfig,
+		clientRepo:            clientRepo,
+		authorizationCodeRepo: authorizationCodeRepo,
+		consentRepo:           consentRepo,
+		scopeRepo:             scopeRepo,
+		tokenService:          tokenService,
+	}
+}
+
+func (s *Service) Authorize(userId uuid.UUID, request model.AuthorizationRequest) (*model.AuthorizationResult, *err.AppError) {
+	client, redirectUri, appErr := s.validateAuthorizationRequest(request)
+	if appErr != nil {
+		return nil, appErr
+	}
+	scopes := s.getGrantedScopes(userId, request.Scope)
+	result := &model.AuthorizationResult{
+		ClientName:        client.ClientName,
+		ClientDescription: client.Description,
+		WebsiteUrl:        client.WebsiteUrl,
+		Scopes:            scopes,
+	}
+
+	consent, getErr := s.consentRepo.GetConsent(userId, client.Id)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if consent == nil || !util.ContainsAll(scopes, consent.GetScopes()) {
+		result.ConsentRequired = true
+		return result, nil
+	}
+
+	result.RedirectUri, appErr = s.issueAuthorizationCode(client, userId, redirectUri, scopes, request)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return result, nil
+}
+
+func (s *Service) Consent(userId uuid.UUID, request model.AuthorizationRequest, approved bool) (*model.AuthorizationResult, *err.AppError) {
+	client, redirectUri, appErr := s.validateAuthorizationRequest(request)
+	if appErr != nil {
+		return nil, appErr
+	}
+	scopes := s.getGrantedScopes(userId, request.Scope)
+	result := &model.AuthorizationResult{
+		ClientName:        client.ClientName,
+		ClientDescription: client.Description,
+		WebsiteUrl:        client.WebsiteUrl,
+		Scopes:            scopes,
+	}
+
+	if !approved {
+		result.RedirectUri = buildRedirectUri(redirectUri, map[string]string{
+			"error": "access_denied",
+			"state": request.State,
+		})
+		return result, nil
+	}
+
+	if _, saveErr := s.consentRepo.SaveConsent(*entity.NewConsent(userId, client.Id, scopes)); saveErr != nil {
+		s.logger.Error().Err(saveErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	result.RedirectUri, appErr = s.issueAuthorizationCode(client, userId, redirectUri, scopes, request)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return result, nil
+}
+
+func (s *Service) Token(request model.TokenRequest) (*model.TokenResult, *err.AppError) {
+	client, appErr := s.authenticateClient(request.ClientId, request.ClientSecret)
+	if appErr != nil {
+		return nil, appErr
+	}
+	// No grant may turn an impersonation into tokens which outlive it or escape its audit trail
+	if s.tokenService.IsImpersonationToken(request.Code) || s.tokenService.IsImpersonationToken(request.RefreshToken) {
+		return nil, err.NewOAuthError(http.StatusBadRequest, "invalid_grant", "Impersonation tokens can't be exchanged")
+	}
+
+	switch request.GrantType {
+	case model.AuthorizationCodeGrant:
+		return s.exchangeAuthorizationCode(client, request)
+	case model.RefreshTokenGrant:
+		accessToken, refreshToken, exchangeErr := s.tokenService.ExchangeRefreshToken(request.RefreshToken, client.ClientId, request.Device, request.Ip)
+		if exchangeErr != nil {
+			return nil, err.NewOAuthError(http.StatusBadRequest, "invalid_grant", exchangeErr.Message)
+		}
+		return s.newTokenResult(accessToken, refreshToken, nil), nil
+	case model.ClientCredentialsGrant:
+		return s.issueClientToken(client, request)
+	default:
+		return nil, err.NewOAuthError(http.StatusBadRequest, "unsupported_grant_type", "Grant type is not supported")
+	}
+}
+
+func (s *Service) exchangeAuthorizationCode(client *entity.Client, request model.TokenRequest) (*model.TokenResult, *err.AppError) {
+	invalidGrant := err.NewOAuthError(http.StatusBadRequest, "invalid_grant", "Authorization code is invalid")
+
+	authorizationCode, getErr := s.authorizationCodeRepo.GetAuthorizationCode(util.HashToken(request.Code))
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if authorizationCode == nil || authorizationCode.ClientId != client.Id || authorizationCode.RedirectUri != request.RedirectUri {
+		return nil, invalidGrant
+	}
+	if authorizationCode.IsExpired(util.GetCurrentUtcTime(7)) {
+		return nil, invalidGrant
+	}
+	if !verifyCodeChallenge(authorizationCode.CodeChallenge, request.CodeVerifier) {
+		return nil, err.NewOAuthError(http.StatusBadRequest, "invalid_grant", "Code verifier is invalid")
+	}
+
+	ok, useErr := s.authorizationCodeRepo.UseAuthorizationCode(authorizationCode.Id)
+	if useErr != nil {
+		s.logger.Error().Err(useErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if !ok {
+		s.logger.Warn().Msgf("Authorization code %s was reused by client %s", authorizationCode.Id, client.ClientId)
+		return nil, invalidGrant
+	}
+
+	scopes := strings.Fields(authorizationCode.Scopes)
+	accessToken, refreshToken, appErr := s.tokenService.GenerateDelegatedTokens(authorizationCode.UserId, client.ClientId, scopes, request.Device, request.Ip)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return s.newTokenResult(accessToken, refreshToken, scopes), nil
+}
+
+// issueClientToken authenticates a service by itself, only confidential clients may use this grant
+func (s *Service) issueClientToken(client *entity.Client, request model.TokenRequest) (*model.TokenResult, *err.AppError) {
+	if !client.IsConfidential() {
+		return nil, err.NewOAuthError(http.StatusBadRequest, "unauthorized_client", "Client credentials grant requires a confidential client")
+	}
+	scopes := client.GetScopes()
+	if requested := strings.Fields(request.Scope); len(requested) > 0 {
+		if !util.ContainsAll(requested, scopes) {
+			return nil, err.NewOAuthError(http.StatusBadRequest, "invalid_scope", "Requested scope is not allowed for the client")
+		}
+		scopes = requested
+	}
+
+	accessToken, appErr := s.tokenService.GenerateClientToken(*client, scopes)
+	if appErr != nil {
+		return nil, appErr
+	}
+	result := s.newTokenResult(accessToken, "", scopes)
+	result.ExpiresIn = s.jwtConfig.ClientAccessExpireTime * 60
+	return result, nil
+}
+
+func (s *Service) newTokenResult(accessToken string, refreshToken string, scopes []string) *model.TokenResult {
+	return &model.TokenResult{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    s.jwtConfig.DefaultAccessExpireTime * 60,
+		RefreshToken: refreshToken,
+		Scope:        strings.Join(scopes, " "),
+	}
+}
+
+func (s *Service) GetDiscovery() model.Discovery {
+	issuer := strings.TrimSuffix(s.jwtConfig.Issuer, "/")
+	scopes := []string{}
+	if scopeEntities, getErr := s.scopeRepo.GetScopes(); getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+	} else {
+		for _, scope := range scopeEntities {
+			scopes = append(scopes, scope.Name)
+		}
+	}
+	signingAlgorithm := s.jwtConfig.SigningAlgorithm
+	if signingAlgorithm == "" {
+		signingAlgorithm = "HS256"
+	}
+	return model.Discovery{
+		Issuer:                            s.jwtConfig.Issuer,
+		AuthorizationEndpoint:             issuer + "/authorize",
+		TokenEndpoint:                     issuer + "/token",
+		UserInfoEndpoint:                  issuer + "/userinfo",
+		JwksUri:                           issuer + "/.well-known/jwks.json",
+		IntrospectionEndpoint:             issuer + "/introspect",
+		ScopesSupported:                   scopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{model.AuthorizationCodeGrant, model.RefreshTokenGrant, model.ClientCredentialsGrant},
+		SubjectTypesSupported:             []string{"public"},
+		IdTokenSigningAlgValuesSupported:  []string{signingAlgorithm},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		IntrospectionEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post"},
+		CodeChallengeMethodsSupported:             []string{codeChallengeMethodS256},
+		ClaimsSupported:                           []string{"sub", "iss", "email", "email_verified", "preferred_username", "picture", "phone_number", "role", "scope"},
+	}
+}
+
+func (s *Service) UserInfo(accessToken string) (*model.UserInfo, *err.AppError) {
+	introspection := s.tokenService.IntrospectToken(accessToken, token.AccessToken)
+	if !introspection.Active || introspection.TokenType != token.AccessToken || introspection.User == nil {
+		return nil, err.NewOAuthError(http.StatusUnauthorized, "invalid_token", "Access token is invalid")
+	}
+	return &model.UserInfo{
+		User:  *introspection.User,
+		Role:  introspection.Role,
+		Scope: introspection.Scope,
+	}, nil
+}
+
+func (s *Service) Introspect(clientId string, clientSecret string, tokenString string, tokenTypeHint string) (*token.TokenIntrospection, *err.AppError) {
+	client, appErr := s.authenticateClient(clientId, clientSecret)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !client.IsConfidential() {
+		return nil, err.NewOAuthError(http.StatusUnauthorized, "invalid_client", "Only confidential clients may introspect tokens")
+	}
+	hint := token.AccessToken
+	if tokenTypeHint == "refresh_token" {
+		hint = token.RefreshToken
+	}
+	introspection := s.tokenService.IntrospectToken(tokenString, hint)
+	return &introspection, nil
+}
+
+func (s *Service) authenticateClient(clientId string, clientSecret string) (*entity.Client, *err.AppError) {
+	invalidClient := err.NewOAuthError(http.StatusUnauthorized, "invalid_client", "Client authentication failed")
+	client, getErr := s.clientRepo.GetClientByClientId(clientId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if client == nil || !client.Enabled {
+		return nil, invalidClient
+	}
+	if client.IsConfidential() && !util.VerifyPassword(clientSecret, client.ClientSecret) {
+		return nil, invalidClient
+	}
+	return client, nil
+}
+
+// validateAuthorizationRequest returns the client and the redirect uri the user will be sent back to
+func (s *Service) validateAuthorizationRequest(request model.AuthorizationRequest) (*entity.Client, string, *err.AppError) {
+	if request.ResponseType != "code" {
+		return nil, "", err.NewOAuthError(http.StatusBadRequest, "unsupported_response_type", "Only code response type is supported")
+	}
+
+	client, getErr := s.clientRepo.GetClientByClientId(request.ClientId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, "", err.NewUnhandledError()
+	}
+	if client == nil || !client.Enabled {
+		return nil, "", err.NewOAuthError(http.StatusBadRequest, "unauthorized_client", "Client was not found")
+	}
+
+	redirectUri := request.RedirectUri
+	if redirectUri == "" {
+		defaultRedirectUri, ok := client.GetDefaultRedirectUri()
+		if !ok {
+			return nil, "", err.NewOAuthError(http.StatusBadRequest, "invalid_request", "Redirect uri is required")
+		}
+		redirectUri = defaultRedirectUri
+	} else if !client.IsRedirectUriAllowed(redirectUri) {
+		return nil, "", err.NewOAuthError(http.StatusBadRequest, "invalid_request", "Redirect uri is not registered")
+	}
+
+	if request.CodeChallenge == "" {
+		return nil, "", err.NewOAuthError(http.StatusBadRequest, "invalid_request", "Code challenge is required")
+	}
+	if request.CodeChallengeMethod != codeChallengeMethodS256 {
+		return nil, "", err.NewOAuthError(http.StatusBadRequest, "invalid_request", "Only S256 code challenge method is supported")
+	}
+	return client, redirectUri, nil
+}
+
+// getGrantedScopes keeps the requested scopes the user actually has, all of them when nothing was requested
+func (s *Service) getGrantedScopes(userId uuid.UUID, requestedScope string) []string {
+	userScopes := []string{}
+	for _, scope := range s.scopeRepo.GetPermissionsByUserId(userId) {
+		userScopes = append(userScopes, scope.Name)
+	}
+	requested := strings.Fields(requestedScope)
+	if len(requested) == 0 {
+		return userScopes
+	}
+	granted := []string{}
+	for _, scope := range requested {
+		if util.ContainsAny([]string{scope}, userScopes) {
+			granted = append(granted, scope)
+		}
+	}
+	return granted
+}
+
+func (s *Service) issueAuthorizationCode(client *entity.Client, userId uuid.UUID, redirectUri string, scopes []string, request model.AuthorizationRequest) (string, *err.AppError) {
+	code, generateErr := util.GenerateRandomToken(32)
+	if generateErr != nil {
+		s.logger.Error().Err(generateErr).Msg("")
+		return "", err.NewUnhandledError()
+	}
+	authorizationCode := entity.NewAuthorizationCode(
+		util.HashToken(code),
+		client.Id,
+		userId,
+		request.RedirectUri,
+		strings.Join(scopes, " "),
+		request.CodeChallenge,
+		request.CodeChallengeMethod,
+		util.GetCurrentUtcTime(7).Add(authorizationCodeExpireTime),
+	)
+	if _, addErr := s.authorizationCodeRepo.AddAuthorizationCode(*authorizationCode); addErr != nil {
+		s.logger.Error().Err(addErr).Msg("")
+		return "", err.NewUnhandledError()
+	}
+	return buildRedirectUri(redirectUri, map[string]string{
+		"code":  code,
+		"state": request.State,
+	}), nil
+}
+
+func verifyCodeChallenge(codeChallenge string, codeVerifier string) bool {
+	if codeVerifier == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(codeVerifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+func buildRedirectUri(redirectUri string, params map[string]string) string {
+	parsed, parseErr := url.Parse(redirectUri)
+	if parseErr != nil {
+		return redirectUri
+	}
+	query := parsed.Query()
+	for key, value := range params {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -0,0 +1,10 @@
+package outbox
+
+import "github.com/TechwizsonORG/auth-service/err"
+
+// OutboxInterface publishes the events saved together with the changes they announce
+type OutboxInterface interface {
+	// PublishPending publishes the pending messages in the order they were saved. It stops at the first failure,
+	// the failed message and the ones after it are tried again on the next run
+	PublishPending() *err.AppError
+}
@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"encoding/json"
+
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/rs/zerolog"
+)
+
+// Messages published per run
+const outboxBatchSize = 100
+
+type Service struct {
+	logger     zerolog.Logger
+	outboxRepo usecase.OutboxRepository
+	msgQueue   messagequeue.MessageQueue
+}
+
+func NewOutboxService(logger zerolog.Logger, outboxRepo usecase.OutboxRepository, msgQueue messagequeue.MessageQueue) *Service {
+	logger = logger.
+		With().
+		Str("Service", "Outbox").
+		Logger()
+	return &Service{
+		logger:     logger,
+		outboxRepo: outboxRepo,
+		msgQueue:   msgQueue,
+	}
+}
+
+func (s *Service) PublishPending() *err.AppError {
+	outboxMessages, getErr := s.outboxRepo.GetPendingOutboxMessages(outboxBatchSize)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	for _, outboxMessage := range outboxMessages {
+		publishErr := s.msgQueue.Publish(
+			*messagequeue.NewDefaultExchangeConfig("you_shop", messagequeue.Topic),
+			*messagequeue.NewDefaultQueueConfig("", outboxMessage.RoutingKey),
+			json.RawMessage(outboxMessage.Payload),
+		)
+		if publishErr != nil {
+			s.logger.Error().Err(publishErr).Msgf("Failed to publish outbox message %s", outboxMessage.Id)
+			return err.NewUnhandledError()
+		}
+		// A crash before this point publishes the message again, the consumers handle it idempotently
+		if markErr := s.outboxRepo.MarkOutboxMessagePublished(outboxMessage.Id, util.GetCurrentUtcTime(7)); markErr != nil {
+			s.logger.Error().Err(markErr).Msg("")
+			return err.NewUnhandledError()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package model
+
+import "github.com/TechwizsonORG/auth-service/entity"
+
+type RoleDetail struct {
+	Role entity.Role
+	// Empty when the role doesn't inherit another one
+	ParentName string
+	// Scopes mapped to the role itself, the inherited ones aren't included
+	Scopes []entity.Scope
+}
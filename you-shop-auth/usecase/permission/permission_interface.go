@@ -0,0 +1,28 @@
+package permission
+
+import (
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/permission/model"
+)
+
+// PermissionInterface administers the roles, their inheritance and the scopes they grant.
+// Changes apply from the next token issued to the users
+type PermissionInterface interface {
+	GetRoles() ([]model.RoleDetail, *err.AppError)
+	GetRole(name string) (*model.RoleDetail, *err.AppError)
+	// AddRole creates a role inheriting parentName, pass an empty parentName for a role without parent
+	AddRole(name string, description string, parentName string) (*entity.Role, *err.AppError)
+	// UpdateRole rejects a parent which would make the role inherit itself
+	UpdateRole(name string, description string, parentName string) (*entity.Role, *err.AppError)
+	// DeleteRole is rejected while another role inherits it
+	DeleteRole(name string) *err.AppError
+	AddRoleScope(roleName string, scopeName string) *err.AppError
+	RemoveRoleScope(roleName string, scopeName string) *err.AppError
+	GetScopes() ([]entity.Scope, *err.AppError)
+	AddScope(name string, description string) (*entity.Scope, *err.AppError)
+	UpdateScope(name string, description string) (*entity.Scope, *err.AppError)
+	DeleteScope(name string) *err.AppError
+	// SeedDefaultScopes creates the scopes other services check on first start, e.g. product:write for admins
+	SeedDefaultScopes() *err.AppError
+}
@@ -0,0 +1,331 @@
+package permission
+
+import (
+	"regexp"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/permission/model"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Names end up joined by commas in the tokens and separated by spaces in OAuth requests
+var namePattern = regexp.MustCompile(`^[a-zA-Z0-9:._-]+$`)
+
+// defaultScope is a permission the other services check, it's granted to roleName when it's first created
+type defaultScope struct {
+	name        string
+	description string
+	roleName    string
+}
+
+var defaultScopes = []defaultScope{
+	{name: "product:write", description: "Manage products, their variants and inventory", roleName: "admin"},
+}
+
+type Service struct {
+	logger    zerolog.Logger
+	roleRepo  usecase.RoleRepository
+	scopeRepo usecase.ScopeRepository
+}
+
+func NewPermissionService(logger zerolog.Logger, roleRepo usecase.RoleRepository, scopeRepo usecase.ScopeRepository) *Service {
+	logger = logger.
+		With().
+		Str("Service", "Permission").
+		Logger()
+	return &Service{
+		logger:    logger,
+		roleRepo:  roleRepo,
+		scopeRepo: scopeRepo,
+	}
+}
+
+func (s *Service) GetRoles() ([]model.RoleDetail, *err.AppError) {
+	roles, getErr := s.roleRepo.GetRoles()
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	roleNames := map[uuid.UUID]string{}
+	for _, role := range roles {
+		roleNames[role.Id] = role.Name
+	}
+	result := []model.RoleDetail{}
+	for _, role := range roles {
+		result = append(result, model.RoleDetail{
+			Role:       role,
+			ParentName: roleNames[role.ParentId],
+			Scopes:     s.scopeRepo.GetScopesByRoleId(role.Id),
+		})
+	}
+	return result, nil
+}
+
+func (s *Service) GetRole(name string) (*model.RoleDetail, *err.AppError) {
+	roles, appErr := s.GetRoles()
+	if appErr != nil {
+		return nil, appErr
+	}
+	for _, role := range roles {
+		if role.Role.Name == name {
+			return &role, nil
+		}
+	}
+	return nil, err.NewNotFoundError("Role not found")
+}
+
+func (s *Service) AddRole(name string, description string, parentName string) (*entity.Role, *err.AppError) {
+	if !namePattern.MatchString(name) {
+		return nil, err.NewPermissionError("Role name may only contain letters, digits and :._-")
+	}
+	existing, getErr := s.roleRepo.GetRoleByName(name)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if existing != nil {
+		return nil, err.NewPermissionError("Role already exists")
+	}
+	parentId, appErr := s.getParentId(parentName)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	role, addErr := s.roleRepo.AddRole(*entity.NewRole(name, description, parentId))
+	if addErr != nil {
+		s.logger.Error().Err(addErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return &role, nil
+}
+
+func (s *Service) UpdateRole(name string, description string, parentName string) (*entity.Role, *err.AppError) {
+	roles, getErr := s.roleRepo.GetRoles()
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	var role *entity.Role
+	parents := map[uuid.UUID]uuid.UUID{}
+	for i := range roles {
+		parents[roles[i].Id] = roles[i].ParentId
+		if roles[i].Name == name {
+			role = &roles[i]
+		}
+	}
+	if role == nil {
+		return nil, err.NewNotFoundError("Role not found")
+	}
+	parentId, appErr := s.getParentId(parentName)
+	if appErr != nil {
+		return nil, appErr
+	}
+	// Walk up from the new parent, meeting the role means it would inherit itself
+	for ancestorId := parentId; ancestorId != uuid.Nil; ancestorId = parents[ancestorId] {
+		if ancestorId == role.Id {
+			return nil, err.NewPermissionError("Role can't inherit itself")
+		}
+	}
+
+	role.Description = description
+	role.ParentId = parentId
+	updatedRole, updateErr := s.roleRepo.UpdateRole(*role)
+	if updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return &updatedRole, nil
+}
+
+func (s *Service) DeleteRole(name string) *err.AppError {
+	roles, getErr := s.roleRepo.GetRoles()
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	var role *entity.Role
+	for i := range roles {
+		if roles[i].Name == name {
+			role = &roles[i]
+		}
+	}
+	if role == nil {
+		return err.NewNotFoundError("Role not found")
+	}
+	for _, child := range roles {
+		if child.ParentId == role.Id {
+			return err.NewPermissionError("Role is inherited by " + child.Name)
+		}
+	}
+	if deleteErr := s.roleRepo.DeleteRole(role.Id); deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	s.logger.Info().Msgf("Role %s deleted", name)
+	return nil
+}
+
+func (s *Service) AddRoleScope(roleName string, scopeName string) *err.AppError {
+	role, scope, appErr := s.getRoleAndScope(roleName, scopeName)
+	if appErr != nil {
+		return appErr
+	}
+	if addErr := s.roleRepo.AddRoleScope(role.Id, scope.Id); addErr != nil {
+		s.logger.Error().Err(addErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	return nil
+}
+
+func (s *Service) RemoveRoleScope(roleName string, scopeName string) *err.AppError {
+	role, scope, appErr := s.getRoleAndScope(roleName, scopeName)
+	if appErr != nil {
+		return appErr
+	}
+	removed, removeErr := s.roleRepo.RemoveRoleScope(role.Id, scope.Id)
+	if removeErr != nil {
+		s.logger.Error().Err(removeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !removed {
+		return err.NewNotFoundError("Role doesn't have scope " + scopeName)
+	}
+	return nil
+}
+
+func (s *Service) GetScopes() ([]entity.Scope, *err.AppError) {
+	scopes, getErr := s.scopeRepo.GetScopes()
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return scopes, nil
+}
+
+func (s *Service) AddScope(name string, description string) (*entity.Scope, *err.AppError) {
+	if !namePattern.MatchString(name) {
+		return nil, err.NewPermissionError("Scope name may only contain letters, digits and :._-")
+	}
+	existing, getErr := s.scopeRepo.GetScopeByName(name)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if existing != nil {
+		return nil, err.NewPermissionError("Scope already exists")
+	}
+	scope, addErr := s.scopeRepo.AddScope(*entity.NewScope(name, description))
+	if addErr != nil {
+		s.logger.Error().Err(addErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return &scope, nil
+}
+
+func (s *Service) UpdateScope(name string, description string) (*entity.Scope, *err.AppError) {
+	scope, appErr := s.getScope(name)
+	if appErr != nil {
+		return nil, appErr
+	}
+	scope.Description = description
+	updatedScope, updateErr := s.scopeRepo.UpdateScope(*scope)
+	if updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return &updatedScope, nil
+}
+
+func (s *Service) DeleteScope(name string) *err.AppError {
+	scope, appErr := s.getScope(name)
+	if appErr != nil {
+		return appErr
+	}
+	if deleteErr := s.scopeRepo.DeleteScope(scope.Id); deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	s.logger.Info().Msgf("Scope %s deleted", name)
+	return nil
+}
+
+// SeedDefaultScopes creates the missing default scopes and grants them to their role. A scope which already exists
+// is left alone, so a grant removed by an admin isn't given back
+func (s *Service) SeedDefaultScopes() *err.AppError {
+	for _, defaultScope := range defaultScopes {
+		existing, getErr := s.scopeRepo.GetScopeByName(defaultScope.name)
+		if getErr != nil {
+			s.logger.Error().Err(getErr).Msg("")
+			return err.NewUnhandledError()
+		}
+		if existing != nil {
+			continue
+		}
+		scope, addErr := s.scopeRepo.AddScope(*entity.NewScope(defaultScope.name, defaultScope.description))
+		if addErr != nil {
+			s.logger.Error().Err(addErr).Msg("")
+			return err.NewUnhandledError()
+		}
+		role, getErr := s.roleRepo.GetRoleByName(defaultScope.roleName)
+		if getErr != nil {
+			s.logger.Error().Err(getErr).Msg("")
+			return err.NewUnhandledError()
+		}
+		if role == nil {
+			s.logger.Warn().Msgf("Role %s doesn't exist, scope %s wasn't granted to it", defaultScope.roleName, scope.Name)
+			continue
+		}
+		if addErr := s.roleRepo.AddRoleScope(role.Id, scope.Id); addErr != nil {
+			s.logger.Error().Err(addErr).Msg("")
+			return err.NewUnhandledError()
+		}
+		s.logger.Info().Msgf("Scope %s created and granted to role %s", scope.Name, role.Name)
+	}
+	return nil
+}
+
+func (s *Service) getParentId(parentName string) (uuid.UUID, *err.AppError) {
+	if parentName == "" {
+		return uuid.Nil, nil
+	}
+	parent, getErr := s.roleRepo.GetRoleByName(parentName)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return uuid.Nil, err.NewUnhandledError()
+	}
+	if parent == nil {
+		return uuid.Nil, err.NewNotFoundError("Parent role not found")
+	}
+	return parent.Id, nil
+}
+
+func (s *Service) getScope(name string) (*entity.Scope, *err.AppError) {
+	scope, getErr := s.scopeRepo.GetScopeByName(name)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if scope == nil {
+		return nil, err.NewNotFoundError("Scope not found")
+	}
+	return scope, nil
+}
+
+func (s *Service) getRoleAndScope(roleName string, scopeName string) (*entity.Role, *entity.Scope, *err.AppError) {
+	role, getErr := s.roleRepo.GetRoleByName(roleName)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, nil, err.NewUnhandledError()
+	}
+	if role == nil {
+		return nil, nil, err.NewNotFoundError("Role not found")
+	}
+	scope, appErr := s.getScope(scopeName)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+	return role, scope, nil
+}
@@ -0,0 +1,23 @@
+package profile
+
+import (
+	"io"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/google/uuid"
+)
+
+// ProfileInterface lets the users manage their own account
+type ProfileInterface interface {
+	GetProfile(userId uuid.UUID) (*entity.User, *err.AppError)
+	UpdateProfile(userId uuid.UUID, username string, phoneNumber string) (*entity.User, *err.AppError)
+	// ChangePassword ends all of the user's sessions once the password is changed
+	ChangePassword(userId uuid.UUID, currentPassword string, newPassword string, ip string) *err.AppError
+	// ChangeEmail marks the new email as unverified and mails a verification link to it
+	ChangeEmail(userId uuid.UUID, currentPassword string, email string, ip string) *err.AppError
+	// UpdateAvatar uploads the image to the image service and returns its url
+	UpdateAvatar(userId uuid.UUID, fileName string, content io.Reader) (string, *err.AppError)
+	// DeleteAccount anonymizes the user, ends all of their sessions and asks the other services to erase their data
+	DeleteAccount(userId uuid.UUID, currentPassword string, ip string) *err.AppError
+}
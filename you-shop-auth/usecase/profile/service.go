@@ -0,0 +1,225 @@
+package profile
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/audit"
+	"github.com/TechwizsonORG/auth-service/usecase/auth"
+	"github.com/TechwizsonORG/auth-service/usecase/image"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue/event"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// maxAvatarSize is the largest avatar file accepted, in bytes
+const maxAvatarSize = 5 << 20
+
+var avatarContentTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+type Service struct {
+	logger        zerolog.Logger
+	userRepo      usecase.UserRepository
+	tokenService  token.TokenInterface
+	authService   auth.AuthInterface
+	imageUploader image.ImageUploader
+	auditService  audit.AuditInterface
+}
+
+func NewProfileService(logger zerolog.Logger, userRepo usecase.UserRepository, tokenService token.TokenInterface, authService auth.AuthInterface, imageUploader image.ImageUploader, auditService audit.AuditInterface) *Service {
+	logger = logger.
+		With().
+		Str("Service", "Profile").
+		Logger()
+	return &Service{
+		logger:        logger,
+		userRepo:      userRepo,
+		tokenService:  tokenService,
+		authService:   authService,
+		imageUploader: imageUploader,
+		auditService:  auditService,
+	}
+}
+
+func (s *Service) GetProfile(userId uuid.UUID) (*entity.User, *err.AppError) {
+	return s.getUser(userId)
+}
+
+func (s *Service) UpdateProfile(userId uuid.UUID, username string, phoneNumber string) (*entity.User, *err.AppError) {
+	if username == "" {
+		return nil, err.NewProfileError("Username is required")
+	}
+	user, appErr := s.getUser(userId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if username != user.Username {
+		existingUser, getErr := s.userRepo.GetUserByEmailOrUsername("", username)
+		if getErr != nil {
+			s.logger.Error().Err(getErr).Msg("")
+			return nil, err.NewUnhandledError()
+		}
+		if existingUser != nil {
+			return nil, err.NewProfileError("Username was already used")
+		}
+		user.ChangeUsername(username)
+	}
+	user.PhoneNumber = phoneNumber
+	updatedUser, updateErr := s.userRepo.UpdateUser(*user)
+	if updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return &updatedUser, nil
+}
+
+func (s *Service) ChangePassword(userId uuid.UUID, currentPassword string, newPassword string, ip string) *err.AppError {
+	if newPassword == "" {
+		return err.NewProfileError("New password is required")
+	}
+	if _, appErr := s.checkPassword(userId, currentPassword, ip); appErr != nil {
+		return appErr
+	}
+	passwordHash, hashErr := util.HashPassword(newPassword)
+	if hashErr != nil {
+		s.logger.Error().Err(hashErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if updateErr := s.userRepo.UpdatePassword(userId, passwordHash); updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	s.logger.Info().Msgf("User %s changed their password", userId)
+	return s.tokenService.RevokeUserTokens(userId, "password_changed")
+}
+
+func (s *Service) ChangeEmail(userId uuid.UUID, currentPassword string, email string, ip string) *err.AppError {
+	if email == "" {
+		return err.NewProfileError("Email is required")
+	}
+	user, appErr := s.checkPassword(userId, currentPassword, ip)
+	if appErr != nil {
+		return appErr
+	}
+	if email == user.Email {
+		return err.NewProfileError("New email is the same as the current one")
+	}
+	existingUser, getErr := s.userRepo.GetUserByEmailOrUsername(email, "")
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if existingUser != nil {
+		return err.NewProfileError("Email was already used")
+	}
+	user.ChangeEmail(email)
+	if updateErr := s.userRepo.UpdateEmail(userId, user.Email, user.NormalizedEmail); updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	// The user can ask for another mail, the email is changed already
+	if e := s.authService.ResendVerificationEmail(user.Email); e != nil {
+		s.logger.Warn().Msgf("Couldn't send verification email to user %s", userId)
+	}
+	return nil
+}
+
+func (s *Service) UpdateAvatar(userId uuid.UUID, fileName string, content io.Reader) (string, *err.AppError) {
+	user, appErr := s.getUser(userId)
+	if appErr != nil {
+		return "", appErr
+	}
+	image, readErr := io.ReadAll(io.LimitReader(content, maxAvatarSize+1))
+	if readErr != nil {
+		s.logger.Error().Err(readErr).Msg("")
+		return "", err.NewUnhandledError()
+	}
+	if len(image) == 0 {
+		return "", err.NewProfileError("Avatar file is empty")
+	}
+	if len(image) > maxAvatarSize {
+		return "", err.NewProfileError(fmt.Sprintf("Avatar file must not be larger than %d MiB", maxAvatarSize>>20))
+	}
+	if !slices.Contains(avatarContentTypes, http.DetectContentType(image)) {
+		return "", err.NewProfileError("Avatar must be a jpeg, png, gif or webp image")
+	}
+	avatarUrl, uploadErr := s.imageUploader.Upload(userId, fileName, user.Username, bytes.NewReader(image))
+	if uploadErr != nil {
+		s.logger.Error().Err(uploadErr).Msg("")
+		return "", err.NewUnhandledError()
+	}
+	user.AvatarUrl = avatarUrl
+	if _, updateErr := s.userRepo.UpdateUser(*user); updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("")
+		return "", err.NewUnhandledError()
+	}
+	return avatarUrl, nil
+}
+
+func (s *Service) DeleteAccount(userId uuid.UUID, currentPassword string, ip string) *err.AppError {
+	user, appErr := s.checkPassword(userId, currentPassword, ip)
+	if appErr != nil {
+		return appErr
+	}
+	loginAttemptKeys := []string{
+		entity.UserLoginAttemptKey(userId),
+		entity.EmailPasswordResetKey(user.Email),
+	}
+	user.Anonymize(util.GetCurrentUtcTime(7))
+	// The other services erase their data once the outbox job publishes the event
+	outboxMessage, marshalErr := entity.NewOutboxMessage(event.UserDeleted, event.UserDeletedEvent{
+		UserId:    userId,
+		Pseudonym: uuid.New(),
+		DeletedAt: user.DeletedAt,
+	})
+	if marshalErr != nil {
+		s.logger.Error().Err(marshalErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if deleteErr := s.userRepo.DeleteUser(*user, loginAttemptKeys, *outboxMessage); deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	s.logger.Info().Msgf("User %s deleted their account", userId)
+
+	s.auditService.Record(event.AuthEvent{
+		Type:   event.AuthUserDeleted,
+		UserId: userId,
+	})
+	return s.tokenService.RevokeUserTokens(userId, "account_deleted")
+}
+
+func (s *Service) getUser(userId uuid.UUID) (*entity.User, *err.AppError) {
+	user, getErr := s.userRepo.GetUserByID(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if user == nil {
+		return nil, err.NewNotFoundError("User not found")
+	}
+	return user, nil
+}
+
+func (s *Service) checkPassword(userId uuid.UUID, password string, ip string) (*entity.User, *err.AppError) {
+	user, appErr := s.getUser(userId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	ok, appErr := s.authService.VerifyPassword(user, password, ip)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if !ok {
+		return nil, err.NewProfileError("Current password is wrong")
+	}
+	return user, nil
+}
@@ -0,0 +1,55 @@
+package session
+
+import (
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type Service struct {
+	logger       zerolog.Logger
+	sessionRepo  usecase.SessionRepository
+	tokenService token.TokenInterface
+}
+
+func NewSessionService(logger zerolog.Logger, sessionRepo usecase.SessionRepository, tokenService token.TokenInterface) *Service {
+	logger = logger.
+		With().
+		Str("Service", "Session").
+		Logger()
+	return &Service{
+		logger:       logger,
+		sessionRepo:  sessionRepo,
+		tokenService: tokenService,
+	}
+}
+
+func (s *Service) GetSessions(userId uuid.UUID) ([]entity.Session, *err.AppError) {
+	sessions, getErr := s.sessionRepo.GetActiveSessionsByUserId(userId, util.GetCurrentUtcTime(7))
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return sessions, nil
+}
+
+func (s *Service) RevokeSession(userId uuid.UUID, sessionId uuid.UUID) *err.AppError {
+	session, getErr := s.sessionRepo.GetSessionById(sessionId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	// Sessions of other users are reported as missing
+	if session == nil || session.UserId != userId || !session.IsActive(util.GetCurrentUtcTime(7)) {
+		return err.NewNotFoundError("Session not found")
+	}
+	return s.tokenService.RevokeSession(userId, sessionId, "user_request")
+}
+
+func (s *Service) RevokeSessions(userId uuid.UUID) *err.AppError {
+	return s.tokenService.RevokeUserTokens(userId, "user_request")
+}
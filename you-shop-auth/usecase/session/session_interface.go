@@ -0,0 +1,16 @@
+package session
+
+import (
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/google/uuid"
+)
+
+// SessionInterface lets the users see where they are logged in and log out of their devices
+type SessionInterface interface {
+	// GetSessions returns the sessions which are neither revoked nor expired
+	GetSessions(userId uuid.UUID) ([]entity.Session, *err.AppError)
+	RevokeSession(userId uuid.UUID, sessionId uuid.UUID) *err.AppError
+	// RevokeSessions ends every session of the user, the current one included
+	RevokeSessions(userId uuid.UUID) *err.AppError
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/TechwizsonORG/auth-service/entity"
 	"github.com/TechwizsonORG/auth-service/err"
 	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/audit"
+	"github.com/TechwizsonORG/auth-service/usecase/key"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue/event"
 	"github.com/TechwizsonORG/auth-service/util"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -15,29 +18,58 @@ import (
 )
 
 type Service struct {
-	jwtConfig model.JwtConfig
-	logger    zerolog.Logger
-	userRepo  usecase.UserRepository
-	roleRepo  usecase.RoleRepository
-	scopeRepo usecase.ScopeRepository
+	jwtConfig         model.JwtConfig
+	logger            zerolog.Logger
+	userRepo          usecase.UserRepository
+	roleRepo          usecase.RoleRepository
+	scopeRepo         usecase.ScopeRepository
+	refreshTokenRepo  usecase.RefreshTokenRepository
+	sessionRepo       usecase.SessionRepository
+	apiKeyRepo        usecase.ApiKeyRepository
+	impersonationRepo usecase.ImpersonationRepository
+	accessTokenRepo   usecase.AccessTokenRepository
+	clientRepo        usecase.ClientRepository
+	keyService        key.KeyInterface
+	auditService      audit.AuditInterface
 }
 
-func NewTokenService(logger zerolog.Logger, userRepo usecase.UserRepository, jwtConfig model.JwtConfig, roleRepo usecase.RoleRepository, scopeRepo usecase.ScopeRepository) *Service {
+const (
+	// Role carried by tokens issued with the client credentials grant
+	serviceRole              = "service"
+	challengeTokenExpireTime = 5 * time.Minute
+	// Authorization scheme of personal api keys, e.g. "ApiKey ysk_..."
+	apiKeyScheme = "ApiKey "
+	// Last used time of an api key is only saved when it is older than this, so busy keys don't write on every request
+	apiKeyTouchInterval = time.Minute
+	// Role which may impersonate users and can't be impersonated itself
+	adminRole                    = "admin"
+	impersonationTokenExpireTime = 15 * time.Minute
+)
+
+func NewTokenService(logger zerolog.Logger, userRepo usecase.UserRepository, jwtConfig model.JwtConfig, roleRepo usecase.RoleRepository, scopeRepo usecase.ScopeRepository, refreshTokenRepo usecase.RefreshTokenRepository, sessionRepo usecase.SessionRepository, apiKeyRepo usecase.ApiKeyRepository, impersonationRepo usecase.ImpersonationRepository, accessTokenRepo usecase.AccessTokenRepository, clientRepo usecase.ClientRepository, keyService key.KeyInterface, auditService audit.AuditInterface) *Service {
 	logger = logger.
 		With().
 		Str("service", "token").
 		Logger()
 	return &Service{
-		logger:    logger,
-		userRepo:  userRepo,
-		jwtConfig: jwtConfig,
-		roleRepo:  roleRepo,
-		scopeRepo: scopeRepo,
+		logger:            logger,
+		userRepo:          userRepo,
+		jwtConfig:         jwtConfig,
+		roleRepo:          roleRepo,
+		scopeRepo:         scopeRepo,
+		refreshTokenRepo:  refreshTokenRepo,
+		sessionRepo:       sessionRepo,
+		apiKeyRepo:        apiKeyRepo,
+		impersonationRepo: impersonationRepo,
+		accessTokenRepo:   accessTokenRepo,
+		clientRepo:        clientRepo,
+		keyService:        keyService,
+		auditService:      auditService,
 	}
 }
 
 func (s *Service) getRoles(userId uuid.UUID, roles chan string) {
-	roleEntities := s.roleRepo.GetRolesByUserId(userId)
+	roleEntities := s.roleRepo.GetEffectiveRolesByUserId(userId)
 	roleStr := strings.Builder{}
 	for i, role := range roleEntities {
 		if i > 0 {
@@ -49,7 +81,7 @@ func (s *Service) getRoles(userId uuid.UUID, roles chan string) {
 }
 func (s *Service) getScopes(userId uuid.UUID, scopes chan string) {
 
-	scopeEntities := s.scopeRepo.GetScopesByUserId(userId)
+	scopeEntities := s.scopeRepo.GetPermissionsByUserId(userId)
 	scopeStr := strings.Builder{}
 	for i, scope := range scopeEntities {
 		if i > 0 {
@@ -60,40 +92,66 @@ func (s *Service) getScopes(userId uuid.UUID, scopes chan string) {
 	scopes <- scopeStr.String()
 }
 
+func (s *Service) getExpiration(tokenType TokenType) time.Duration {
+	if tokenType == AccessToken {
+		return time.Minute * time.Duration(s.jwtConfig.DefaultAccessExpireTime)
+	}
+	return time.Hour * time.Duration(s.jwtConfig.DefaultRefreshExpireTime)
+}
+
 func (s *Service) GenerateToken(userId uuid.UUID, tokenType TokenType) (string, *err.AppError) {
+	if tokenType == RefreshToken {
+		_, refreshToken, appErr := s.GenerateSessionTokens(userId, "", "")
+		return refreshToken, appErr
+	}
+	return s.signToken(userId, tokenType, util.GetCurrentUtcTime(7), nil)
+}
+
+func (s *Service) signToken(userId uuid.UUID, tokenType TokenType, current time.Time, extraClaims jwt.MapClaims) (string, *err.AppError) {
 	user, error := s.userRepo.GetUserByID(userId)
 	if error != nil {
 		s.logger.Err(error).Msgf("Failed to get user by id %s", userId)
 		return "", err.NewTokenGenerationError("Failed to get user by id", nil)
 	}
+	if user == nil {
+		return "", err.NewTokenGenerationError("User not found", nil)
+	}
 	roles := make(chan string)
 	scopes := make(chan string)
 	go s.getRoles(user.Id, roles)
 	go s.getScopes(user.Id, scopes)
 
-	var expired time.Duration
-	if tokenType == AccessToken {
-		expired = time.Minute * time.Duration(s.jwtConfig.DefaultAccessExpireTime)
-	} else {
-		expired = time.Hour * time.Duration(s.jwtConfig.DefaultRefreshExpireTime)
-	}
-
-	current := util.GetCurrentUtcTime(7)
-
-	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	claims := jwt.MapClaims{
 		"sub":        user.Id.String(),
 		"email":      user.Email,
 		"avatar_url": user.AvatarUrl,
 		"iss":        s.jwtConfig.Issuer,
 		"iat":        current.Unix(),
 		"nbf":        current.Unix(),
-		"exp":        current.Add(expired).Unix(),
+		"exp":        current.Add(s.getExpiration(tokenType)).Unix(),
 		"userId":     user.Id.String(),
 		"role":       <-roles,
 		"scope":      <-scopes,
-	})
+		"token_type": tokenType.String(),
+		"jti":        uuid.New().String(),
+	}
+	for key, value := range extraClaims {
+		claims[key] = value
+	}
+
+	return s.sign(claims)
+}
 
-	token, error := claims.SignedString([]byte(s.jwtConfig.SecretKey))
+func (s *Service) sign(claims jwt.MapClaims) (string, *err.AppError) {
+	signer, appErr := s.keyService.GetSigner()
+	if appErr != nil {
+		return "", appErr
+	}
+	jwtToken := jwt.NewWithClaims(signer.Method, claims)
+	if signer.Kid != "" {
+		jwtToken.Header["kid"] = signer.Kid
+	}
+	token, error := jwtToken.SignedString(signer.Key)
 	if error != nil {
 		s.logger.Err(error).Msg("Failed to sign token")
 		return "", err.NewTokenGenerationError("Failed to sign token", nil)
@@ -101,58 +159,679 @@ func (s *Service) GenerateToken(userId uuid.UUID, tokenType TokenType) (string,
 	return token, nil
 }
 
-func (s *Service) ValidateTokenWithResponse(token string, tokenType TokenType) (user *entity.User, role, scope string, appErr *err.AppError) {
+func (s *Service) GenerateClientToken(client entity.Client, scopes []string) (string, *err.AppError) {
+	current := util.GetCurrentUtcTime(7)
+	claims := jwt.MapClaims{
+		"sub":        client.Id.String(),
+		"client_id":  client.ClientId,
+		"iss":        s.jwtConfig.Issuer,
+		"iat":        current.Unix(),
+		"nbf":        current.Unix(),
+		"exp":        current.Add(time.Minute * time.Duration(s.jwtConfig.ClientAccessExpireTime)).Unix(),
+		"role":       serviceRole,
+		"scope":      strings.Join(scopes, ","),
+		"token_type": AccessToken.String(),
+		"jti":        uuid.New().String(),
+	}
+	return s.sign(claims)
+}
+
+func (s *Service) GenerateChallengeToken(userId uuid.UUID, challengeType TokenType) (string, *err.AppError) {
+	current := util.GetCurrentUtcTime(7)
+	claims := jwt.MapClaims{
+		"sub":        userId.String(),
+		"iss":        s.jwtConfig.Issuer,
+		"iat":        current.Unix(),
+		"nbf":        current.Unix(),
+		"exp":        current.Add(challengeTokenExpireTime).Unix(),
+		"token_type": challengeType.String(),
+		"jti":        uuid.New().String(),
+	}
+	return s.sign(claims)
+}
+
+func (s *Service) ValidateChallengeToken(challengeToken string, challengeType TokenType) (uuid.UUID, *err.AppError) {
+	userId, tokenId, _, appErr := s.parseChallengeToken(challengeToken, challengeType)
+	if appErr != nil {
+		return uuid.Nil, appErr
+	}
+	// Used challenges are kept with the revoked access tokens until they expire
+	isUsed, checkErr := s.accessTokenRepo.IsAccessTokenRevoked(tokenId)
+	if checkErr != nil {
+		s.logger.Error().Err(checkErr).Msg("")
+		return uuid.Nil, err.NewUnhandledError()
+	}
+	if isUsed {
+		return uuid.Nil, err.NewTokenValidationError("Challenge token was already used", nil)
+	}
+	return userId, nil
+}
+
+func (s *Service) UseChallengeToken(challengeToken string, challengeType TokenType) *err.AppError {
+	userId, tokenId, expiresAt, appErr := s.parseChallengeToken(challengeToken, challengeType)
+	if appErr != nil {
+		return appErr
+	}
+	usedChallenge := entity.AccessToken{
+		AuditEntity: entity.AuditEntity{Id: tokenId},
+		UserId:      userId,
+		ExpiresAt:   expiresAt,
+	}
+	ok, revokeErr := s.accessTokenRepo.RevokeAccessToken(usedChallenge)
+	if revokeErr != nil {
+		s.logger.Error().Err(revokeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !ok {
+		return err.NewTokenValidationError("Challenge token was already used", nil)
+	}
+	return nil
+}
+
+func (s *Service) parseChallengeToken(challengeToken string, challengeType TokenType) (userId uuid.UUID, tokenId uuid.UUID, expiresAt time.Time, appErr *err.AppError) {
+	claims, appErr := s.parseToken(challengeToken, challengeType)
+	if appErr != nil {
+		return uuid.Nil, uuid.Nil, time.Time{}, appErr
+	}
+	subject, _ := claims.GetSubject()
+	userId, parseUuidErr := uuid.Parse(subject)
+	if parseUuidErr != nil {
+		return uuid.Nil, uuid.Nil, time.Time{}, err.NewTokenValidationError("Couldn't parse user id", nil)
+	}
+	tokenId, appErr = s.getTokenId(claims)
+	if appErr != nil {
+		return uuid.Nil, uuid.Nil, time.Time{}, appErr
+	}
+	expiration, _ := claims.GetExpirationTime()
+	return userId, tokenId, expiration.Time, nil
+}
+
+func (s *Service) GenerateImpersonationToken(adminId uuid.UUID, userId uuid.UUID, reason string, ip string) (string, *entity.Impersonation, *err.AppError) {
+	if strings.TrimSpace(reason) == "" {
+		return "", nil, err.NewImpersonationError("Reason is required")
+	}
+	if adminId == userId {
+		return "", nil, err.NewImpersonationError("Admins can't impersonate themselves")
+	}
+	user, getErr := s.userRepo.GetUserByID(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return "", nil, err.NewUnhandledError()
+	}
+	if user == nil {
+		return "", nil, err.NewNotFoundError("User not found")
+	}
+	for _, role := range s.roleRepo.GetEffectiveRolesByUserId(userId) {
+		if role.Name == adminRole {
+			return "", nil, err.NewImpersonationError("Admins can't be impersonated")
+		}
+	}
+
+	current := util.GetCurrentUtcTime(7)
+	impersonation := entity.NewImpersonation(adminId, userId, reason, ip, current.Add(impersonationTokenExpireTime))
+	if addErr := s.impersonationRepo.AddImpersonation(*impersonation); addErr != nil {
+		s.logger.Error().Err(addErr).Msg("Failed to save impersonation")
+		return "", nil, err.NewTokenGenerationError("Failed to save impersonation", nil)
+	}
+	accessToken, appErr := s.signToken(userId, AccessToken, current, jwt.MapClaims{
+		"act": jwt.MapClaims{"sub": adminId.String()},
+		"jti": impersonation.Id.String(),
+		"exp": impersonation.ExpiresAt.Unix(),
+	})
+	if appErr != nil {
+		return "", nil, appErr
+	}
+
+	s.auditService.Record(event.AuthEvent{
+		Type:    event.AuthImpersonationStarted,
+		UserId:  userId,
+		ActorId: adminId,
+		Ip:      ip,
+		Detail: event.ImpersonationDetail{
+			ImpersonationId: impersonation.Id,
+			Reason:          reason,
+		},
+	})
+	s.logger.Info().Msgf("Admin %s started impersonating user %s", adminId, userId)
+	return accessToken, impersonation, nil
+}
+
+func (s *Service) IsImpersonationToken(token string) bool {
+	claims := jwt.MapClaims{}
+	if _, _, parseErr := jwt.NewParser().ParseUnverified(token, claims); parseErr != nil {
+		return false
+	}
+	_, ok := claims["act"]
+	return ok
+}
+
+func (s *Service) EndImpersonation(adminId uuid.UUID, impersonationId uuid.UUID) *err.AppError {
+	impersonation, getErr := s.impersonationRepo.GetImpersonationById(impersonationId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if impersonation == nil {
+		return err.NewNotFoundError("Impersonation not found")
+	}
+	ended, endErr := s.impersonationRepo.EndImpersonation(impersonationId, util.GetCurrentUtcTime(7))
+	if endErr != nil {
+		s.logger.Error().Err(endErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !ended {
+		return err.NewImpersonationError("Impersonation was already ended")
+	}
+	s.auditService.Record(event.AuthEvent{
+		Type:    event.AuthImpersonationEnded,
+		UserId:  impersonation.UserId,
+		ActorId: adminId,
+		Detail: event.ImpersonationDetail{
+			ImpersonationId: impersonation.Id,
+		},
+	})
+	return nil
+}
+
+func (s *Service) GenerateSessionTokens(userId uuid.UUID, device string, ip string) (accessToken string, refreshToken string, appErr *err.AppError) {
+	return s.startSession(userId, "", "", device, ip)
+}
+
+func (s *Service) GenerateDelegatedTokens(userId uuid.UUID, clientId string, scopes []string, device string, ip string) (accessToken string, refreshToken string, appErr *err.AppError) {
+	return s.startSession(userId, clientId, strings.Join(scopes, ","), device, ip)
+}
+
+// startSession issues the first tokens of a new refresh token family and saves the session behind it
+func (s *Service) startSession(userId uuid.UUID, clientId string, scope string, device string, ip string) (accessToken string, refreshToken string, appErr *err.AppError) {
+	current := util.GetCurrentUtcTime(7)
+	refreshTokenEntity := entity.NewRefreshToken(userId, uuid.Nil, device, current.Add(s.getExpiration(RefreshToken)))
+	refreshTokenEntity.ClientId = clientId
+	refreshTokenEntity.Scope = scope
+
+	accessToken, appErr = s.signToken(userId, AccessToken, current, sessionClaims(*refreshTokenEntity))
+	if appErr != nil {
+		return "", "", appErr
+	}
+	if _, addErr := s.sessionRepo.AddSession(*entity.NewSession(*refreshTokenEntity, ip)); addErr != nil {
+		s.logger.Err(addErr).Msg("Failed to save session")
+		return "", "", err.NewTokenGenerationError("Failed to save session", nil)
+	}
+	refreshToken, appErr = s.issueRefreshToken(*refreshTokenEntity, current)
+	if appErr != nil {
+		return "", "", appErr
+	}
+	return accessToken, refreshToken, nil
+}
+
+// delegatedClaims limits a token issued to an OAuth client to the granted scope, the user's roles are
+// never delegated so the client can't pass role based checks on their behalf
+func delegatedClaims(clientId string, scope string) jwt.MapClaims {
+	if clientId == "" {
+		return jwt.MapClaims{}
+	}
+	return jwt.MapClaims{
+		"client_id": clientId,
+		"role":      "",
+		"scope":     scope,
+	}
+}
+
+// sessionClaims ties the tokens of a refresh token family to their session
+func sessionClaims(refreshToken entity.RefreshToken) jwt.MapClaims {
+	claims := delegatedClaims(refreshToken.ClientId, refreshToken.Scope)
+	claims["sid"] = refreshToken.FamilyId.String()
+	return claims
+}
+
+// issueRefreshToken signs the refresh token with its id as jti, then persists it
+func (s *Service) issueRefreshToken(refreshToken entity.RefreshToken, current time.Time) (string, *err.AppError) {
+	claims := sessionClaims(refreshToken)
+	claims["jti"] = refreshToken.Id.String()
+	claims["exp"] = refreshToken.ExpiresAt.Unix()
+	token, appErr := s.signToken(refreshToken.UserId, RefreshToken, current, claims)
+	if appErr != nil {
+		return "", appErr
+	}
+	if _, addErr := s.refreshTokenRepo.AddRefreshToken(refreshToken); addErr != nil {
+		s.logger.Err(addErr).Msg("Failed to save refresh token")
+		return "", err.NewTokenGenerationError("Failed to save refresh token", nil)
+	}
+	return token, nil
+}
+
+func (s *Service) ExchangeRefreshToken(refreshToken string, clientId string, device string, ip string) (accessToken string, newRefreshToken string, appErr *err.AppError) {
+	claims, appErr := s.parseToken(refreshToken, RefreshToken)
+	if appErr != nil {
+		return "", "", appErr
+	}
+	if _, ok := claims["act"]; ok {
+		return "", "", err.NewTokenValidationError("Impersonation tokens can't be exchanged", nil)
+	}
+
+	tokenId, appErr := s.getTokenId(claims)
+	if appErr != nil {
+		return "", "", appErr
+	}
+
+	stored, getErr := s.refreshTokenRepo.GetRefreshTokenById(tokenId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return "", "", err.NewUnhandledError()
+	}
+	if stored == nil {
+		return "", "", err.NewTokenValidationError("Refresh token was not found", nil)
+	}
+	if sub, _ := claims.GetSubject(); sub != stored.UserId.String() {
+		return "", "", err.NewTokenValidationError("Refresh token was not found", nil)
+	}
+	if stored.ClientId != clientId {
+		return "", "", err.NewTokenValidationError("Refresh token was not issued to the client", nil)
+	}
+
+	current := util.GetCurrentUtcTime(7)
+	if stored.IsExpired(current) {
+		return "", "", err.NewTokenValidationError("Token was expired", nil)
+	}
+	hasSession, appErr := s.checkSession(claims)
+	if appErr != nil {
+		return "", "", appErr
+	}
+
+	if device == "" {
+		device = stored.Device
+	}
+	rotated := entity.NewRefreshToken(stored.UserId, stored.FamilyId, device, current.Add(s.getExpiration(RefreshToken)))
+	rotated.ClientId = stored.ClientId
+	rotated.Scope = stored.Scope
+	ok, rotateErr := s.refreshTokenRepo.RotateRefreshToken(stored.Id, rotated.Id)
+	if rotateErr != nil {
+		s.logger.Error().Err(rotateErr).Msg("")
+		return "", "", err.NewUnhandledError()
+	}
+	if !ok {
+		s.logger.Warn().Msgf("Refresh token %s was reused, revoking session %s", stored.Id, stored.FamilyId)
+		if appErr := s.RevokeSession(stored.UserId, stored.FamilyId, "refresh_token_reused"); appErr != nil {
+			s.logger.Error().Msg("Failed to revoke session")
+		}
+		return "", "", err.NewTokenValidationError("Refresh token was already used", nil)
+	}
+	if hasSession {
+		if touchErr := s.sessionRepo.TouchSession(stored.FamilyId, device, ip, rotated.ExpiresAt); touchErr != nil {
+			s.logger.Error().Err(touchErr).Msg("Failed to update session")
+		}
+	} else if _, addErr := s.sessionRepo.AddSession(*entity.NewSession(*rotated, ip)); addErr != nil {
+		// The family started before sessions were tracked, its rotated tokens need a session to pass validation
+		s.logger.Err(addErr).Msg("Failed to save session")
+		return "", "", err.NewTokenGenerationError("Failed to save session", nil)
+	}
+
+	accessToken, appErr = s.signToken(stored.UserId, AccessToken, current, sessionClaims(*rotated))
+	if appErr != nil {
+		return "", "", appErr
+	}
+	newRefreshToken, appErr = s.issueRefreshToken(*rotated, current)
+	if appErr != nil {
+		return "", "", appErr
+	}
+	return accessToken, newRefreshToken, nil
+}
+
+func (s *Service) getTokenId(claims jwt.MapClaims) (uuid.UUID, *err.AppError) {
+	jti, ok := claims["jti"].(string)
+	if !ok {
+		return uuid.Nil, err.NewTokenValidationError("Couldn't get token id", nil)
+	}
+	tokenId, parseUuidErr := uuid.Parse(jti)
+	if parseUuidErr != nil {
+		s.logger.Error().Err(parseUuidErr).Msg("")
+		return uuid.Nil, err.NewTokenValidationError("Couldn't parse token id", nil)
+	}
+	return tokenId, nil
+}
+
+func (s *Service) RevokeToken(token string, tokenType TokenType) *err.AppError {
+	claims, appErr := s.parseToken(token, tokenType)
+	if appErr != nil {
+		return appErr
+	}
+	tokenId, appErr := s.getTokenId(claims)
+	if appErr != nil {
+		return appErr
+	}
+
+	if tokenType == RefreshToken {
+		stored, getErr := s.refreshTokenRepo.GetRefreshTokenById(tokenId)
+		if getErr != nil {
+			s.logger.Error().Err(getErr).Msg("")
+			return err.NewUnhandledError()
+		}
+		if stored == nil {
+			return err.NewTokenValidationError("Refresh token was not found", nil)
+		}
+		return s.RevokeSession(stored.UserId, stored.FamilyId, "user_request")
+	}
+
+	subject, _ := claims.GetSubject()
+	userId, _ := uuid.Parse(subject)
+	expire, _ := claims.GetExpirationTime()
+	accessToken := entity.AccessToken{
+		AuditEntity: entity.AuditEntity{
+			Id: tokenId,
+		},
+		UserId:    userId,
+		ExpiresAt: expire.Time,
+		RevokedAt: util.GetCurrentUtcTime(7),
+	}
+	if _, revokeErr := s.accessTokenRepo.RevokeAccessToken(accessToken); revokeErr != nil {
+		s.logger.Error().Err(revokeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	sid, _ := claims["sid"].(string)
+	sessionId, _ := uuid.Parse(sid)
+	s.recordTokenRevoked(userId, AccessToken.String(), sessionId, "user_request")
+	return nil
+}
+
+func (s *Service) RevokeUserTokens(userId uuid.UUID, reason string) *err.AppError {
+	if revokeErr := s.sessionRepo.RevokeUserSessions(userId); revokeErr != nil {
+		s.logger.Error().Err(revokeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if revokeErr := s.refreshTokenRepo.RevokeUserRefreshTokens(userId); revokeErr != nil {
+		s.logger.Error().Err(revokeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if revokeErr := s.apiKeyRepo.RevokeUserApiKeys(userId); revokeErr != nil {
+		s.logger.Error().Err(revokeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	s.recordTokenRevoked(userId, "all", uuid.Nil, reason)
+	return nil
+}
+
+func (s *Service) RevokeSession(userId uuid.UUID, sessionId uuid.UUID, reason string) *err.AppError {
+	if _, revokeErr := s.sessionRepo.RevokeSession(sessionId); revokeErr != nil {
+		s.logger.Error().Err(revokeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if revokeErr := s.refreshTokenRepo.RevokeRefreshTokenFamily(sessionId); revokeErr != nil {
+		s.logger.Error().Err(revokeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	s.recordTokenRevoked(userId, "session", sessionId, reason)
+	return nil
+}
+
+func (s *Service) recordTokenRevoked(userId uuid.UUID, tokenType string, sessionId uuid.UUID, reason string) {
+	s.auditService.Record(event.AuthEvent{
+		Type:   event.AuthTokenRevoked,
+		UserId: userId,
+		Detail: event.TokenRevokedDetail{
+			TokenType: tokenType,
+			SessionId: sessionId,
+			Reason:    reason,
+		},
+	})
+}
+
+// checkSession rejects the tokens of revoked sessions. Tokens issued before sessions were tracked carry no sid,
+// they are let through and hasSession is false
+func (s *Service) checkSession(claims jwt.MapClaims) (hasSession bool, appErr *err.AppError) {
+	sid, ok := claims["sid"].(string)
+	if !ok {
+		return false, nil
+	}
+	sessionId, parseUuidErr := uuid.Parse(sid)
+	if parseUuidErr != nil {
+		return false, err.NewTokenValidationError("Couldn't parse session id", nil)
+	}
+	session, getErr := s.sessionRepo.GetSessionById(sessionId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return false, err.NewUnhandledError()
+	}
+	if session == nil || session.IsRevoked() {
+		return false, err.NewTokenValidationError("Session was revoked", nil)
+	}
+	return true, nil
+}
+
+// checkImpersonation rejects impersonation tokens once the impersonation is ended or its admin is gone,
+// other tokens carry no act claim and pass
+func (s *Service) checkImpersonation(claims jwt.MapClaims) *err.AppError {
+	if _, ok := claims["act"]; !ok {
+		return nil
+	}
+	adminId := getActorId(claims)
+	if adminId == uuid.Nil {
+		return err.NewTokenValidationError("Couldn't parse actor id", nil)
+	}
+	impersonationId, appErr := s.getTokenId(claims)
+	if appErr != nil {
+		return appErr
+	}
+	impersonation, getErr := s.impersonationRepo.GetImpersonationById(impersonationId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if impersonation == nil || impersonation.AdminId != adminId || !impersonation.IsActive(util.GetCurrentUtcTime(7)) {
+		return err.NewTokenValidationError("Impersonation was ended", nil)
+	}
+	admin, getErr := s.userRepo.GetUserByID(adminId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if admin == nil {
+		return err.NewTokenValidationError("Impersonating admin is no longer active", nil)
+	}
+	for _, role := range s.roleRepo.GetEffectiveRolesByUserId(adminId) {
+		if role.Name == adminRole {
+			return nil
+		}
+	}
+	return err.NewTokenValidationError("Impersonating user is no longer an admin", nil)
+}
+
+// getActorId reads the admin from the act claim of an impersonation token, nil for every other token
+func getActorId(claims jwt.MapClaims) uuid.UUID {
+	act, ok := claims["act"].(map[string]any)
+	if !ok {
+		return uuid.Nil
+	}
+	subject, _ := act["sub"].(string)
+	actorId, parseUuidErr := uuid.Parse(subject)
+	if parseUuidErr != nil {
+		return uuid.Nil
+	}
+	return actorId
+}
+
+func (s *Service) CleanupExpiredTokens() *err.AppError {
+	current := util.GetCurrentUtcTime(7)
+	accessTokens, deleteErr := s.accessTokenRepo.DeleteExpiredAccessTokens(current)
+	if deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("Failed to delete expired revoked access tokens")
+		return err.NewUnhandledError()
+	}
+	refreshTokens, deleteErr := s.refreshTokenRepo.DeleteExpiredRefreshTokens(current)
+	if deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("Failed to delete expired refresh tokens")
+		return err.NewUnhandledError()
+	}
+	sessions, deleteErr := s.sessionRepo.DeleteExpiredSessions(current)
+	if deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("Failed to delete expired sessions")
+		return err.NewUnhandledError()
+	}
+	s.logger.Debug().Msgf("Deleted %d revoked access tokens, %d refresh tokens and %d sessions", accessTokens, refreshTokens, sessions)
+	return nil
+}
+
+// parseToken verifies signature, time based claims, issuer and token type then returns the claims
+func (s *Service) parseToken(token string, tokenType TokenType) (jwt.MapClaims, *err.AppError) {
 	if strings.Contains(token, "Bearer") {
 		token = strings.Split(token, " ")[1]
 	}
-	jwtToken, parseErr := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
-		return []byte(s.jwtConfig.SecretKey), nil
-	})
+	validMethods := []string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+	jwtToken, parseErr := jwt.Parse(token, s.keyService.Keyfunc, jwt.WithValidMethods(validMethods))
 
 	if parseErr != nil {
 		s.logger.Error().Err(parseErr).Msg("Failed to parse token")
-		return nil, "", "", err.NewTokenValidationError("Failed to parse token", nil)
-	}
-
-	if jwtToken.Method != jwt.SigningMethodHS256 {
-		return nil, "", "", err.NewTokenValidationError("Invalid signing method", nil)
+		return nil, err.NewTokenValidationError("Failed to parse token", nil)
 	}
 
 	claims, ok := jwtToken.Claims.(jwt.MapClaims)
 	if !ok {
-		return nil, "", "", err.NewTokenValidationError("Failed when getting claims", nil)
+		return nil, err.NewTokenValidationError("Failed when getting claims", nil)
 	}
 
 	current := util.GetCurrentUtcTime(7)
 
 	expire, expiredError := claims.GetExpirationTime()
 	if expiredError != nil {
-		return nil, "", "", err.NewTokenValidationError("Failed when getting expiration time", nil)
+		return nil, err.NewTokenValidationError("Failed when getting expiration time", nil)
 	}
 	if current.After(expire.Time) {
-		return nil, "", "", err.NewTokenValidationError("Token was expired", nil)
+		return nil, err.NewTokenValidationError("Token was expired", nil)
 	}
 	notBefore, notBeforeError := claims.GetNotBefore()
 
 	if notBeforeError != nil {
-		return nil, "", "", err.NewTokenValidationError("Failed when getting not before time", nil)
+		return nil, err.NewTokenValidationError("Failed when getting not before time", nil)
 	}
 
 	if current.Before(notBefore.Time) {
-		return nil, "", "", err.NewTokenValidationError("Not before time was failed validation", nil)
+		return nil, err.NewTokenValidationError("Not before time was failed validation", nil)
 	}
 
 	issuer, issError := claims.GetIssuer()
 	if issError != nil {
-		return nil, "", "", err.NewTokenValidationError("Failed when getting not before time", nil)
+		return nil, err.NewTokenValidationError("Failed when getting not before time", nil)
 	}
 
 	if issuer != s.jwtConfig.Issuer {
-		return nil, "", "", err.NewTokenValidationError("Issuer was failed validation", nil)
+		return nil, err.NewTokenValidationError("Issuer was failed validation", nil)
+	}
+
+	if claimType, _ := claims["token_type"].(string); claimType != tokenType.String() {
+		return nil, err.NewTokenValidationError("Token type was failed validation", nil)
+	}
+	return claims, nil
+}
+
+func (s *Service) ValidateTokenWithResponse(token string, tokenType TokenType) (user *entity.User, role, scope string, actorId uuid.UUID, appErr *err.AppError) {
+	if apiKey, isApiKey := strings.CutPrefix(token, apiKeyScheme); isApiKey {
+		if tokenType != AccessToken {
+			return nil, "", "", uuid.Nil, err.NewTokenValidationError("Api keys can only be used as access tokens", nil)
+		}
+		user, role, scope, appErr = s.validateApiKey(apiKey)
+		return user, role, scope, uuid.Nil, appErr
+	}
+	claims, appErr := s.parseToken(token, tokenType)
+	if appErr != nil {
+		return nil, "", "", uuid.Nil, appErr
+	}
+	user, role, scope, appErr = s.validateClaims(claims, tokenType)
+	if appErr != nil {
+		return nil, "", "", uuid.Nil, appErr
+	}
+	return user, role, scope, getActorId(claims), nil
+}
+
+// IntrospectToken tries the token as an access token then as a refresh token, the hint only changes the order.
+// Refresh tokens are active until they are rotated, revoked or expired
+func (s *Service) IntrospectToken(token string, tokenTypeHint TokenType) TokenIntrospection {
+	tokenTypes := []TokenType{AccessToken, RefreshToken}
+	if tokenTypeHint == RefreshToken {
+		tokenTypes = []TokenType{RefreshToken, AccessToken}
+	}
+	for _, tokenType := range tokenTypes {
+		claims, appErr := s.parseToken(token, tokenType)
+		if appErr != nil {
+			continue
+		}
+		user, role, scope, appErr := s.validateClaims(claims, tokenType)
+		if appErr != nil {
+			return TokenIntrospection{}
+		}
+		if tokenType == RefreshToken && !s.isRefreshTokenActive(claims) {
+			return TokenIntrospection{}
+		}
+
+		introspection := TokenIntrospection{
+			Active:    true,
+			TokenType: tokenType,
+			Role:      role,
+			Scope:     scope,
+		}
+		introspection.Subject, _ = claims.GetSubject()
+		introspection.Issuer, _ = claims.GetIssuer()
+		introspection.ClientId, _ = claims["client_id"].(string)
+		introspection.TokenId, _ = claims["jti"].(string)
+		introspection.SessionId, _ = claims["sid"].(string)
+		if actorId := getActorId(claims); actorId != uuid.Nil {
+			introspection.ActorId = actorId.String()
+		}
+		if expiresAt, _ := claims.GetExpirationTime(); expiresAt != nil {
+			introspection.ExpiresAt = expiresAt.Time
+		}
+		if issuedAt, _ := claims.GetIssuedAt(); issuedAt != nil {
+			introspection.IssuedAt = issuedAt.Time
+		}
+		if notBefore, _ := claims.GetNotBefore(); notBefore != nil {
+			introspection.NotBefore = notBefore.Time
+		}
+		if _, isUserToken := claims["userId"]; isUserToken {
+			introspection.User = user
+		}
+		return introspection
+	}
+	return TokenIntrospection{}
+}
+
+func (s *Service) isRefreshTokenActive(claims jwt.MapClaims) bool {
+	tokenId, appErr := s.getTokenId(claims)
+	if appErr != nil {
+		return false
+	}
+	stored, getErr := s.refreshTokenRepo.GetRefreshTokenById(tokenId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return false
+	}
+	return stored != nil && !stored.IsRevoked() && !stored.IsExpired(util.GetCurrentUtcTime(7))
+}
+
+// validateClaims makes sure the token wasn't revoked and its owner still exists
+func (s *Service) validateClaims(claims jwt.MapClaims, tokenType TokenType) (user *entity.User, role, scope string, appErr *err.AppError) {
+	if tokenType == AccessToken {
+		tokenId, appErr := s.getTokenId(claims)
+		if appErr != nil {
+			return nil, "", "", appErr
+		}
+		isRevoked, checkErr := s.accessTokenRepo.IsAccessTokenRevoked(tokenId)
+		if checkErr != nil {
+			s.logger.Error().Err(checkErr).Msg("")
+			return nil, "", "", err.NewUnhandledError()
+		}
+		if isRevoked {
+			return nil, "", "", err.NewTokenValidationError("Token was revoked", nil)
+		}
+	}
+	if _, appErr := s.checkSession(claims); appErr != nil {
+		return nil, "", "", appErr
+	}
+	if appErr := s.checkImpersonation(claims); appErr != nil {
+		return nil, "", "", appErr
 	}
 
 	userIdStr, ok := claims["userId"]
 	if !ok {
+		if _, isClientToken := claims["client_id"]; isClientToken && tokenType == AccessToken {
+			return s.validateClientToken(claims)
+		}
 		return nil, "", "", err.NewAppError(401, "Couldn't get user id", "Couldn't get user id", nil)
 	}
 
@@ -167,6 +846,9 @@ func (s *Service) ValidateTokenWithResponse(token string, tokenType TokenType) (
 		s.logger.Error().Err(getUserErr).Msg("")
 		return nil, "", "", err.NewAppError(401, "Couldn't find user", "Couldn't find user", nil)
 	}
+	if user == nil {
+		return nil, "", "", err.NewAppError(401, "Couldn't find user", "Couldn't find user", nil)
+	}
 	roles, ok := claims["role"].(string)
 	if !ok {
 		return nil, "", "", err.NewAppError(401, "Couldn't get user role", "Couldn't get user role", nil)
@@ -177,3 +859,68 @@ func (s *Service) ValidateTokenWithResponse(token string, tokenType TokenType) (
 	}
 	return user, roles, scopes, nil
 }
+
+// validateApiKey authenticates the owner of the key. The key carries no role, only the scopes of the key
+// which the owner still holds
+func (s *Service) validateApiKey(key string) (*entity.User, string, string, *err.AppError) {
+	apiKey, getErr := s.apiKeyRepo.GetApiKeyByHash(util.HashToken(strings.TrimSpace(key)))
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, "", "", err.NewUnhandledError()
+	}
+	current := util.GetCurrentUtcTime(7)
+	if apiKey == nil || apiKey.IsRevoked() || apiKey.IsExpired(current) {
+		return nil, "", "", err.NewTokenValidationError("Api key is invalid or expired", nil)
+	}
+	user, getUserErr := s.userRepo.GetUserByID(apiKey.UserId)
+	if getUserErr != nil {
+		s.logger.Error().Err(getUserErr).Msg("")
+		return nil, "", "", err.NewAppError(401, "Couldn't find user", "Couldn't find user", nil)
+	}
+	if user == nil {
+		return nil, "", "", err.NewAppError(401, "Couldn't find user", "Couldn't find user", nil)
+	}
+
+	scopes := make(chan string)
+	go s.getScopes(user.Id, scopes)
+	permissions := strings.Split(<-scopes, ",")
+	grantedScopes := []string{}
+	for _, scope := range strings.Split(apiKey.Scope, ",") {
+		if util.ContainsAny([]string{scope}, permissions) {
+			grantedScopes = append(grantedScopes, scope)
+		}
+	}
+
+	if current.Sub(apiKey.LastUsedAt) > apiKeyTouchInterval {
+		if touchErr := s.apiKeyRepo.TouchApiKey(apiKey.Id, current); touchErr != nil {
+			s.logger.Error().Err(touchErr).Msg("Failed to update last used time of api key")
+		}
+	}
+	return user, "", strings.Join(grantedScopes, ","), nil
+}
+
+// validateClientToken checks a token issued with the client credentials grant, the client
+// is returned as a user without email so services can treat it like any other caller
+func (s *Service) validateClientToken(claims jwt.MapClaims) (*entity.User, string, string, *err.AppError) {
+	subject, _ := claims.GetSubject()
+	clientId, parseUuidErr := uuid.Parse(subject)
+	if parseUuidErr != nil {
+		return nil, "", "", err.NewAppError(401, "Couldn't parse client id", "Couldn't parse client id", nil)
+	}
+	client, getClientErr := s.clientRepo.GetClientByID(clientId)
+	if getClientErr != nil {
+		s.logger.Error().Err(getClientErr).Msg("")
+		return nil, "", "", err.NewUnhandledError()
+	}
+	if client == nil || !client.Enabled {
+		return nil, "", "", err.NewAppError(401, "Couldn't find client", "Couldn't find client", nil)
+	}
+	scopes, _ := claims["scope"].(string)
+	user := &entity.User{
+		AuditEntity: entity.AuditEntity{
+			Id: client.Id,
+		},
+		Username: client.ClientId,
+	}
+	return user, serviceRole, scopes, nil
+}
@@ -11,9 +11,68 @@ type TokenType int8
 const (
 	AccessToken TokenType = iota
 	RefreshToken
+	// Issued after the password check when the login still needs a second factor
+	TwoFactorChallengeToken
+	// Issued instead of TwoFactorChallengeToken to a user who must enroll two factor authentication before logging in
+	TwoFactorEnrollmentToken
 )
 
+func (t TokenType) String() string {
+	switch t {
+	case AccessToken:
+		return "access"
+	case RefreshToken:
+		return "refresh"
+	case TwoFactorChallengeToken:
+		return "two_factor_challenge"
+	case TwoFactorEnrollmentToken:
+		return "two_factor_enrollment"
+	default:
+		return "unknown"
+	}
+}
+
 type TokenInterface interface {
 	GenerateToken(userId uuid.UUID, tokenType TokenType) (string, *err.AppError)
-	ValidateTokenWithResponse(token string, tokenType TokenType) (user *entity.User, role, scope string, appErr *err.AppError)
+	// GenerateSessionTokens starts a new session of the user and issues its first access/refresh token pair,
+	// the refresh token starts a new token family
+	GenerateSessionTokens(userId uuid.UUID, device string, ip string) (accessToken string, refreshToken string, appErr *err.AppError)
+	// GenerateDelegatedTokens issues an access/refresh token pair to an OAuth client on behalf of the user,
+	// the tokens only carry the granted scopes
+	GenerateDelegatedTokens(userId uuid.UUID, clientId string, scopes []string, device string, ip string) (accessToken string, refreshToken string, appErr *err.AppError)
+	// GenerateClientToken issues a short lived access token to a client authenticated with the client credentials grant,
+	// there is no user nor refresh token behind it
+	GenerateClientToken(client entity.Client, scopes []string) (string, *err.AppError)
+	// GenerateImpersonationToken lets an admin act as a customer with a short lived access token carrying the admin
+	// in its act claim. There is no refresh token, and the impersonation is recorded for the audit trail
+	GenerateImpersonationToken(adminId uuid.UUID, userId uuid.UUID, reason string, ip string) (string, *entity.Impersonation, *err.AppError)
+	// EndImpersonation stops the impersonation token from passing validation before it expires
+	EndImpersonation(adminId uuid.UUID, impersonationId uuid.UUID) *err.AppError
+	// IsImpersonationToken tells whether the token carries an act claim, the signature isn't checked
+	IsImpersonationToken(token string) bool
+	// GenerateChallengeToken issues a short lived token which proves the user passed the password step of the login
+	// challengeType is TwoFactorChallengeToken or TwoFactorEnrollmentToken
+	GenerateChallengeToken(userId uuid.UUID, challengeType TokenType) (string, *err.AppError)
+	// ValidateChallengeToken accepts a challenge of the given type which hasn't been used yet
+	ValidateChallengeToken(challengeToken string, challengeType TokenType) (uuid.UUID, *err.AppError)
+	// UseChallengeToken makes the challenge single use, it fails when the challenge was already used
+	UseChallengeToken(challengeToken string, challengeType TokenType) *err.AppError
+	// ExchangeRefreshToken rotates the given refresh token and returns a new access/refresh token pair.
+	// Presenting a refresh token which was already used revokes its whole family. The token is only accepted
+	// from the client it was issued to, first party tokens have an empty client id.
+	ExchangeRefreshToken(refreshToken string, clientId string, device string, ip string) (accessToken string, newRefreshToken string, appErr *err.AppError)
+	// RevokeToken revokes an access token by its jti or the whole family of a refresh token
+	RevokeToken(token string, tokenType TokenType) *err.AppError
+	// RevokeUserTokens revokes every refresh token and api key of the user, ending all of their sessions
+	// The reason ends up in the audit trail
+	RevokeUserTokens(userId uuid.UUID, reason string) *err.AppError
+	// RevokeSession ends the session, its access and refresh tokens stop passing validation
+	RevokeSession(userId uuid.UUID, sessionId uuid.UUID, reason string) *err.AppError
+	// CleanupExpiredTokens removes revoked and refresh tokens which are already expired
+	CleanupExpiredTokens() *err.AppError
+	// ValidateTokenWithResponse also accepts personal api keys sent as "ApiKey <key>", they carry no role.
+	// actorId is the admin behind an impersonation token, nil for every other token
+	ValidateTokenWithResponse(token string, tokenType TokenType) (user *entity.User, role, scope string, actorId uuid.UUID, appErr *err.AppError)
+	// IntrospectToken never fails, invalid tokens are reported as inactive
+	IntrospectToken(token string, tokenTypeHint TokenType) TokenIntrospection
 }
@@ -0,0 +1,29 @@
+package token
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/auth-service/entity"
+)
+
+// TokenIntrospection describes a token as seen by RFC 7662, every field but Active is empty for inactive tokens
+type TokenIntrospection struct {
+	Active    bool
+	TokenType TokenType
+	Subject   string
+	// Nil for tokens issued with the client credentials grant
+	User *entity.User
+	// Set when the token was issued to an OAuth client
+	ClientId string
+	Role     string
+	Scope    string
+	Issuer   string
+	TokenId  string
+	// Empty for tokens which don't belong to a login session
+	SessionId string
+	// Admin acting as the subject, only set for impersonation tokens
+	ActorId   string
+	ExpiresAt time.Time
+	IssuedAt  time.Time
+	NotBefore time.Time
+}
@@ -0,0 +1,202 @@
+package twofactor
+
+import (
+	"strings"
+
+	configModel "github.com/TechwizsonORG/auth-service/config/model"
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const recoveryCodeCount = 10
+
+type Service struct {
+	logger           zerolog.Logger
+	securityConfig   configModel.SecurityConfig
+	userRepo         usecase.UserRepository
+	roleRepo         usecase.RoleRepository
+	twoFactorRepo    usecase.TwoFactorRepository
+	recoveryCodeRepo usecase.RecoveryCodeRepository
+}
+
+func NewTwoFactorService(logger zerolog.Logger, securityConfig configModel.SecurityConfig, userRepo usecase.UserRepository, roleRepo usecase.RoleRepository, twoFactorRepo usecase.TwoFactorRepository, recoveryCodeRepo usecase.RecoveryCodeRepository) *Service {
+	logger = logger.
+		With().
+		Str("Service", "TwoFactor").
+		Logger()
+	return &Service{
+		logger:           logger,
+		securityConfig:   securityConfig,
+		userRepo:         userRepo,
+		roleRepo:         roleRepo,
+		twoFactorRepo:    twoFactorRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+	}
+}
+
+func (s *Service) GetStatus(userId uuid.UUID) (enabled bool, required bool, appErr *err.AppError) {
+	twoFactor, getErr := s.twoFactorRepo.GetTwoFactorByUserId(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return false, false, err.NewUnhandledError()
+	}
+	enabled = twoFactor != nil && twoFactor.IsEnabled()
+	return enabled, enabled || s.isRequiredByRole(userId), nil
+}
+
+func (s *Service) isRequiredByRole(userId uuid.UUID) bool {
+	if len(s.securityConfig.TwoFactorRequiredRoles) == 0 {
+		return false
+	}
+	roles := []string{}
+	for _, role := range s.roleRepo.GetEffectiveRolesByUserId(userId) {
+		roles = append(roles, role.Name)
+	}
+	return util.ContainsAny(s.securityConfig.TwoFactorRequiredRoles, roles)
+}
+
+func (s *Service) Enroll(userId uuid.UUID) (secret string, otpAuthUri string, appErr *err.AppError) {
+	twoFactor, getErr := s.twoFactorRepo.GetTwoFactorByUserId(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return "", "", err.NewUnhandledError()
+	}
+	if twoFactor != nil && twoFactor.IsEnabled() {
+		return "", "", err.NewTwoFactorError("Two factor authentication is already enabled")
+	}
+	user, getErr := s.userRepo.GetUserByID(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return "", "", err.NewUnhandledError()
+	}
+	if user == nil {
+		return "", "", err.NewNotFoundError("User not found")
+	}
+
+	secret, generateErr := util.GenerateTotpSecret()
+	if generateErr != nil {
+		s.logger.Error().Err(generateErr).Msg("")
+		return "", "", err.NewUnhandledError()
+	}
+	if _, saveErr := s.twoFactorRepo.SaveTwoFactor(*entity.NewTwoFactor(userId, secret)); saveErr != nil {
+		s.logger.Error().Err(saveErr).Msg("")
+		return "", "", err.NewUnhandledError()
+	}
+	return secret, util.BuildOtpAuthUri(s.securityConfig.TwoFactorIssuer, user.Email, secret), nil
+}
+
+func (s *Service) Enable(userId uuid.UUID, code string) ([]string, *err.AppError) {
+	twoFactor, getErr := s.twoFactorRepo.GetTwoFactorByUserId(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if twoFactor == nil {
+		return nil, err.NewTwoFactorError("Two factor authentication has not been enrolled")
+	}
+	if twoFactor.IsEnabled() {
+		return nil, err.NewTwoFactorError("Two factor authentication is already enabled")
+	}
+	step, ok := util.VerifyTotpCode(twoFactor.Secret, code, util.GetCurrentUtcTime(7))
+	if !ok {
+		return nil, err.NewInvalidTwoFactorCodeError()
+	}
+	// The code can't complete a pending login afterwards
+	used, useErr := s.twoFactorRepo.UseTwoFactorStep(userId, step)
+	if useErr != nil {
+		s.logger.Error().Err(useErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if !used {
+		return nil, err.NewInvalidTwoFactorCodeError()
+	}
+	if enableErr := s.twoFactorRepo.EnableTwoFactor(userId, util.GetCurrentUtcTime(7)); enableErr != nil {
+		s.logger.Error().Err(enableErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return s.generateRecoveryCodes(userId)
+}
+
+func (s *Service) Disable(userId uuid.UUID, code string) *err.AppError {
+	if s.isRequiredByRole(userId) {
+		return err.NewTwoFactorError("Two factor authentication is mandatory for your role")
+	}
+	if appErr := s.Verify(userId, code); appErr != nil {
+		return appErr
+	}
+	if deleteErr := s.twoFactorRepo.DeleteTwoFactor(userId); deleteErr != nil {
+		s.logger.Error().Err(deleteErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if replaceErr := s.recoveryCodeRepo.ReplaceRecoveryCodes(userId, nil); replaceErr != nil {
+		s.logger.Error().Err(replaceErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	return nil
+}
+
+func (s *Service) RegenerateRecoveryCodes(userId uuid.UUID, code string) ([]string, *err.AppError) {
+	if appErr := s.Verify(userId, code); appErr != nil {
+		return nil, appErr
+	}
+	return s.generateRecoveryCodes(userId)
+}
+
+func (s *Service) Verify(userId uuid.UUID, code string) *err.AppError {
+	twoFactor, getErr := s.twoFactorRepo.GetTwoFactorByUserId(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if twoFactor == nil || !twoFactor.IsEnabled() {
+		return err.NewTwoFactorError("Two factor authentication is not enabled")
+	}
+
+	code = strings.ToLower(strings.TrimSpace(code))
+	if step, ok := util.VerifyTotpCode(twoFactor.Secret, code, util.GetCurrentUtcTime(7)); ok {
+		used, useErr := s.twoFactorRepo.UseTwoFactorStep(userId, step)
+		if useErr != nil {
+			s.logger.Error().Err(useErr).Msg("")
+			return err.NewUnhandledError()
+		}
+		if !used {
+			s.logger.Warn().Msgf("TOTP code of user %s was replayed", userId)
+			return err.NewInvalidTwoFactorCodeError()
+		}
+		return nil
+	}
+
+	used, useErr := s.recoveryCodeRepo.UseRecoveryCode(userId, util.HashToken(code))
+	if useErr != nil {
+		s.logger.Error().Err(useErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !used {
+		return err.NewInvalidTwoFactorCodeError()
+	}
+	s.logger.Info().Msgf("User %s used a recovery code", userId)
+	return nil
+}
+
+func (s *Service) generateRecoveryCodes(userId uuid.UUID) ([]string, *err.AppError) {
+	codes := make([]string, 0, recoveryCodeCount)
+	recoveryCodes := make([]entity.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, generateErr := util.GenerateRecoveryCode()
+		if generateErr != nil {
+			s.logger.Error().Err(generateErr).Msg("")
+			return nil, err.NewUnhandledError()
+		}
+		codes = append(codes, code)
+		recoveryCodes = append(recoveryCodes, *entity.NewRecoveryCode(userId, util.HashToken(code)))
+	}
+	if replaceErr := s.recoveryCodeRepo.ReplaceRecoveryCodes(userId, recoveryCodes); replaceErr != nil {
+		s.logger.Error().Err(replaceErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return codes, nil
+}
@@ -0,0 +1,20 @@
+package twofactor
+
+import (
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/google/uuid"
+)
+
+type TwoFactorInterface interface {
+	// GetStatus reports whether the user has enabled two factor authentication and whether their roles require it
+	GetStatus(userId uuid.UUID) (enabled bool, required bool, appErr *err.AppError)
+	// Enroll creates a pending TOTP secret, it is only enabled once a code was verified with Enable
+	Enroll(userId uuid.UUID) (secret string, otpAuthUri string, appErr *err.AppError)
+	// Enable verifies the first code of the pending secret and returns the recovery codes
+	Enable(userId uuid.UUID, code string) ([]string, *err.AppError)
+	Disable(userId uuid.UUID, code string) *err.AppError
+	// RegenerateRecoveryCodes replaces the recovery codes of the user, the previous ones stop working
+	RegenerateRecoveryCodes(userId uuid.UUID, code string) ([]string, *err.AppError)
+	// Verify accepts either a TOTP code or an unused recovery code
+	Verify(userId uuid.UUID, code string) *err.AppError
+}
@@ -0,0 +1,16 @@
+package model
+
+import "github.com/TechwizsonORG/auth-service/entity"
+
+type UserDetail struct {
+	User   entity.User
+	Roles  []entity.Role
+	Scopes []entity.Scope
+}
+
+type UserPage struct {
+	Users    []entity.User
+	Page     int
+	PageSize int
+	Total    int
+}
@@ -0,0 +1,219 @@
+package user
+
+import (
+	"github.com/TechwizsonORG/auth-service/entity"
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase"
+	"github.com/TechwizsonORG/auth-service/usecase/audit"
+	"github.com/TechwizsonORG/auth-service/usecase/messagequeue/event"
+	"github.com/TechwizsonORG/auth-service/usecase/token"
+	"github.com/TechwizsonORG/auth-service/usecase/user/model"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// Admins can't take this role from themselves
+const adminRole = "admin"
+
+type Service struct {
+	logger       zerolog.Logger
+	userRepo     usecase.UserRepository
+	roleRepo     usecase.RoleRepository
+	scopeRepo    usecase.ScopeRepository
+	tokenService token.TokenInterface
+	auditService audit.AuditInterface
+}
+
+func NewUserService(logger zerolog.Logger, userRepo usecase.UserRepository, roleRepo usecase.RoleRepository, scopeRepo usecase.ScopeRepository, tokenService token.TokenInterface, auditService audit.AuditInterface) *Service {
+	logger = logger.
+		With().
+		Str("Service", "User").
+		Logger()
+	return &Service{
+		logger:       logger,
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		scopeRepo:    scopeRepo,
+		tokenService: tokenService,
+		auditService: auditService,
+	}
+}
+
+func (s *Service) GetUsers(search string, page int, pageSize int) (*model.UserPage, *err.AppError) {
+	users, getErr := s.userRepo.GetUsers(search, page-1, pageSize)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	total, countErr := s.userRepo.CountUsers(search)
+	if countErr != nil {
+		s.logger.Error().Err(countErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	return &model.UserPage{
+		Users:    users,
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}, nil
+}
+
+func (s *Service) GetUser(userId uuid.UUID) (*model.UserDetail, *err.AppError) {
+	user, appErr := s.getUser(userId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return &model.UserDetail{
+		User:   *user,
+		Roles:  s.roleRepo.GetRolesByUserId(userId),
+		Scopes: s.scopeRepo.GetScopesByUserId(userId),
+	}, nil
+}
+
+func (s *Service) DeactivateUser(adminId uuid.UUID, userId uuid.UUID) *err.AppError {
+	if adminId == userId {
+		return err.NewUserManagementError("You can't deactivate yourself")
+	}
+	if appErr := s.setUserActive(userId, false); appErr != nil {
+		return appErr
+	}
+	// Access tokens already fail validation since inactive users aren't found anymore
+	return s.tokenService.RevokeUserTokens(userId, "deactivated")
+}
+
+func (s *Service) ReactivateUser(userId uuid.UUID) *err.AppError {
+	return s.setUserActive(userId, true)
+}
+
+func (s *Service) AssignRole(adminId uuid.UUID, userId uuid.UUID, roleName string) *err.AppError {
+	role, appErr := s.getRole(userId, roleName)
+	if appErr != nil {
+		return appErr
+	}
+	if addErr := s.roleRepo.AddUserRole(userId, role.Id); addErr != nil {
+		s.logger.Error().Err(addErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	s.logger.Info().Msgf("Role %s assigned to user %s", roleName, userId)
+	s.recordRoleChanged(adminId, userId, roleName, "assigned")
+	return nil
+}
+
+func (s *Service) RemoveRole(adminId uuid.UUID, userId uuid.UUID, roleName string) *err.AppError {
+	if adminId == userId && roleName == adminRole {
+		return err.NewUserManagementError("You can't remove your own admin role")
+	}
+	role, appErr := s.getRole(userId, roleName)
+	if appErr != nil {
+		return appErr
+	}
+	removed, removeErr := s.roleRepo.RemoveUserRole(userId, role.Id)
+	if removeErr != nil {
+		s.logger.Error().Err(removeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !removed {
+		return err.NewNotFoundError("User doesn't have role " + roleName)
+	}
+	s.logger.Info().Msgf("Role %s removed from user %s", roleName, userId)
+	s.recordRoleChanged(adminId, userId, roleName, "removed")
+	return nil
+}
+
+func (s *Service) AssignScope(userId uuid.UUID, scopeName string) *err.AppError {
+	scope, appErr := s.getScope(userId, scopeName)
+	if appErr != nil {
+		return appErr
+	}
+	if addErr := s.scopeRepo.AddUserScope(userId, scope.Id); addErr != nil {
+		s.logger.Error().Err(addErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	s.logger.Info().Msgf("Scope %s assigned to user %s", scopeName, userId)
+	return nil
+}
+
+func (s *Service) RemoveScope(userId uuid.UUID, scopeName string) *err.AppError {
+	scope, appErr := s.getScope(userId, scopeName)
+	if appErr != nil {
+		return appErr
+	}
+	removed, removeErr := s.scopeRepo.RemoveUserScope(userId, scope.Id)
+	if removeErr != nil {
+		s.logger.Error().Err(removeErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !removed {
+		return err.NewNotFoundError("User doesn't have scope " + scopeName)
+	}
+	s.logger.Info().Msgf("Scope %s removed from user %s", scopeName, userId)
+	return nil
+}
+
+func (s *Service) recordRoleChanged(adminId uuid.UUID, userId uuid.UUID, roleName string, action string) {
+	s.auditService.Record(event.AuthEvent{
+		Type:    event.AuthRoleChanged,
+		UserId:  userId,
+		ActorId: adminId,
+		Detail: event.RoleChangedDetail{
+			Role:   roleName,
+			Action: action,
+		},
+	})
+}
+
+func (s *Service) getUser(userId uuid.UUID) (*entity.User, *err.AppError) {
+	user, getErr := s.userRepo.GetUserByIDIncludeInactive(userId)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if user == nil {
+		return nil, err.NewNotFoundError("User not found")
+	}
+	return user, nil
+}
+
+func (s *Service) setUserActive(userId uuid.UUID, isActive bool) *err.AppError {
+	updated, updateErr := s.userRepo.SetUserActive(userId, isActive)
+	if updateErr != nil {
+		s.logger.Error().Err(updateErr).Msg("")
+		return err.NewUnhandledError()
+	}
+	if !updated {
+		return err.NewNotFoundError("User not found")
+	}
+	return nil
+}
+
+// getRole also makes sure the user exists
+func (s *Service) getRole(userId uuid.UUID, roleName string) (*entity.Role, *err.AppError) {
+	if _, appErr := s.getUser(userId); appErr != nil {
+		return nil, appErr
+	}
+	role, getErr := s.roleRepo.GetRoleByName(roleName)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if role == nil {
+		return nil, err.NewNotFoundError("Role not found")
+	}
+	return role, nil
+}
+
+// getScope also makes sure the user exists
+func (s *Service) getScope(userId uuid.UUID, scopeName string) (*entity.Scope, *err.AppError) {
+	if _, appErr := s.getUser(userId); appErr != nil {
+		return nil, appErr
+	}
+	scope, getErr := s.scopeRepo.GetScopeByName(scopeName)
+	if getErr != nil {
+		s.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewUnhandledError()
+	}
+	if scope == nil {
+		return nil, err.NewNotFoundError("Scope not found")
+	}
+	return scope, nil
+}
@@ -0,0 +1,23 @@
+package user
+
+import (
+	"github.com/TechwizsonORG/auth-service/err"
+	"github.com/TechwizsonORG/auth-service/usecase/user/model"
+	"github.com/google/uuid"
+)
+
+// UserInterface manages the users on behalf of admins, role and scope changes apply from the next issued token
+type UserInterface interface {
+	// GetUsers pages through the users, deactivated ones included, whose username or email contains search
+	GetUsers(search string, page int, pageSize int) (*model.UserPage, *err.AppError)
+	GetUser(userId uuid.UUID) (*model.UserDetail, *err.AppError)
+	// DeactivateUser prevents the user from logging in and ends all of their sessions, admins can't deactivate themselves
+	DeactivateUser(adminId uuid.UUID, userId uuid.UUID) *err.AppError
+	ReactivateUser(userId uuid.UUID) *err.AppError
+	// AssignRole records the change in the audit trail along with the admin who made it
+	AssignRole(adminId uuid.UUID, userId uuid.UUID, roleName string) *err.AppError
+	// RemoveRole refuses to remove the admin role from the admin making the change
+	RemoveRole(adminId uuid.UUID, userId uuid.UUID, roleName string) *err.AppError
+	AssignScope(userId uuid.UUID, scopeName string) *err.AppError
+	RemoveScope(userId uuid.UUID, scopeName string) *err.AppError
+}
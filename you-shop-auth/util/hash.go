@@ -1,14 +1,124 @@
 package util
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
 
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHashParams are the argon2id cost parameters of new password hashes
+type PasswordHashParams struct {
+	// In KiB
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+const (
+	argon2idId            = "argon2id"
+	passwordSaltLength    = 16
+	passwordHashKeyLength = 32
+)
+
+var passwordHashParams = PasswordHashParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+}
+
+// passwordHashSlots bounds the argon2id hashes computed at the same time, every one of them holds its memory cost
+var passwordHashSlots = make(chan struct{}, runtime.NumCPU())
+
+// SetMaxConcurrentPasswordHashes changes how many hashes may be computed at the same time, call it before serving requests
+func SetMaxConcurrentPasswordHashes(max int) {
+	passwordHashSlots = make(chan struct{}, max)
+}
+
+// SetPasswordHashParams changes the cost of the hashes made from now on, existing hashes keep verifying
+func SetPasswordHashParams(params PasswordHashParams) {
+	passwordHashParams = params
+}
+
+// HashPassword hashes with argon2id, the result is in the PHC string format
+// $argon2id$v=19$m=<memory>,t=<iterations>,p=<parallelism>$<salt>$<key>
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+	salt := make([]byte, passwordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	params := passwordHashParams
+	key := idKey(password, salt, params, passwordHashKeyLength)
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idId,
+		argon2.Version,
+		params.Memory,
+		params.Iterations,
+		params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
 }
 
 // VerifyPassword verifies if the given password matches the stored hash.
+// Both argon2id hashes and the legacy bcrypt ones are supported
 func VerifyPassword(password, hash string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-	return err == nil
+	if isBcryptHash(hash) {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	}
+	params, salt, key, err := parseArgon2idHash(hash)
+	if err != nil {
+		return false
+	}
+	computedKey := idKey(password, salt, params, uint32(len(key)))
+	return subtle.ConstantTimeCompare(computedKey, key) == 1
+}
+
+// NeedsRehash tells whether the hash was made by a legacy algorithm or with other cost parameters than the current ones
+func NeedsRehash(hash string) bool {
+	params, _, _, err := parseArgon2idHash(hash)
+	return err != nil || params != passwordHashParams
+}
+
+// idKey waits for a free slot before deriving the key
+func idKey(password string, salt []byte, params PasswordHashParams, keyLength uint32) []byte {
+	passwordHashSlots <- struct{}{}
+	defer func() { <-passwordHashSlots }()
+	return argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, keyLength)
+}
+
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func parseArgon2idHash(hash string) (params PasswordHashParams, salt []byte, key []byte, err error) {
+	// "", id, version, parameters, salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != argon2idId {
+		return params, nil, nil, errors.New("hash isn't an argon2id hash")
+	}
+	var version int
+	if _, err = fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, err
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	if _, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, err
+	}
+	if salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return params, nil, nil, err
+	}
+	if key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return params, nil, nil, err
+	}
+	return params, salt, key, nil
 }
@@ -0,0 +1,31 @@
+package util
+
+import "strings"
+
+// Check if arr2 contains any element of arr1
+func ContainsAny(arr1, arr2 []string) bool {
+	elements := make(map[string]bool)
+	for _, arr2Element := range arr2 {
+		elements[strings.Trim(arr2Element, " ")] = true
+	}
+	for _, arr1Element := range arr1 {
+		if elements[strings.Trim(arr1Element, " ")] {
+			return true
+		}
+	}
+	return false
+}
+
+// Check if arr2 contains all elements of arr1
+func ContainsAll(arr1, arr2 []string) bool {
+	elements := make(map[string]bool)
+	for _, arr2Element := range arr2 {
+		elements[strings.Trim(arr2Element, " ")] = true
+	}
+	for _, arr1Element := range arr1 {
+		if !elements[strings.Trim(arr1Element, " ")] {
+			return false
+		}
+	}
+	return true
+}
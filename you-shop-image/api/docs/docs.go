@@ -150,6 +150,12 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.ApiResponse"
+                        }
+                    },
                     "default": {
                         "description": ""
                     }
@@ -30,7 +30,7 @@ func (i *ImageHandler) RegisterRoutes(routes *gin.RouterGroup) {
 	imageRoutes := routes.Group("/images")
 	imageRoutes.GET("/:id", i.serveImageById)
 	imageRoutes.GET("/banner", i.getBanners)
-	imageRoutes.POST("/upload", i.upload)
+	imageRoutes.POST("/upload", middleware.AuthorizationMiddleware(nil, []string{"image:upload"}), i.upload)
 	imageRoutes.POST("/upload/banner", middleware.AuthorizationMiddleware([]string{"admin"}, nil), i.uploadBanners)
 	imageRoutes.DELETE("/:id", middleware.AuthorizationMiddleware([]string{"admin"}, nil), i.deleteImage)
 	imageRoutes.DELETE("/banner", middleware.AuthorizationMiddleware([]string{"admin"}, nil), i.deleteBanners)
@@ -45,6 +45,7 @@ func (i *ImageHandler) RegisterRoutes(routes *gin.RouterGroup) {
 //	@Param		alt			formData	string	true	"Image Alt"
 //	@Param		owner_id	formData	string	true	"Owner ID"
 //	@Failure	400			{object}	model.ApiResponse{data=err.AppError}
+//	@Failure	401			{object}	model.ApiResponse
 //	@Success	201
 //	@Response	default
 //	@Header		200	{string}	Location	"/api/v1/images/{id}"
@@ -0,0 +1,9 @@
+package token
+
+// ClientCredentialsResponse is what the auth service token endpoint answers to the client credentials grant
+type ClientCredentialsResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
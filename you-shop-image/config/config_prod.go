@@ -89,6 +89,9 @@ func Init() (
 	}
 	httpEndpoint = &model.HttpEndpoint{
 		AuthServerUrl: envMap["AUTH_SERVER_URL"],
+		ClientId:      envMap["AUTH_CLIENT_ID"],
+		ClientSecret:  envMap["AUTH_CLIENT_SECRET"],
+		ClientScope:   envMap["AUTH_CLIENT_SCOPE"],
 	}
 	return databaseConfig, serverConfig, logConfig, rabbitMqConfig, s3ProxyConfig, httpEndpoint, mode
 }
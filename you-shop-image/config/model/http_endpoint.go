@@ -1,5 +1,10 @@
 package model
 
 type HttpEndpoint struct {
-	AuthServerUrl  string
+	AuthServerUrl string
+	// Credentials used to obtain service tokens from the auth server for outbound calls
+	ClientId     string
+	ClientSecret string
+	// Space separated scopes requested for the service token
+	ClientScope string
 }
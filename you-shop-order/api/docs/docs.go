@@ -372,16 +372,10 @@ const docTemplate = `{
         "model.CreateOrderItem": {
             "type": "object",
             "properties": {
-                "colorId": {
-                    "type": "string"
-                },
-                "productId": {
-                    "type": "string"
-                },
                 "quantity": {
                     "type": "integer"
                 },
-                "sizeId": {
+                "variantId": {
                     "type": "string"
                 }
             }
@@ -400,6 +394,9 @@ const docTemplate = `{
                 },
                 "quantity": {
                     "type": "integer"
+                },
+                "variantId": {
+                    "type": "string"
                 }
             }
         },
@@ -43,8 +43,8 @@ func (o *OrderHandler) AddRoute(route *gin.RouterGroup) {
 	orderRoute.GET("/:id", middleware.AuthorizationMiddleware([]string{"admin", "guest"}, nil), o.getOrderById)
 	orderRoute.GET("/user-orders", middleware.AuthorizationMiddleware([]string{"guest", "admin"}, nil), o.getCurrentUserOrders)
 	orderRoute.PATCH("/:id/:status", middleware.AuthorizationMiddleware([]string{"admin"}, nil), o.updateStatus)
-	orderRoute.POST("", middleware.AuthorizationMiddleware([]string{"admin", "guest"}, nil), o.createOrder)
-	orderRoute.PUT("/:id", middleware.AuthorizationMiddleware([]string{"admin", "guest"}, nil), o.updateOrder)
+	orderRoute.POST("", middleware.AuthorizationMiddleware([]string{"admin", "guest"}, nil), middleware.SensitiveMiddleware(), o.createOrder)
+	orderRoute.PUT("/:id", middleware.AuthorizationMiddleware([]string{"admin", "guest"}, nil), middleware.SensitiveMiddleware(), o.updateOrder)
 	orderRoute.DELETE("/:id", middleware.AuthorizationMiddleware([]string{"admin"}, nil), o.deleteOrder)
 }
 
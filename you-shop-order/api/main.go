@@ -74,6 +74,7 @@ func main() {
 	job := job.NewJob(logger)
 	background.Go(logger, job.CreateOrder(rpcService, orderService))
 	background.Go(logger, job.HandlePaymentStatusChangedEvent(msq, orderService))
+	background.Go(logger, job.HandleUserDeletedEvent(msq, orderService))
 
 	gin.SetMode(mode)
 	r := gin.New()
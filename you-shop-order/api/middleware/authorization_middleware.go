@@ -43,3 +43,15 @@ func AuthorizationMiddleware(requireRole, requireScope []string) gin.HandlerFunc
 		c.Next()
 	}
 }
+
+// SensitiveMiddleware marks a write endpoint as sensitive, admins impersonating a user can't call it
+func SensitiveMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actorId := c.GetHeader("actorId"); actorId != "" {
+			c.JSON(403, model.NewApiResponse(403, "Not allowed while impersonating", false, nil))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -19,6 +19,7 @@ type Order struct {
 }
 
 type Item struct {
+	VariantId   uuid.UUID `json:"variantId"`
 	ProductId   uuid.UUID `json:"productId"`
 	ProductName string    `json:"productName"`
 	Price       float64   `json:"price"`
@@ -29,6 +30,7 @@ func FromOrderItem(orderItems []*entity.OrderItem) []*Item {
 	items := []*Item{}
 	for _, item := range orderItems {
 		items = append(items, &Item{
+			VariantId: item.VariantId,
 			ProductId: item.ProductId,
 			Price:     item.Price,
 			Quantity:  item.Quantity,
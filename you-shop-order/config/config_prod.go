@@ -80,9 +80,14 @@ func Init() (
 		GetTotalPrice:        envMap["RPC_SERVER_GET_TOTAL_PRICE"],
 		GetProductByIds:      envMap["RPC_SERVER_GET_PRODUCT_BY_IDS"],
 		GetOrdersPayment:     envMap["RPC_SERVER_GET_ORDERS_PAYMENT"],
+		ReserveInventory:     envMap["RPC_SERVER_RESERVE_INVENTORY"],
+		ReleaseInventory:     envMap["RPC_SERVER_RELEASE_INVENTORY"],
 	}
 	httpEndpoint = &model.HttpEndpoint{
 		AuthServerUrl: envMap["AUTH_SERVER_URL"],
+		ClientId:      envMap["AUTH_CLIENT_ID"],
+		ClientSecret:  envMap["AUTH_CLIENT_SECRET"],
+		ClientScope:   envMap["AUTH_CLIENT_SCOPE"],
 	}
 	return databaseConfig, serverConfig, logConfig, rabbitMqConfig, rpcServerEndpoint, httpEndpoint, mode
 }
@@ -5,4 +5,6 @@ type RpcServerEndpoint struct {
 	GetTotalPrice        string
 	GetProductByIds      string
 	GetOrdersPayment      string
+	ReserveInventory     string
+	ReleaseInventory     string
 }
@@ -4,6 +4,7 @@ import "github.com/google/uuid"
 
 type OrderItem struct {
 	Quantity  int
+	VariantId uuid.UUID
 	ProductId uuid.UUID
 	ColorId   uuid.UUID
 	SizeId    uuid.UUID
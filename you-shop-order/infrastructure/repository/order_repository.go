@@ -37,6 +37,7 @@ func (o *OrderRepository) GetOrderById(orderId uuid.UUID) (*entity.Order, error)
 			o.updated_at,
 			oi.quantity,
 			oi.product_id,
+			oi.variant_id,
 			oi.color_id,
 			oi.size_id,
 			oi.price,
@@ -63,6 +64,7 @@ func (o *OrderRepository) GetOrderById(orderId uuid.UUID) (*entity.Order, error)
 			&order.UpdatedAt,
 			&item.Quantity,
 			&item.ProductId,
+			&item.VariantId,
 			&item.ColorId,
 			&item.SizeId,
 			&item.Price,
@@ -143,8 +145,8 @@ func (o *OrderRepository) CreateOrder(order *entity.Order) error {
 		return err
 	}
 	itemQuery := `
-		INSERT INTO order_item (quantity, product_id, color_id, size_id, order_id, price, price_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO order_item (quantity, variant_id, product_id, color_id, size_id, order_id, price, price_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 	itemStmt, err := tx.Prepare(itemQuery)
 	if err != nil {
@@ -152,7 +154,7 @@ func (o *OrderRepository) CreateOrder(order *entity.Order) error {
 	}
 	defer itemStmt.Close()
 	for _, item := range order.Items {
-		_, err = itemStmt.Exec(item.Quantity, item.ProductId, item.ColorId, item.SizeId, order.Id, item.Price, item.PriceId)
+		_, err = itemStmt.Exec(item.Quantity, item.VariantId, item.ProductId, item.ColorId, item.SizeId, order.Id, item.Price, item.PriceId)
 		if err != nil {
 			tx.Rollback()
 			return err
@@ -193,3 +195,19 @@ func (o *OrderRepository) UpdateOrder(entity *entity.Order) error {
 func (o *OrderRepository) DeleteOrder(*entity.Order) error {
 	return nil
 }
+
+func (o *OrderRepository) PseudonymizeOwner(ownerId uuid.UUID, pseudonym uuid.UUID) (int64, error) {
+	query := `
+		UPDATE "order"
+		SET
+			owner_id = $1,
+			description = '',
+			updated_at = $2
+		WHERE owner_id = $3
+	`
+	result, err := o.db.Exec(query, pseudonym, util.GetCurrentUtcTime(7), ownerId)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -81,3 +81,27 @@ func (j *Job) HandlePaymentStatusChangedEvent(msq messagequeue.MessageQueue, ord
 		)
 	}
 }
+
+func (j *Job) HandleUserDeletedEvent(msq messagequeue.MessageQueue, orderService order.Service) background.JobFunc {
+	return func() {
+		j.logger.Debug().Msg("Handle user deleted event from background job")
+		msq.ConsumeDurable(
+			*messagequeue.NewDefaultExchangeConfig("you_shop", messagequeue.Topic),
+			*messagequeue.NewDurableQueueConfig("order_user_deleted_event", "user.deleted"),
+			func(data string) error {
+				var userDeletedEvent event.UserDeletedEvent
+				bindErr := json.Unmarshal([]byte(data), &userDeletedEvent)
+				if bindErr != nil {
+					// A malformed event never succeeds, retrying it would block the queue
+					j.logger.Error().Err(bindErr).Msgf("Dropped malformed user deleted event %s", data)
+					return nil
+				}
+				j.logger.Debug().Msgf("User %s was deleted, erasing their order data", userDeletedEvent.UserId.String())
+				if eraseErr := orderService.EraseUserData(userDeletedEvent.UserId, userDeletedEvent.Pseudonym); eraseErr != nil {
+					return eraseErr
+				}
+				return nil
+			},
+		)
+	}
+}
@@ -33,6 +33,7 @@ type UpdatedOrderEvent struct {
 
 type UpdatedOrderItem struct {
 	Quantity  int       `json:"quantity"`
+	VariantId uuid.UUID `json:"variantId"`
 	ProductId uuid.UUID `json:"productId"`
 	ColorId   uuid.UUID `json:"colorId"`
 	SizeId    uuid.UUID `json:"sizeId"`
@@ -45,6 +46,7 @@ func FromOrderEntity(order *entity.Order) *UpdatedOrderEvent {
 	for _, item := range order.Items {
 		items = append(items, &UpdatedOrderItem{
 			Quantity:  item.Quantity,
+			VariantId: item.VariantId,
 			ProductId: item.ProductId,
 			ColorId:   item.ColorId,
 			SizeId:    item.SizeId,
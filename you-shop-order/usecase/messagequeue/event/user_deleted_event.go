@@ -0,0 +1,15 @@
+package event
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDeletedEvent is published by the auth service once an account is deleted
+type UserDeletedEvent struct {
+	UserId uuid.UUID `json:"userId"`
+	// Random id replacing the user id in the kept records
+	Pseudonym uuid.UUID `json:"pseudonym"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
@@ -15,6 +15,8 @@ type Repository interface {
 	CreateOrder(*entity.Order) error
 	UpdateOrder(*entity.Order) error
 	DeleteOrder(*entity.Order) error
+	// Replace the owner of every order of the user with the pseudonym and clear the description
+	PseudonymizeOwner(ownerId uuid.UUID, pseudonym uuid.UUID) (int64, error)
 }
 type Service interface {
 	CreateOrder(createOrder model.CreateOrder) (*entity.Order, err.ApplicationError)
@@ -24,4 +26,6 @@ type Service interface {
 	GetOrders() []entity.Order
 	GetUserOrders(userId uuid.UUID, page, pageSize int) []entity.Order
 	DeleteOrder(orderId, ownerId uuid.UUID) err.ApplicationError
+	// Erase the personal data of a deleted user, prices and items are kept for the financial records
+	EraseUserData(userId, pseudonym uuid.UUID) err.ApplicationError
 }
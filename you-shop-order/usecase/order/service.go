@@ -38,9 +38,6 @@ func NewOrderService(rpcService rpc.RpcInterface, rpcEndpoint configModel.RpcSer
 
 func (o *OrderService) CreateOrder(createOrder model.CreateOrder) (*entity.Order, err.ApplicationError) {
 
-	if quantityCheckErr := o.checkProductQuantity(createOrder.Items); quantityCheckErr != nil {
-		return nil, quantityCheckErr
-	}
 	totalPrice, items, getTotalPriceErr := o.getTotalPrice(createOrder.Items)
 	if getTotalPriceErr != nil {
 		return nil, getTotalPriceErr
@@ -51,11 +48,15 @@ func (o *OrderService) CreateOrder(createOrder model.CreateOrder) (*entity.Order
 	}
 
 	order := entity.NewOrder(createOrder.Description, generateOrderCode(), totalPrice, createOrder.UserId, items)
+	if reserveErr := o.reserveInventory(order.Id, createOrder.Items); reserveErr != nil {
+		return nil, reserveErr
+	}
 	orderJson, _ := json.Marshal(order)
 	o.logger.Debug().Msgf("new order: %s", orderJson)
 	createError := o.repo.CreateOrder(order)
 	if createError != nil {
 		o.logger.Error().Err(createError).Msg("")
+		o.releaseInventory(order.Id)
 		return nil, err.NewOrderDefaultError(nil)
 	}
 	return order, nil
@@ -96,6 +97,9 @@ func (o *OrderService) UpdateOrder(updateOrder entity.Order, isCancel bool, owne
 		o.logger.Error().Err(updateErr).Msg("")
 		return nil, err.NewOrderDefaultError(nil)
 	}
+	if isCancel {
+		o.releaseInventory(order.Id)
+	}
 	return order, nil
 }
 
@@ -204,6 +208,10 @@ func (o *OrderService) getTotalPrice(orderItems []*model.CreateOrderItem) (float
 		return 0, nil, err.NewOrderDefaultError(nil)
 	}
 	jsonRes := o.rpcService.Req(o.rpcEndpoint.GetTotalPrice, string(jsonReq))
+	if jsonRes == "" {
+		o.logger.Error().Msg("Price service couldn't price the order items")
+		return 0, nil, err.NewOrderError(400, "Order can't be priced", "Some items have no current price", nil)
+	}
 	var res rpcModel.TotalPriceResponse
 	parseJsonErr = json.Unmarshal([]byte(jsonRes), &res)
 	if parseJsonErr != nil {
@@ -214,6 +222,7 @@ func (o *OrderService) getTotalPrice(orderItems []*model.CreateOrderItem) (float
 	for _, item := range res.Items {
 		orderItemEntities = append(orderItemEntities, &entity.OrderItem{
 			Quantity:  item.Quantity,
+			VariantId: item.VariantId,
 			ProductId: item.ProductId,
 			SizeId:    item.SizeId,
 			ColorId:   item.ColorId,
@@ -224,35 +233,44 @@ func (o *OrderService) getTotalPrice(orderItems []*model.CreateOrderItem) (float
 	return res.TotalPrice, orderItemEntities, nil
 }
 
-func (o *OrderService) checkProductQuantity(orderItems []*model.CreateOrderItem) err.ApplicationError {
-	for _, item := range orderItems {
-		req := &rpcModel.CheckProductQuantity{
-			ProductId:       item.ProductId,
-			ColorId:         item.ColorId,
-			SizeId:          item.SizeId,
-			RequireQuantity: item.Quantity,
-		}
-		jsonReq, parseJsonErr := json.Marshal(req)
-		if parseJsonErr != nil {
-			o.logger.Error().Err(parseJsonErr).Msg("")
-			return err.NewOrderDefaultError(nil)
-		}
-		jsonRes := o.rpcService.Req(o.rpcEndpoint.CheckProductQuantity, string(jsonReq))
-		var res rpcModel.CheckProductQuantityResponse
-		parseJsonErr = json.Unmarshal([]byte(jsonRes), &res)
-		if parseJsonErr != nil {
-			o.logger.Error().Err(parseJsonErr).Msg("")
-			return err.NewOrderDefaultError(nil)
-		}
-		if !res.IsEnough {
-			title := "Not enough quantity"
-			detail := fmt.Sprintf("Not enough quantity for product: %s", item.ProductId)
-			return err.NewOrderError(400, title, detail, nil)
+// reserveInventory holds the stock of the items for the order until it's confirmed, canceled or the reservation expires
+func (o *OrderService) reserveInventory(orderId uuid.UUID, orderItems []*model.CreateOrderItem) err.ApplicationError {
+	jsonReq, parseJsonErr := json.Marshal(&rpcModel.ReserveInventoryRequest{
+		OrderId: orderId,
+		Items:   rpcModel.FromOrderItemsToReserveItems(orderItems),
+	})
+	if parseJsonErr != nil {
+		o.logger.Error().Err(parseJsonErr).Msg("")
+		return err.NewOrderDefaultError(nil)
+	}
+	jsonRes := o.rpcService.Req(o.rpcEndpoint.ReserveInventory, string(jsonReq))
+	var res rpcModel.ReserveInventoryResponse
+	parseJsonErr = json.Unmarshal([]byte(jsonRes), &res)
+	if parseJsonErr != nil {
+		o.logger.Error().Err(parseJsonErr).Msg("")
+		return err.NewOrderDefaultError(nil)
+	}
+	if !res.IsReserved {
+		title := "Not enough quantity"
+		detail := "Not enough quantity"
+		if len(res.ShortVariantIds) > 0 {
+			detail = fmt.Sprintf("Not enough quantity for variant: %s", res.ShortVariantIds[0])
 		}
+		return err.NewOrderError(400, title, detail, nil)
 	}
 	return nil
 }
 
+// releaseInventory gives the held stock back, the reservation expires by itself when this fails
+func (o *OrderService) releaseInventory(orderId uuid.UUID) {
+	jsonReq, _ := json.Marshal(&rpcModel.ReleaseInventoryRequest{OrderId: orderId})
+	jsonRes := o.rpcService.Req(o.rpcEndpoint.ReleaseInventory, string(jsonReq))
+	var res rpcModel.ReleaseInventoryResponse
+	if parseJsonErr := json.Unmarshal([]byte(jsonRes), &res); parseJsonErr != nil || !res.IsReleased {
+		o.logger.Warn().Msgf("Releasing inventory of order %s failed", orderId)
+	}
+}
+
 func (o *OrderService) DeleteOrder(orderId, ownerId uuid.UUID) err.ApplicationError {
 	ok, checkExistErr := o.repo.IsOrderExistById(orderId)
 	if checkExistErr != nil {
@@ -282,5 +300,17 @@ func (o *OrderService) DeleteOrder(orderId, ownerId uuid.UUID) err.ApplicationEr
 		o.logger.Error().Err(updateErr).Msg("")
 		return err.NewOrderDefaultError(nil)
 	}
+	// Only live reservations are released, the stock of a confirmed order stays deducted
+	o.releaseInventory(order.Id)
+	return nil
+}
+
+func (o *OrderService) EraseUserData(userId, pseudonym uuid.UUID) err.ApplicationError {
+	rows, updateErr := o.repo.PseudonymizeOwner(userId, pseudonym)
+	if updateErr != nil {
+		o.logger.Error().Err(updateErr).Msg("")
+		return err.NewOrderDefaultError(nil)
+	}
+	o.logger.Info().Msgf("Pseudonymized %d orders of deleted user %s", rows, userId.String())
 	return nil
 }
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/order-service/usecase/order/model"
+	"github.com/google/uuid"
+)
+
+type ReserveInventoryRequest struct {
+	OrderId uuid.UUID               `json:"orderId"`
+	Items   []*ReserveInventoryItem `json:"items"`
+}
+
+type ReserveInventoryItem struct {
+	VariantId uuid.UUID `json:"variantId"`
+	Quantity  int       `json:"quantity"`
+}
+
+type ReserveInventoryResponse struct {
+	IsReserved      bool        `json:"isReserved"`
+	ExpiresAt       time.Time   `json:"expiresAt"`
+	ShortVariantIds []uuid.UUID `json:"shortVariantIds"`
+}
+
+type ReleaseInventoryRequest struct {
+	OrderId uuid.UUID `json:"orderId"`
+}
+
+type ReleaseInventoryResponse struct {
+	IsReleased bool `json:"isReleased"`
+}
+
+func FromOrderItemsToReserveItems(items []*model.CreateOrderItem) []*ReserveInventoryItem {
+	result := make([]*ReserveInventoryItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, &ReserveInventoryItem{
+			VariantId: item.VariantId,
+			Quantity:  item.Quantity,
+		})
+	}
+	return result
+}
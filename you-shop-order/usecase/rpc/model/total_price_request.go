@@ -10,9 +10,7 @@ type TotalPriceRequest struct {
 }
 
 type OrderItem struct {
-	ProductId uuid.UUID `json:"productId"`
-	SizeId    uuid.UUID `json:"sizeId"`
-	ColorId   uuid.UUID `json:"colorId"`
+	VariantId uuid.UUID `json:"variantId"`
 	Quantity  int       `json:"quantity"`
 }
 
@@ -20,9 +18,7 @@ func FromOrderItems(items []*model.CreateOrderItem) []*OrderItem {
 	result := make([]*OrderItem, 0, len(items))
 	for _, item := range items {
 		result = append(result, &OrderItem{
-			ProductId: item.ProductId,
-			ColorId:   item.ColorId,
-			SizeId:    item.SizeId,
+			VariantId: item.VariantId,
 			Quantity:  item.Quantity,
 		})
 	}
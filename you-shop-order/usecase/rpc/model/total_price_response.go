@@ -10,6 +10,7 @@ type TotalPriceResponse struct {
 type Item struct {
 	Amount    float64   `json:"amount"`
 	Quantity  int       `json:"quantity"`
+	VariantId uuid.UUID `json:"variantId"`
 	ProductId uuid.UUID `json:"productId"`
 	ColorId   uuid.UUID `json:"colorId"`
 	SizeId    uuid.UUID `json:"sizeId"`
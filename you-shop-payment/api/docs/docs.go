@@ -228,16 +228,10 @@ const docTemplate = `{
         "model.CreateOrderItem": {
             "type": "object",
             "properties": {
-                "colorId": {
-                    "type": "string"
-                },
-                "productId": {
-                    "type": "string"
-                },
                 "quantity": {
                     "type": "integer"
                 },
-                "sizeId": {
+                "variantId": {
                     "type": "string"
                 }
             }
@@ -31,8 +31,8 @@ func NewVnpayHandler(vnpayService *infrastructure.VnpayService, paymentService u
 
 func (v *VnpayHandler) AddVnpayRoute(c *gin.RouterGroup) {
 	vnpayGroup := c.Group("/payments/vnpay")
-	vnpayGroup.POST("/pay", middleware.AuthorizationMiddleware([]string{"admin", "guest"}, nil), v.getVnpayPaymentUrl)
-	vnpayGroup.GET("/pay/:orderId", middleware.AuthorizationMiddleware([]string{"admin", "guest"}, nil), v.reGetVnpayPaymentUrl)
+	vnpayGroup.POST("/pay", middleware.AuthorizationMiddleware([]string{"admin", "guest"}, nil), middleware.SensitiveMiddleware(), v.getVnpayPaymentUrl)
+	vnpayGroup.GET("/pay/:orderId", middleware.AuthorizationMiddleware([]string{"admin", "guest"}, nil), middleware.SensitiveMiddleware(), v.reGetVnpayPaymentUrl)
 	vnpayGroup.GET("/ipn", v.vnpayCallback)
 }
 
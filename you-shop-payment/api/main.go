@@ -77,6 +77,7 @@ func main() {
 	//Register job
 	jobs := job.NewJob(logger)
 	background.Go(logger, jobs.GetOrdersPayment(rpcService, paymentService))
+	background.Go(logger, jobs.HandleUserDeletedEvent(msq, paymentService))
 
 	gin.SetMode(mode)
 	route := gin.New()
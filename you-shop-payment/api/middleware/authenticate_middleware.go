@@ -94,4 +94,9 @@ func setAuthHeader(c *gin.Context, res *http.Response, logger *zerolog.Logger) {
 	} else {
 		c.Request.Header.Set("scope", scope)
 	}
+
+	// Only present when an admin is impersonating the user
+	if actorId, ok := dataMap["actorId"].(string); ok {
+		c.Request.Header.Set("actorId", actorId)
+	}
 }
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TechwizsonORG/payment-service/api/model/token"
+	"github.com/TechwizsonORG/payment-service/config/model"
+	"github.com/rs/zerolog"
+)
+
+// Tokens are renewed a bit before they expire so a request never reaches another service with an expired one
+const tokenExpiryLeeway = 30 * time.Second
+
+// ClientCredentialsTransport authenticates outbound requests with an access token obtained
+// from the auth service with the client credentials grant. The token is cached until it expires.
+type ClientCredentialsTransport struct {
+	logger       zerolog.Logger
+	httpEndpoint model.HttpEndpoint
+	base         http.RoundTripper
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func NewClientCredentialsTransport(logger zerolog.Logger, httpEndpoint model.HttpEndpoint) *ClientCredentialsTransport {
+	logger = logger.
+		With().
+		Str("Middleware", "Client Credentials").
+		Logger()
+	return &ClientCredentialsTransport{
+		logger:       logger,
+		httpEndpoint: httpEndpoint,
+		base:         http.DefaultTransport,
+	}
+}
+
+// NewClientCredentialsClient returns an http client whose requests carry the service access token
+func NewClientCredentialsClient(logger zerolog.Logger, httpEndpoint model.HttpEndpoint) *http.Client {
+	return &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: NewClientCredentialsTransport(logger, httpEndpoint),
+	}
+}
+
+func (t *ClientCredentialsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	accessToken, err := t.GetToken()
+	if err != nil {
+		return nil, err
+	}
+	authReq := req.Clone(req.Context())
+	authReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	res, err := t.base.RoundTrip(authReq)
+	if err == nil && res.StatusCode == http.StatusUnauthorized {
+		// The token may have been revoked, the next request will fetch a new one
+		t.clearToken()
+	}
+	return res, err
+}
+
+// GetToken returns the cached access token or requests a new one when it is about to expire
+func (t *ClientCredentialsTransport) GetToken() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.accessToken != "" && time.Now().Add(tokenExpiryLeeway).Before(t.expiresAt) {
+		return t.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if t.httpEndpoint.ClientScope != "" {
+		form.Set("scope", t.httpEndpoint.ClientScope)
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/token", strings.TrimSuffix(t.httpEndpoint.AuthServerUrl, "/")), strings.NewReader(form.Encode()))
+	if err != nil {
+		t.logger.Error().Err(err).Msg("Error occurred when creating token request")
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(t.httpEndpoint.ClientId, t.httpEndpoint.ClientSecret)
+
+	requestedAt := time.Now()
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logger.Error().Err(err).Msg("Error occurred when requesting access token")
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.logger.Error().Msgf("Auth service answered %d to the client credentials grant", res.StatusCode)
+		return "", fmt.Errorf("couldn't obtain access token, status %d", res.StatusCode)
+	}
+
+	var tokenRes token.ClientCredentialsResponse
+	if err := json.NewDecoder(res.Body).Decode(&tokenRes); err != nil {
+		t.logger.Error().Err(err).Msg("Failed to parse token response")
+		return "", err
+	}
+	t.accessToken = tokenRes.AccessToken
+	t.expiresAt = requestedAt.Add(time.Duration(tokenRes.ExpiresIn) * time.Second)
+	return t.accessToken, nil
+}
+
+func (t *ClientCredentialsTransport) clearToken() {
+	t.mu.Lock()
+	t.accessToken = ""
+	t.mu.Unlock()
+}
@@ -56,6 +56,9 @@ func Init() (databaseConfig *model.DatabaseConfig, serverConfig *model.ServerCon
 
 	httpEndpoint = &model.HttpEndpoint{
 		AuthServerUrl: envMap["AUTH_SERVER_URL"],
+		ClientId:      envMap["AUTH_CLIENT_ID"],
+		ClientSecret:  envMap["AUTH_CLIENT_SECRET"],
+		ClientScope:   envMap["AUTH_CLIENT_SCOPE"],
 	}
 
 	rabbitMqPort, err := strconv.Atoi(envMap["MSG_BROKER_PORT"])
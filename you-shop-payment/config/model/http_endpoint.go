@@ -2,4 +2,9 @@ package model
 
 type HttpEndpoint struct {
 	AuthServerUrl string
+	// Credentials used to obtain service tokens from the auth server for outbound calls
+	ClientId     string
+	ClientSecret string
+	// Space separated scopes requested for the service token
+	ClientScope string
 }
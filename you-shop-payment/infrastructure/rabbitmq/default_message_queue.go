@@ -2,6 +2,7 @@ package rabbitmq
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/TechwizsonORG/payment-service/config/model"
 	messageQueue "github.com/TechwizsonORG/payment-service/usecase/message_queue"
@@ -91,3 +92,67 @@ func (mq *DefaultMessageQueue) Consume(exchangeConfig messageQueue.ExchangeConfi
 	<-forever
 
 }
+
+// consumeRetryDelay is how long a failed message waits before it's redelivered and a lost connection before it's reopened
+const consumeRetryDelay = 10 * time.Second
+
+func (mq *DefaultMessageQueue) ConsumeDurable(exchangeConfig messageQueue.ExchangeConfig, queueConfig messageQueue.QueueConfig, handler func(data string) error) {
+	for {
+		err := mq.consumeWithAck(exchangeConfig, queueConfig, handler)
+		mq.logger.Error().Err(err).Msgf("Consumer of %s stopped, reconnecting in %s", queueConfig.QueueName, consumeRetryDelay)
+		time.Sleep(consumeRetryDelay)
+	}
+}
+
+func (mq *DefaultMessageQueue) consumeWithAck(exchangeConfig messageQueue.ExchangeConfig, queueConfig messageQueue.QueueConfig, handler func(data string) error) error {
+	conn, err := amqp091.Dial(mq.rabbitMqConfig.GetAmqpServerUrl())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	err = ch.ExchangeDeclare(exchangeConfig.ExchangeName, string(exchangeConfig.Type), exchangeConfig.Durable, exchangeConfig.AutoDelete, exchangeConfig.Internal, exchangeConfig.NoWait, nil)
+	if err != nil {
+		return err
+	}
+
+	q, err := ch.QueueDeclare(queueConfig.QueueName, queueConfig.Durable, queueConfig.DeleteUnused, queueConfig.Exclusive, queueConfig.NoWait, nil)
+	if err != nil {
+		return err
+	}
+
+	err = ch.QueueBind(q.Name, queueConfig.RoutingKey, exchangeConfig.ExchangeName, queueConfig.NoWait, nil)
+	if err != nil {
+		return err
+	}
+
+	// One unacknowledged message at a time, a failing message is retried before the ones behind it
+	if err = ch.Qos(1, 0, false); err != nil {
+		return err
+	}
+
+	msgs, err := ch.Consume(q.Name, "", false, queueConfig.Exclusive, false, queueConfig.NoWait, nil)
+	if err != nil {
+		return err
+	}
+
+	for d := range msgs {
+		if handleErr := handler(string(d.Body)); handleErr != nil {
+			mq.logger.Error().Err(handleErr).Msgf("Failed to handle message of %s, retrying in %s", q.Name, consumeRetryDelay)
+			time.Sleep(consumeRetryDelay)
+			err = d.Nack(false, true)
+		} else {
+			err = d.Ack(false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return amqp091.ErrClosed
+}
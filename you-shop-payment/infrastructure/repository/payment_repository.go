@@ -218,3 +218,16 @@ func (p *PaymentRepository) GetPaymentByOrderId(orderId uuid.UUID) (*entity.Paym
 	}
 	return payment, nil
 }
+
+func (p *PaymentRepository) PseudonymizeUserPayments(userId uuid.UUID, pseudonym uuid.UUID) (int64, error) {
+	query := `
+		UPDATE payment
+		SET user_id = $1, updated_at = $2
+		WHERE user_id = $3
+	`
+	result, err := p.db.Exec(query, pseudonym, util.GetCurrentUtcTime(7), userId)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/TechwizsonORG/payment-service/background"
 	"github.com/TechwizsonORG/payment-service/usecase"
+	messagequeue "github.com/TechwizsonORG/payment-service/usecase/message_queue"
+	"github.com/TechwizsonORG/payment-service/usecase/message_queue/event"
 	"github.com/TechwizsonORG/payment-service/usecase/rpc"
 	"github.com/TechwizsonORG/payment-service/usecase/rpc/model"
 	"github.com/rs/zerolog"
@@ -47,3 +49,26 @@ func (j *Job) GetOrdersPayment(rpc rpc.RpcInterface, paymentService usecase.Serv
 		})
 	}
 }
+
+func (j *Job) HandleUserDeletedEvent(msq messagequeue.MessageQueue, paymentService usecase.Service) background.JobFunc {
+	return func() {
+		msq.ConsumeDurable(
+			*messagequeue.NewDefaultExchangeConfig("you_shop", messagequeue.Topic),
+			*messagequeue.NewDurableQueueConfig("payment_user_deleted_event", "user.deleted"),
+			func(data string) error {
+				var userDeletedEvent event.UserDeletedEvent
+				bindErr := json.Unmarshal([]byte(data), &userDeletedEvent)
+				if bindErr != nil {
+					// A malformed event never succeeds, retrying it would block the queue
+					j.logger.Error().Err(bindErr).Msgf("Dropped malformed user deleted event %s", data)
+					return nil
+				}
+				j.logger.Debug().Msgf("User %s was deleted, erasing their payment data", userDeletedEvent.UserId.String())
+				if eraseErr := paymentService.EraseUserData(userDeletedEvent.UserId, userDeletedEvent.Pseudonym); eraseErr != nil {
+					return eraseErr
+				}
+				return nil
+			},
+		)
+	}
+}
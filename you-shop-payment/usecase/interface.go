@@ -15,6 +15,8 @@ type PaymentRepository interface {
 	GetPaymentById(paymentId uuid.UUID) (*entity.Payment, error)
 	GetPaymensByOrderIds(orderIds []uuid.UUID) ([]*entity.Payment, error)
 	GetPaymentByOrderId(orderId uuid.UUID) (*entity.Payment, error)
+	// Replace the user of every payment with the pseudonym, amounts and transactions are untouched
+	PseudonymizeUserPayments(userId uuid.UUID, pseudonym uuid.UUID) (int64, error)
 }
 
 type Service interface {
@@ -23,4 +25,5 @@ type Service interface {
 	GetUserPayments(userId uuid.UUID, pageSize, pageNumber int) []*entity.Payment
 	GetPaymentsByOrderIds(orderIds []uuid.UUID) []*entity.Payment
 	GetPaymentByOrderId(orderId uuid.UUID) (*entity.Payment, err.AppError)
+	EraseUserData(userId, pseudonym uuid.UUID) err.AppError
 }
@@ -38,6 +38,13 @@ func NewDefaultQueueConfig(name string, routingKey string) *QueueConfig {
 	}
 }
 
+// NewDurableQueueConfig declares a queue which survives a broker restart, for events which must not be lost
+func NewDurableQueueConfig(name string, routingKey string) *QueueConfig {
+	queueConfig := NewDefaultQueueConfig(name, routingKey)
+	queueConfig.Durable = true
+	return queueConfig
+}
+
 func NewDefaultExchangeConfig(name string, exchangeType ExchangeType) *ExchangeConfig {
 	return &ExchangeConfig{
 		ExchangeName: name,
@@ -56,4 +63,8 @@ type MessageQueue interface {
 
 	// Data parameter in handler will be a string in JSON form
 	Consume(exchangeConfig ExchangeConfig, queueConfig QueueConfig, handler func(data string) error)
+
+	// Messages are acknowledged once the handler succeeded, failed ones are redelivered after a delay
+	// and the consumer reconnects when the connection is lost
+	ConsumeDurable(exchangeConfig ExchangeConfig, queueConfig QueueConfig, handler func(data string) error)
 }
@@ -13,9 +13,7 @@ type CreateOrder struct {
 }
 
 type CreateOrderItem struct {
-	ProductId uuid.UUID `json:"productId"`
-	SizeId    uuid.UUID `json:"sizeId"`
-	ColorId   uuid.UUID `json:"colorId"`
+	VariantId uuid.UUID `json:"variantId"`
 	Quantity  int       `json:"quantity"`
 }
 
@@ -120,3 +120,13 @@ func (p *PaymentService) GetPaymentByOrderId(orderId uuid.UUID) (*entity.Payment
 	}
 	return payment, nil
 }
+
+func (p *PaymentService) EraseUserData(userId, pseudonym uuid.UUID) err.AppError {
+	rows, updateErr := p.paymentRepo.PseudonymizeUserPayments(userId, pseudonym)
+	if updateErr != nil {
+		p.logger.Error().Err(updateErr).Msg("")
+		return err.NewCommonErr()
+	}
+	p.logger.Info().Msgf("Pseudonymized %d payments of deleted user %s", rows, userId.String())
+	return nil
+}
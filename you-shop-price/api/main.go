@@ -54,6 +54,9 @@ func main() {
 	rpcService := rpc.NewRpcService(*rabbitMqConfig, logger)
 	priceRepo := repository.NewPriceRepository(logger, db)
 	priceService := usecase.NewPriceService(logger, priceRepo)
+	if backfillErr := priceService.BackfillVariantIds(); backfillErr != nil {
+		logger.Error().Msg("Failed to backfill the variant ids of legacy prices")
+	}
 	priceHandler := handler.NewPriceHandler(priceService)
 	msgQueue := rabbitmq.NewDefaultMessageQueue(*rabbitMqConfig, logger)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -64,6 +67,7 @@ func main() {
 	background.Go(logger, job.InventoriesCreatedHandler(msgQueue, priceService))
 	background.Go(logger, job.UpdatePrice(ctx, rpcService, priceService))
 	background.Go(logger, job.GetTotalPrice(rpcService, priceService))
+	background.Go(logger, job.FilterProductsByPrice(rpcService, priceService))
 
 	logger.Info().Msg("Starting server...")
 
@@ -13,6 +13,7 @@ type Price struct {
 	ValidFrom   time.Time
 	ValidTo     time.Time
 	MinQuantity int
+	VariantId   uuid.UUID
 	ProductId   uuid.UUID
 	ColorId     uuid.UUID
 	SizeId      uuid.UUID
@@ -20,7 +21,16 @@ type Price struct {
 	PriceListId uuid.UUID
 }
 
-func NewPrice(amount float64, productId, colorId, sizeId uuid.UUID) *Price {
+// legacyVariantNamespace must match the one of the product service
+var legacyVariantNamespace = uuid.MustParse("3c8f2a5e-9d41-4b6e-8f0a-7e2d5c1b9a64")
+
+// LegacyVariantId derives the id of the variant the product service backfilled for a product, color and size
+// which were priced before variants existed
+func LegacyVariantId(productId, colorId, sizeId uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(legacyVariantNamespace, []byte(productId.String()+"/"+colorId.String()+"/"+sizeId.String()))
+}
+
+func NewPrice(amount float64, productId, colorId, sizeId, variantId uuid.UUID) *Price {
 	return &Price{
 		AuditEntity: AuditEntity{
 			Id:        uuid.New(),
@@ -28,6 +38,7 @@ func NewPrice(amount float64, productId, colorId, sizeId uuid.UUID) *Price {
 			UpdatedAt: util.GetCurrentUtcTime(7),
 		},
 		Amount:    amount,
+		VariantId: variantId,
 		ProductId: productId,
 		ColorId:   colorId,
 		SizeId:    sizeId,
@@ -2,8 +2,6 @@ package repository
 
 import (
 	"database/sql"
-	"fmt"
-	"strings"
 
 	"github.com/TechwizsonORG/price-service/entity"
 	"github.com/TechwizsonORG/price-service/usecase/rpc/model"
@@ -27,16 +25,16 @@ func NewPriceRepository(logger zerolog.Logger, db *sql.DB) *PriceRepository {
 }
 
 func (p *PriceRepository) GetCurrentPrices(items []*model.OrderItem) ([]entity.Price, error) {
-	keys := make([]interface{}, 0, len(items))
+	variantIds := make([]uuid.UUID, 0, len(items))
 	for _, item := range items {
-		keys = append(keys, item.ProductId, item.ColorId, item.SizeId)
+		variantIds = append(variantIds, item.VariantId)
 	}
 
-	queryBuff := strings.Builder{}
-	queryBuff.WriteString(`
+	query := `
 		SELECT
 			p.id,
 			COALESCE(p.amount, 0) AS amount,
+			p.variant_id,
 			p.color_id,
 			p.product_id,
 			p.size_id
@@ -46,19 +44,9 @@ func (p *PriceRepository) GetCurrentPrices(items []*model.OrderItem) ([]entity.P
 			AND p.is_active = true
 			AND pl.currency = 1
 			AND NOW() BETWEEN p.valid_from AND COALESCE(p.valid_to, 'infinity'::timestamptz)
-			AND (p.product_id, p.color_id, p.size_id) IN (
-	`)
-	for i := range items {
-		if i > 0 {
-			queryBuff.WriteString(", ")
-		}
-
-		queryBuff.WriteString(fmt.Sprintf("($%d, $%d, $%d)", 3*i+1, 3*i+2, 3*i+3))
-	}
-	queryBuff.WriteRune(')')
-	query := queryBuff.String()
-	p.logger.Debug().Msgf("Get current price query: %s", query)
-	rows, queryErr := p.db.Query(query, keys...)
+			AND p.variant_id = ANY($1::uuid[])
+	`
+	rows, queryErr := p.db.Query(query, pq.Array(variantIds))
 
 	if queryErr != nil {
 		return nil, queryErr
@@ -68,7 +56,7 @@ func (p *PriceRepository) GetCurrentPrices(items []*model.OrderItem) ([]entity.P
 	result := make([]entity.Price, 0, len(items))
 	for rows.Next() {
 		var price entity.Price
-		scanErr := rows.Scan(&price.Id, &price.Amount, &price.ColorId, &price.ProductId, &price.SizeId)
+		scanErr := rows.Scan(&price.Id, &price.Amount, &price.VariantId, &price.ColorId, &price.ProductId, &price.SizeId)
 		if scanErr != nil {
 			return nil, scanErr
 		}
@@ -116,7 +104,8 @@ func (p *PriceRepository) AddNewPriceList(priceList entity.PriceList) (*entity.P
 	return &priceList, nil
 }
 
-func (p *PriceRepository) UpdatePrice(productId, colorId, sizeId uuid.UUID, price float64) (bool, error) {
+// UpdatePrice closes the current price of the variant and opens a new one with the same product, color and size
+func (p *PriceRepository) UpdatePrice(variantId uuid.UUID, price float64) (bool, error) {
 	tx, err := p.db.Begin()
 	if err != nil {
 		return false, err
@@ -127,10 +116,10 @@ func (p *PriceRepository) UpdatePrice(productId, colorId, sizeId uuid.UUID, pric
 		VALUES 
 		(
 			generate_uuid_v4(), 
-			$1,
-			(SELECT p.id FROM price p WHERE p.product_id = $2 AND p.color_id = $3 AND p.size_id = $4 AND p.is_active = true LIMIT 1),
-			(SELECT p.amount FROM price p WHERE p.product_id = $5 AND p.color_id = $6 AND p.size_id = $7 AND p.is_active = true LIMIT 1),
-			$8,
+			(SELECT p.product_id FROM price p WHERE p.variant_id = $1 AND p.is_active = true LIMIT 1),
+			(SELECT p.id FROM price p WHERE p.variant_id = $1 AND p.is_active = true LIMIT 1),
+			(SELECT p.amount FROM price p WHERE p.variant_id = $1 AND p.is_active = true LIMIT 1),
+			$2,
 			'price updated'
 		)
 	`
@@ -140,7 +129,7 @@ func (p *PriceRepository) UpdatePrice(productId, colorId, sizeId uuid.UUID, pric
 		return false, err
 	}
 	defer priceHistoryStmt.Close()
-	_, err = priceHistoryStmt.Exec(uuid.New(), productId, colorId, sizeId, productId, colorId, sizeId, price)
+	_, err = priceHistoryStmt.Exec(variantId, price)
 	if err != nil {
 		tx.Rollback()
 		return false, err
@@ -150,23 +139,19 @@ func (p *PriceRepository) UpdatePrice(productId, colorId, sizeId uuid.UUID, pric
 		UPDATE price 
 		SET valid_to = NOW(),
 			is_active = false
-		WHERE product_id = $1 AND color_id = $2 AND size_id = $3 AND is_active = true
+		WHERE variant_id = $1 AND is_active = true
+		RETURNING product_id, color_id, size_id
 	`
-	priceUpdateStmt, err := tx.Prepare(priceUpdateQuery)
-	if err != nil {
-		tx.Rollback()
-		return false, err
-	}
-	defer priceUpdateStmt.Close()
-	_, err = priceUpdateStmt.Exec(productId, colorId, sizeId)
+	var productId, colorId, sizeId uuid.UUID
+	err = tx.QueryRow(priceUpdateQuery, variantId).Scan(&productId, &colorId, &sizeId)
 	if err != nil {
 		tx.Rollback()
 		return false, err
 	}
 
 	priceInsertQuery := `
-		INSERT INTO price (id, valid_from, amount, product_id, color_id, size_id, is_active, price_list_id)
-		VALUES (generate_uuid_v4(), $1, $2, $3, $4, $5, true, $6)
+		INSERT INTO price (id, valid_from, amount, variant_id, product_id, color_id, size_id, is_active, price_list_id)
+		VALUES (generate_uuid_v4(), $1, $2, $3, $4, $5, $6, true, $7)
 	`
 	priceInsertStmt, err := tx.Prepare(priceInsertQuery)
 	if err != nil {
@@ -174,7 +159,7 @@ func (p *PriceRepository) UpdatePrice(productId, colorId, sizeId uuid.UUID, pric
 		return false, err
 	}
 	defer priceInsertStmt.Close()
-	_, err = priceInsertStmt.Exec(util.GetCurrentUtcTime(7), price, productId, colorId, sizeId, p.GetDefaultPriceList().Id)
+	_, err = priceInsertStmt.Exec(util.GetCurrentUtcTime(7), price, variantId, productId, colorId, sizeId, p.GetDefaultPriceList().Id)
 	if err != nil {
 		tx.Rollback()
 		return false, err
@@ -189,8 +174,8 @@ func (p *PriceRepository) UpdatePrice(productId, colorId, sizeId uuid.UUID, pric
 
 func (p *PriceRepository) AddNewPrices(prices []*entity.Price) error {
 	query := `
-		INSERT INTO price (id, created_at, updated_at, amount, product_id, color_id, size_id, is_active, valid_from, price_list_id)	
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO price (id, created_at, updated_at, amount, variant_id, product_id, color_id, size_id, is_active, valid_from, price_list_id)	
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 	tx, err := p.db.Begin()
 	if err != nil {
@@ -203,7 +188,7 @@ func (p *PriceRepository) AddNewPrices(prices []*entity.Price) error {
 			tx.Rollback()
 			return err
 		}
-		if _, err = stmt.Exec(price.Id, price.CreatedAt, price.UpdatedAt, price.Amount, price.ProductId, price.ColorId, price.SizeId, price.IsActive, price.ValidFrom, priceList.Id); err != nil {
+		if _, err = stmt.Exec(price.Id, price.CreatedAt, price.UpdatedAt, price.Amount, price.VariantId, price.ProductId, price.ColorId, price.SizeId, price.IsActive, price.ValidFrom, priceList.Id); err != nil {
 			tx.Rollback()
 			return err
 		}
@@ -211,18 +196,18 @@ func (p *PriceRepository) AddNewPrices(prices []*entity.Price) error {
 	return tx.Commit()
 }
 
-func (p *PriceRepository) GetPrice(productId uuid.UUID, colorId uuid.UUID, sizeId uuid.UUID) (*entity.Price, error) {
+func (p *PriceRepository) GetPrice(variantId uuid.UUID) (*entity.Price, error) {
 	query := `
-		SELECT id, created_at, updated_at, amount, product_id, color_id, size_id, is_active, valid_from, valid_to, price_list_id
+		SELECT id, created_at, updated_at, amount, variant_id, product_id, color_id, size_id, is_active, valid_from, valid_to, price_list_id
 		FROM price
-		WHERE product_id = $1 AND color_id = $2 AND size_id = $3 AND is_active = true
+		WHERE variant_id = $1 AND is_active = true
 		LIMIT 1
 	`
-	row := p.db.QueryRow(query, productId, colorId, sizeId)
+	row := p.db.QueryRow(query, variantId)
 	var price entity.Price
 	var validTo sql.NullTime
 	var validFrom sql.NullTime
-	err := row.Scan(&price.Id, &price.CreatedAt, &price.UpdatedAt, &price.Amount, &price.ProductId, &price.ColorId, &price.SizeId, &price.IsActive, &validFrom, &validTo, &price.PriceListId)
+	err := row.Scan(&price.Id, &price.CreatedAt, &price.UpdatedAt, &price.Amount, &price.VariantId, &price.ProductId, &price.ColorId, &price.SizeId, &price.IsActive, &validFrom, &validTo, &price.PriceListId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -276,3 +261,78 @@ func (p *PriceRepository) GetCurrentPricesByProductIds(ids []uuid.UUID) ([]entit
 	}
 	return result, nil
 }
+
+// GetProductIdsByPriceRange matches products by their lowest current price, the one shown in the storefront
+func (p *PriceRepository) GetProductIdsByPriceRange(minPrice, maxPrice float64) ([]uuid.UUID, error) {
+	query := `
+		SELECT
+			p.product_id
+		FROM price p
+		INNER JOIN price_list pl ON p.price_list_id = pl.id
+		WHERE p.deleted_at IS NULL
+			AND p.is_active = true
+			AND pl.currency = 1
+			AND NOW() BETWEEN p.valid_from AND COALESCE(p.valid_to, 'infinity'::timestamptz)
+		GROUP BY p.product_id
+		HAVING MIN(p.amount) >= $1
+			AND ($2 = 0 OR MIN(p.amount) <= $2)
+	`
+	rows, err := p.db.Query(query, minPrice, maxPrice)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := []uuid.UUID{}
+	for rows.Next() {
+		var productId uuid.UUID
+		if scanErr := rows.Scan(&productId); scanErr != nil {
+			return nil, scanErr
+		}
+		result = append(result, productId)
+	}
+	return result, nil
+}
+
+// BackfillVariantIds links the prices made before variants existed to the variant the product service backfilled
+func (p *PriceRepository) BackfillVariantIds() (int, error) {
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	rows, err := tx.Query(`
+		SELECT p.id, p.product_id, p.color_id, p.size_id
+		FROM price p
+		WHERE p.variant_id IS NULL
+	`)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	prices := []entity.Price{}
+	for rows.Next() {
+		var price entity.Price
+		if err = rows.Scan(&price.Id, &price.ProductId, &price.ColorId, &price.SizeId); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return 0, err
+		}
+		prices = append(prices, price)
+	}
+	rows.Close()
+
+	query := `
+		UPDATE price
+		SET
+			variant_id = $1,
+			updated_at = $2
+		WHERE id = $3
+	`
+	current := util.GetCurrentUtcTime(7)
+	for _, price := range prices {
+		if _, err = tx.Exec(query, entity.LegacyVariantId(price.ProductId, price.ColorId, price.SizeId), current, price.Id); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+	return len(prices), tx.Commit()
+}
@@ -78,7 +78,7 @@ func (j *Job) UpdatePrice(ctx context.Context, rpcService rpc.RpcInterface, pric
 				j.logger.Error().Err(err).Msg("Error unmarshal data:")
 				return "{}"
 			}
-			_, updateError := priceService.UpdatePrice(updateReq.ProductId, updateReq.ColorId, updateReq.SizeId, updateReq.Price)
+			_, updateError := priceService.UpdatePrice(updateReq.VariantId, updateReq.Price)
 
 			updateRes := &rpcModel.UpdatePriceResponse{
 				false,
@@ -95,6 +95,30 @@ func (j *Job) UpdatePrice(ctx context.Context, rpcService rpc.RpcInterface, pric
 	}
 }
 
+func (j *Job) FilterProductsByPrice(rpcService rpc.RpcInterface, priceService usecase.Service) background.JobFunc {
+	return func() {
+		// Errors answer an empty string, "[]" would read as no product being in the price range
+		rpcService.NewRpcQueue("filter_products_by_price", func(data string) string {
+			var priceRangeReq rpcModel.PriceRangeRequest
+			if err := json.Unmarshal([]byte(data), &priceRangeReq); err != nil {
+				j.logger.Error().Err(err).Msg("Error unmarshal data:")
+				return ""
+			}
+			productIds, appErr := priceService.GetProductIdsByPriceRange(priceRangeReq)
+			if appErr != nil {
+				j.logger.Error().Msg(appErr.Message)
+				return ""
+			}
+			res, err := json.Marshal(productIds)
+			if err != nil {
+				j.logger.Error().Err(err).Msg("Error marshal data:")
+				return ""
+			}
+			return string(res)
+		})
+	}
+}
+
 func (j *Job) GetTotalPrice(rpcService rpc.RpcInterface, priceService usecase.Service) background.JobFunc {
 	return func() {
 		rpcService.NewRpcQueue("get_total_price", func(data string) string {
@@ -106,9 +130,10 @@ func (j *Job) GetTotalPrice(rpcService rpc.RpcInterface, priceService usecase.Se
 				return defaultResult
 			}
 			totalPrice, prices, getTotalPriceError := priceService.GetTotalPrice(getTotalPriceReq)
-			
 			if getTotalPriceError != nil {
-				return defaultResult
+				// An empty answer tells the order service the items couldn't be priced, "{}" would read as a free order
+				j.logger.Error().Msg(getTotalPriceError.Message)
+				return ""
 			}
 			res := rpcModel.From(totalPrice, prices, getTotalPriceReq.Items)
 			parseResult, parseJsonErr := json.Marshal(res)
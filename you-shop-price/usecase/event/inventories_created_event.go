@@ -3,6 +3,7 @@ package event
 import "github.com/google/uuid"
 
 type CreateInventory struct {
+	VariantId uuid.UUID
 	ProductId uuid.UUID
 	ColorId   uuid.UUID
 	SizeId    uuid.UUID
@@ -17,20 +17,25 @@ type Service interface {
 	GetCurrentPrices(productIds []string) (map[string]float64, *err.AppError)
 	CreateNewPrices(event.CreatedInventoriesEvent) ([]*entity.Price, *err.AppError)
 	CreateNewPriceList(description string, currency entity.Currency) (*entity.PriceList, *err.AppError)
-	UpdatePrice(productId, colorId, sizeId uuid.UUID, price float64) (bool, *err.AppError)
+	UpdatePrice(variantId uuid.UUID, price float64) (bool, *err.AppError)
 	GetTotalPrice(model.TotalPriceRequest) (float64, []entity.Price, *err.AppError)
+	GetProductIdsByPriceRange(model.PriceRangeRequest) ([]string, *err.AppError)
+	// BackfillVariantIds links the prices made before variants existed to their variant, it runs at startup
+	BackfillVariantIds() *err.AppError
 }
 
 type Reader interface {
 	GetCurrentPricesByProductIds([]uuid.UUID) ([]entity.Price, error)
 	GetCurrentPrices([]*model.OrderItem) ([]entity.Price, error)
 	GetDefaultPriceList() *entity.PriceList
-	GetPrice(productId uuid.UUID, colorId uuid.UUID, sizeId uuid.UUID) (*entity.Price, error)
+	GetPrice(variantId uuid.UUID) (*entity.Price, error)
+	GetProductIdsByPriceRange(minPrice, maxPrice float64) ([]uuid.UUID, error)
 }
 
 type Writer interface {
 	AddNewPrice(entity.Price) (*entity.Price, error)
 	AddNewPrices([]*entity.Price) error
 	AddNewPriceList(entity.PriceList) (*entity.PriceList, error)
-	UpdatePrice(productId, sizeId, colorId uuid.UUID, price float64) (bool, error)
+	UpdatePrice(variantId uuid.UUID, price float64) (bool, error)
+	BackfillVariantIds() (int, error)
 }
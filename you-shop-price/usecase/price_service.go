@@ -62,9 +62,9 @@ func (ps *PriceService) CreateNewPriceList(description string, currency entity.C
 	}
 	return createdPriceList, nil
 }
-func (p *PriceService) UpdatePrice(productId, colorId, sizeId uuid.UUID, price float64) (bool, *err.AppError) {
+func (p *PriceService) UpdatePrice(variantId uuid.UUID, price float64) (bool, *err.AppError) {
 	p.logger.Debug().Msg("Updating price")
-	priceEntity, getPriceErr := p.priceRepo.GetPrice(productId, colorId, sizeId)
+	priceEntity, getPriceErr := p.priceRepo.GetPrice(variantId)
 	if getPriceErr != nil {
 		return false, err.NewAppError(500, "getting price failed", "getting price failed", nil)
 	}
@@ -75,7 +75,7 @@ func (p *PriceService) UpdatePrice(productId, colorId, sizeId uuid.UUID, price f
 		p.logger.Debug().Msg("Price wasn't change")
 		return true, nil
 	}
-	ok, updateErr := p.priceRepo.UpdatePrice(productId, sizeId, colorId, price)
+	ok, updateErr := p.priceRepo.UpdatePrice(variantId, price)
 
 	if !ok || updateErr != nil {
 		if updateErr != nil {
@@ -84,7 +84,7 @@ func (p *PriceService) UpdatePrice(productId, colorId, sizeId uuid.UUID, price f
 		return false, err.NewAppError(500, "updated fail", "updated fail", nil)
 	}
 
-	p.logger.Info().Msgf("Update price of product's %s successfully", productId.String())
+	p.logger.Info().Msgf("Update price of variant's %s successfully", variantId.String())
 	return true, nil
 }
 
@@ -93,28 +93,48 @@ func (p *PriceService) GetTotalPrice(req model.TotalPriceRequest) (float64, []en
 	prices, getPriceErr := p.priceRepo.GetCurrentPrices(req.Items)
 	if getPriceErr != nil {
 		p.logger.Error().Err(getPriceErr).Msg("")
-		return 0, nil, nil
+		return 0, nil, err.NewAppError(500, "getting prices failed", "getting prices failed", nil)
 	}
 
 	var totalPrice float64 = 0
-	priceMap := make(map[string]float64, len(prices))
+	priceMap := make(map[uuid.UUID]float64, len(prices))
 
 	for _, price := range prices {
-		key := fmt.Sprintf("%s-%s-%s", price.ProductId, price.ColorId, price.SizeId)
-		priceMap[key] = price.Amount
+		priceMap[price.VariantId] = price.Amount
 	}
 
 	for _, item := range req.Items {
-		key := fmt.Sprintf("%s-%s-%s", item.ProductId, item.ColorId, item.SizeId)
-		totalPrice += priceMap[key] * float64(item.Quantity)
+		amount, ok := priceMap[item.VariantId]
+		if !ok {
+			// Pricing the item at zero would sell it for free
+			message := fmt.Sprintf("variant %s has no current price", item.VariantId)
+			return 0, nil, err.NewAppError(400, message, message, nil)
+		}
+		totalPrice += amount * float64(item.Quantity)
 	}
 	return totalPrice, prices, nil
 }
 
+func (p *PriceService) GetProductIdsByPriceRange(req model.PriceRangeRequest) ([]string, *err.AppError) {
+	if req.MinPrice < 0 || req.MaxPrice < 0 || (req.MaxPrice > 0 && req.MinPrice > req.MaxPrice) {
+		return nil, err.NewAppError(400, "invalid price range", "invalid price range", nil)
+	}
+	productIds, getErr := p.priceRepo.GetProductIdsByPriceRange(req.MinPrice, req.MaxPrice)
+	if getErr != nil {
+		p.logger.Error().Err(getErr).Msg("")
+		return nil, err.NewAppError(500, "getting products by price failed", "getting products by price failed", nil)
+	}
+	result := make([]string, 0, len(productIds))
+	for _, productId := range productIds {
+		result = append(result, productId.String())
+	}
+	return result, nil
+}
+
 func (p *PriceService) CreateNewPrices(event event.CreatedInventoriesEvent) ([]*entity.Price, *err.AppError) {
 	prices := make([]*entity.Price, 0, len(event.CreatedInventories))
 	for _, inventory := range event.CreatedInventories {
-		prices = append(prices, entity.NewPrice(inventory.Price, inventory.ProductId, inventory.ColorId, inventory.SizeId))
+		prices = append(prices, entity.NewPrice(inventory.Price, inventory.ProductId, inventory.ColorId, inventory.SizeId, inventory.VariantId))
 	}
 	if addErr := p.priceRepo.AddNewPrices(prices); addErr != nil {
 		p.logger.Error().Err(addErr).Msg("")
@@ -122,3 +142,15 @@ func (p *PriceService) CreateNewPrices(event event.CreatedInventoriesEvent) ([]*
 	}
 	return prices, nil
 }
+
+func (p *PriceService) BackfillVariantIds() *err.AppError {
+	backfilled, backfillErr := p.priceRepo.BackfillVariantIds()
+	if backfillErr != nil {
+		p.logger.Error().Err(backfillErr).Msg("")
+		return err.NewAppError(500, "backfilling variant ids failed", "backfilling variant ids failed", nil)
+	}
+	if backfilled > 0 {
+		p.logger.Info().Msgf("Linked %d legacy prices to their variants", backfilled)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package model
+
+type PriceRangeRequest struct {
+	MinPrice float64 `json:"minPrice"`
+	// Zero means there is no upper bound
+	MaxPrice float64 `json:"maxPrice"`
+}
@@ -7,8 +7,6 @@ type TotalPriceRequest struct {
 }
 
 type OrderItem struct {
-	ProductId uuid.UUID `json:"productId"`
-	SizeId    uuid.UUID `json:"sizeId"`
-	ColorId   uuid.UUID `json:"colorId"`
+	VariantId uuid.UUID `json:"variantId"`
 	Quantity  int       `json:"quantity"`
 }
@@ -1,8 +1,6 @@
 package model
 
 import (
-	"fmt"
-
 	"github.com/TechwizsonORG/price-service/entity"
 	"github.com/google/uuid"
 )
@@ -15,6 +13,7 @@ type TotalPriceResponse struct {
 type Item struct {
 	Amount    float64   `json:"amount"`
 	Quantity  int       `json:"quantity"`
+	VariantId uuid.UUID `json:"variantId"`
 	ProductId uuid.UUID `json:"productId"`
 	ColorId   uuid.UUID `json:"colorId"`
 	SizeId    uuid.UUID `json:"sizeId"`
@@ -22,21 +21,20 @@ type Item struct {
 }
 
 func From(totalPrice float64, prices []entity.Price, orderItems []*OrderItem) *TotalPriceResponse {
-	quantityMap := make(map[string]int, len(orderItems))
+	quantityMap := make(map[uuid.UUID]int, len(orderItems))
 	for _, item := range orderItems {
-		key := fmt.Sprintf("%s-%s-%s", item.ProductId, item.ColorId, item.SizeId)
-		quantityMap[key] = item.Quantity
+		quantityMap[item.VariantId] += item.Quantity
 	}
 	items := []*Item{}
 	for _, price := range prices {
-		key := fmt.Sprintf("%s-%s-%s", price.ProductId, price.ColorId, price.SizeId)
 		items = append(items, &Item{
 			Amount:    price.Amount,
+			VariantId: price.VariantId,
 			ProductId: price.ProductId,
 			ColorId:   price.ColorId,
 			SizeId:    price.SizeId,
 			PriceId:   price.Id,
-			Quantity:  quantityMap[key],
+			Quantity:  quantityMap[price.VariantId],
 		})
 	}
 	return &TotalPriceResponse{
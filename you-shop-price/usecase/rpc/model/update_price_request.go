@@ -3,9 +3,7 @@ package model
 import "github.com/google/uuid"
 
 type UpdatePriceRequest struct {
-	ProductId uuid.UUID
-	ColorId   uuid.UUID
-	SizeId    uuid.UUID
+	VariantId uuid.UUID
 	Price     float64
 }
 
@@ -9,3 +9,12 @@ const (
 	PAGE_QUERY      = "page"
 	PAGE_SIZE_QUERY = "page_size"
 )
+
+const (
+	SEARCH_QUERY    = "q"
+	COLOR_ID_QUERY  = "color_id"
+	SIZE_ID_QUERY   = "size_id"
+	STATUS_QUERY    = "status"
+	MIN_PRICE_QUERY = "min_price"
+	MAX_PRICE_QUERY = "max_price"
+)
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/TechwizsonORG/product-service/api/handler/utility"
+	"github.com/TechwizsonORG/product-service/api/middleware"
+	"github.com/TechwizsonORG/product-service/api/model"
+	categoryModel "github.com/TechwizsonORG/product-service/api/model/category"
+	productModel "github.com/TechwizsonORG/product-service/api/model/product"
+	configModel "github.com/TechwizsonORG/product-service/config/model"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/category"
+	"github.com/TechwizsonORG/product-service/usecase/rpc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type CategoryHandler struct {
+	categoryService   category.CategoryUseCase
+	rpcService        rpc.RpcInterface
+	rpcServerEndpoint configModel.RpcServerEndpoint
+	logger            zerolog.Logger
+}
+
+func NewCategoryHandler(logger zerolog.Logger, categoryService category.CategoryUseCase, rpcService rpc.RpcInterface, rpcServerEndpoint configModel.RpcServerEndpoint) *CategoryHandler {
+	logger = logger.With().Str("handler", "category").Logger()
+	return &CategoryHandler{
+		categoryService:   categoryService,
+		rpcService:        rpcService,
+		rpcServerEndpoint: rpcServerEndpoint,
+		logger:            logger,
+	}
+}
+
+func (ca *CategoryHandler) CategoryRoute(routeGroup *gin.RouterGroup) {
+	categoryRoute := routeGroup.Group("/categories")
+	categoryRoute.GET("", ca.getCategoryTree)
+	categoryRoute.GET("/:slug/products", ca.getCategoryProducts)
+	categoryRoute.POST("", middleware.PermissionMiddleware("product:write"), ca.addCategory)
+	categoryRoute.PUT("/:id", middleware.PermissionMiddleware("product:write"), ca.updateCategory)
+	categoryRoute.DELETE("/:id", middleware.PermissionMiddleware("product:write"), ca.deleteCategory)
+	categoryRoute.PUT("/:id/products/:productId", middleware.PermissionMiddleware("product:write"), ca.addCategoryProduct)
+	categoryRoute.DELETE("/:id/products/:productId", middleware.PermissionMiddleware("product:write"), ca.removeCategoryProduct)
+}
+
+// GetCategoryTree godoc
+//
+//	@Summary	Get the category tree
+//	@Tags		categories
+//	@Produce	json
+//	@Success	200	{object}	model.ApiResponse{data=[]categoryModel.CategoryTreeResponse}
+//	@Router		/categories [get]
+func (ca *CategoryHandler) getCategoryTree(c *gin.Context) {
+	c.JSON(http.StatusOK, model.SuccessResponse(categoryModel.FromCategoryNodes(ca.categoryService.GetCategoryTree())))
+}
+
+// GetCategoryProducts godoc
+//
+//	@Summary		Get products of a category
+//	@Description	Includes the products of the descendant categories
+//	@Tags			categories
+//	@Produce		json
+//	@Param			slug		path		string	true	"category slug. Eg: ao-thun"
+//	@Param			page		query		int		false	"page number. Default is 1"			Format(int)
+//	@Param			page_size	query		int		false	"page_size number. Default is 10"	Format(int)
+//	@Success		200			{object}	model.ApiResponse{data=model.PaginationResponse{items=[]productModel.Product}}
+//	@Failure		400			{object}	model.ApiResponse{data=err.ValidationError}
+//	@Failure		404			{object}	model.ApiResponse{data=err.ProductError}
+//	@Router			/categories/{slug}/products [get]
+func (ca *CategoryHandler) getCategoryProducts(c *gin.Context) {
+	isSuccess, validationErr := utility.PaginationValidator(c)
+	if !isSuccess {
+		c.Errors = append(c.Errors, &gin.Error{Err: validationErr})
+		return
+	}
+	page, pageSize := utility.GetPaginationQuery(c)
+	count, products, getErr := ca.categoryService.GetCategoryProducts(c.Param("slug"), page, pageSize)
+	if getErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: getErr})
+		return
+	}
+	results := []productModel.Product{}
+	for _, product := range products {
+		results = append(results, productModel.FromEntity(product))
+	}
+	attachPricesAndImages(ca.rpcService, ca.rpcServerEndpoint, ca.logger, results)
+	c.JSON(http.StatusOK, model.SuccessResponse(model.NewPaginationResponse(page, pageSize, count, results)))
+}
+
+// AddCategory godoc
+//
+//	@Summary	Add category
+//	@Tags		categories
+//	@Accept		json
+//	@Produce	json
+//	@Param		category	body		categoryModel.CategoryRequest	true	"category body"
+//	@Success	201			{object}	model.ApiResponse{data=categoryModel.CategoryResponse}
+//	@Failure	400,409		{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/categories [post]
+func (ca *CategoryHandler) addCategory(c *gin.Context) {
+	var categoryReq categoryModel.CategoryRequest
+	if bindErr := c.BindJSON(&categoryReq); bindErr != nil {
+		ca.logger.Error().Err(bindErr).Msg("")
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse request json", "couldn't parse request json", nil)})
+		return
+	}
+	addedCategory, addErr := ca.categoryService.AddCategory(categoryReq.Name, categoryReq.Slug, categoryReq.ParentId, categoryReq.SortOrder)
+	if addErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: addErr})
+		return
+	}
+	c.JSON(http.StatusCreated, model.NewApiResponse(http.StatusCreated, "Created", true, categoryModel.FromCategoryEntity(*addedCategory)))
+}
+
+// UpdateCategory godoc
+//
+//	@Summary	Update category
+//	@Tags		categories
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string							true	"category id"
+//	@Param		category	body		categoryModel.CategoryRequest	true	"category body"
+//	@Success	200			{object}	model.ApiResponse{data=categoryModel.CategoryResponse}
+//	@Failure	400,404,409	{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/categories/{id} [put]
+func (ca *CategoryHandler) updateCategory(c *gin.Context) {
+	categoryId, ok := ca.parseId(c, "id")
+	if !ok {
+		return
+	}
+	var categoryReq categoryModel.CategoryRequest
+	if bindErr := c.BindJSON(&categoryReq); bindErr != nil {
+		ca.logger.Error().Err(bindErr).Msg("")
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse request json", "couldn't parse request json", nil)})
+		return
+	}
+	updatedCategory, updateErr := ca.categoryService.UpdateCategory(categoryId, categoryReq.Name, categoryReq.Slug, categoryReq.ParentId, categoryReq.SortOrder)
+	if updateErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: updateErr})
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse(categoryModel.FromCategoryEntity(*updatedCategory)))
+}
+
+// DeleteCategory godoc
+//
+//	@Summary		Delete category
+//	@Description	Only a category without children can be deleted
+//	@Tags			categories
+//	@Param			id	path	string	true	"category id"
+//	@Success		204
+//	@Failure		404,409	{object}	model.ApiResponse{data=err.ProductError}
+//	@Router			/categories/{id} [delete]
+func (ca *CategoryHandler) deleteCategory(c *gin.Context) {
+	categoryId, ok := ca.parseId(c, "id")
+	if !ok {
+		return
+	}
+	if deleteErr := ca.categoryService.DeleteCategory(categoryId); deleteErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: deleteErr})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddCategoryProduct godoc
+//
+//	@Summary	Assign a product to a category
+//	@Tags		categories
+//	@Param		id			path	string	true	"category id"
+//	@Param		productId	path	string	true	"product id"
+//	@Success	204
+//	@Failure	404	{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/categories/{id}/products/{productId} [put]
+func (ca *CategoryHandler) addCategoryProduct(c *gin.Context) {
+	categoryId, ok := ca.parseId(c, "id")
+	if !ok {
+		return
+	}
+	productId, ok := ca.parseId(c, "productId")
+	if !ok {
+		return
+	}
+	if addErr := ca.categoryService.AddProduct(categoryId, productId); addErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: addErr})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveCategoryProduct godoc
+//
+//	@Summary	Remove a product from a category
+//	@Tags		categories
+//	@Param		id			path	string	true	"category id"
+//	@Param		productId	path	string	true	"product id"
+//	@Success	204
+//	@Failure	404	{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/categories/{id}/products/{productId} [delete]
+func (ca *CategoryHandler) removeCategoryProduct(c *gin.Context) {
+	categoryId, ok := ca.parseId(c, "id")
+	if !ok {
+		return
+	}
+	productId, ok := ca.parseId(c, "productId")
+	if !ok {
+		return
+	}
+	if removeErr := ca.categoryService.RemoveProduct(categoryId, productId); removeErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: removeErr})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (ca *CategoryHandler) parseId(c *gin.Context, param string) (uuid.UUID, bool) {
+	id, parseErr := uuid.Parse(c.Param(param))
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse "+param, "couldn't parse "+param, nil)})
+		return uuid.Nil, false
+	}
+	return id, true
+}
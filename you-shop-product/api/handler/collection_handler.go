@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/TechwizsonORG/product-service/api/handler/utility"
+	"github.com/TechwizsonORG/product-service/api/middleware"
+	"github.com/TechwizsonORG/product-service/api/model"
+	collectionModel "github.com/TechwizsonORG/product-service/api/model/collection"
+	productModel "github.com/TechwizsonORG/product-service/api/model/product"
+	configModel "github.com/TechwizsonORG/product-service/config/model"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/collection"
+	"github.com/TechwizsonORG/product-service/usecase/rpc"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type CollectionHandler struct {
+	collectionService collection.CollectionUseCase
+	rpcService        rpc.RpcInterface
+	rpcServerEndpoint configModel.RpcServerEndpoint
+	logger            zerolog.Logger
+}
+
+func NewCollectionHandler(logger zerolog.Logger, collectionService collection.CollectionUseCase, rpcService rpc.RpcInterface, rpcServerEndpoint configModel.RpcServerEndpoint) *CollectionHandler {
+	logger = logger.With().Str("handler", "collection").Logger()
+	return &CollectionHandler{
+		collectionService: collectionService,
+		rpcService:        rpcService,
+		rpcServerEndpoint: rpcServerEndpoint,
+		logger:            logger,
+	}
+}
+
+func (co *CollectionHandler) CollectionRoute(routeGroup *gin.RouterGroup) {
+	collectionRoute := routeGroup.Group("/collections")
+	collectionRoute.GET("", co.getCollections)
+	collectionRoute.GET("/:slug/products", co.getCollectionProducts)
+	collectionRoute.POST("", middleware.PermissionMiddleware("product:write"), co.addCollection)
+	collectionRoute.PUT("/:id", middleware.PermissionMiddleware("product:write"), co.updateCollection)
+	collectionRoute.DELETE("/:id", middleware.PermissionMiddleware("product:write"), co.deleteCollection)
+	collectionRoute.PUT("/:id/products", middleware.PermissionMiddleware("product:write"), co.setCollectionProducts)
+}
+
+// GetCollections godoc
+//
+//	@Summary	Get collections
+//	@Tags		collections
+//	@Produce	json
+//	@Success	200	{object}	model.ApiResponse{data=[]collectionModel.CollectionResponse}
+//	@Router		/collections [get]
+func (co *CollectionHandler) getCollections(c *gin.Context) {
+	collections := co.collectionService.GetCollections()
+	result := make([]collectionModel.CollectionResponse, 0, len(collections))
+	for _, collectionEntity := range collections {
+		result = append(result, *collectionModel.FromCollectionEntity(collectionEntity))
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse(result))
+}
+
+// GetCollectionProducts godoc
+//
+//	@Summary		Get products of a collection
+//	@Description	Products are returned in their curated order
+//	@Tags			collections
+//	@Produce		json
+//	@Param			slug		path		string	true	"collection slug. Eg: summer-sale"
+//	@Param			page		query		int		false	"page number. Default is 1"			Format(int)
+//	@Param			page_size	query		int		false	"page_size number. Default is 10"	Format(int)
+//	@Success		200			{object}	model.ApiResponse{data=model.PaginationResponse{items=[]productModel.Product}}
+//	@Failure		400			{object}	model.ApiResponse{data=err.ValidationError}
+//	@Failure		404			{object}	model.ApiResponse{data=err.ProductError}
+//	@Router			/collections/{slug}/products [get]
+func (co *CollectionHandler) getCollectionProducts(c *gin.Context) {
+	isSuccess, validationErr := utility.PaginationValidator(c)
+	if !isSuccess {
+		c.Errors = append(c.Errors, &gin.Error{Err: validationErr})
+		return
+	}
+	page, pageSize := utility.GetPaginationQuery(c)
+	count, products, getErr := co.collectionService.GetCollectionProducts(c.Param("slug"), page, pageSize)
+	if getErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: getErr})
+		return
+	}
+	results := []productModel.Product{}
+	for _, product := range products {
+		results = append(results, productModel.FromEntity(product))
+	}
+	attachPricesAndImages(co.rpcService, co.rpcServerEndpoint, co.logger, results)
+	c.JSON(http.StatusOK, model.SuccessResponse(model.NewPaginationResponse(page, pageSize, count, results)))
+}
+
+// AddCollection godoc
+//
+//	@Summary	Add collection
+//	@Tags		collections
+//	@Accept		json
+//	@Produce	json
+//	@Param		collection	body		collectionModel.CollectionRequest	true	"collection body"
+//	@Success	201			{object}	model.ApiResponse{data=collectionModel.CollectionResponse}
+//	@Failure	400,409		{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/collections [post]
+func (co *CollectionHandler) addCollection(c *gin.Context) {
+	var collectionReq collectionModel.CollectionRequest
+	if bindErr := c.BindJSON(&collectionReq); bindErr != nil {
+		co.logger.Error().Err(bindErr).Msg("")
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse request json", "couldn't parse request json", nil)})
+		return
+	}
+	addedCollection, addErr := co.collectionService.AddCollection(collectionReq.Name, collectionReq.Slug, collectionReq.Description)
+	if addErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: addErr})
+		return
+	}
+	c.JSON(http.StatusCreated, model.NewApiResponse(http.StatusCreated, "Created", true, collectionModel.FromCollectionEntity(*addedCollection)))
+}
+
+// UpdateCollection godoc
+//
+//	@Summary	Update collection
+//	@Tags		collections
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string								true	"collection id"
+//	@Param		collection	body		collectionModel.CollectionRequest	true	"collection body"
+//	@Success	200			{object}	model.ApiResponse{data=collectionModel.CollectionResponse}
+//	@Failure	400,404,409	{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/collections/{id} [put]
+func (co *CollectionHandler) updateCollection(c *gin.Context) {
+	collectionId, ok := co.parseId(c)
+	if !ok {
+		return
+	}
+	var collectionReq collectionModel.CollectionRequest
+	if bindErr := c.BindJSON(&collectionReq); bindErr != nil {
+		co.logger.Error().Err(bindErr).Msg("")
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse request json", "couldn't parse request json", nil)})
+		return
+	}
+	updatedCollection, updateErr := co.collectionService.UpdateCollection(collectionId, collectionReq.Name, collectionReq.Slug, collectionReq.Description)
+	if updateErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: updateErr})
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse(collectionModel.FromCollectionEntity(*updatedCollection)))
+}
+
+// DeleteCollection godoc
+//
+//	@Summary	Delete collection
+//	@Tags		collections
+//	@Param		id	path	string	true	"collection id"
+//	@Success	204
+//	@Failure	404	{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/collections/{id} [delete]
+func (co *CollectionHandler) deleteCollection(c *gin.Context) {
+	collectionId, ok := co.parseId(c)
+	if !ok {
+		return
+	}
+	if deleteErr := co.collectionService.DeleteCollection(collectionId); deleteErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: deleteErr})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// SetCollectionProducts godoc
+//
+//	@Summary		Set products of a collection
+//	@Description	Replaces the products of the collection, the given order is the display order
+//	@Tags			collections
+//	@Accept			json
+//	@Param			id			path	string											true	"collection id"
+//	@Param			products	body	collectionModel.SetCollectionProductsRequest	true	"product ids"
+//	@Success		204
+//	@Failure		400,404	{object}	model.ApiResponse{data=err.ProductError}
+//	@Router			/collections/{id}/products [put]
+func (co *CollectionHandler) setCollectionProducts(c *gin.Context) {
+	collectionId, ok := co.parseId(c)
+	if !ok {
+		return
+	}
+	var setProductsReq collectionModel.SetCollectionProductsRequest
+	if bindErr := c.BindJSON(&setProductsReq); bindErr != nil {
+		co.logger.Error().Err(bindErr).Msg("")
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse request json", "couldn't parse request json", nil)})
+		return
+	}
+	if setErr := co.collectionService.SetProducts(collectionId, setProductsReq.ProductIds); setErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: setErr})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (co *CollectionHandler) parseId(c *gin.Context) (uuid.UUID, bool) {
+	id, parseErr := uuid.Parse(c.Param("id"))
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse collection id", "couldn't parse collection id", nil)})
+		return uuid.Nil, false
+	}
+	return id, true
+}
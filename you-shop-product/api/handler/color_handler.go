@@ -28,7 +28,7 @@ func (c *ColorHandler) ColorRoute(routeGroup *gin.RouterGroup) {
 	colorRoute := routeGroup.Group("/colors")
 	colorRoute.GET("", middleware.AuthorizationMiddleware([]string{"admin"}, nil), c.getColor)
 	colorRoute.GET(":id", middleware.AuthorizationMiddleware([]string{"admin"}, nil), c.getColorById)
-	colorRoute.POST("", middleware.AuthorizationMiddleware([]string{"admin"}, nil), c.addColor)
+	colorRoute.POST("", middleware.PermissionMiddleware("product:write"), c.addColor)
 }
 
 func (color *ColorHandler) getColorById(c *gin.Context) {
@@ -48,12 +48,14 @@ func (p *ProductHandler) ProductRoutes(router *gin.RouterGroup) {
 
 	productGroup.GET("", p.getProducts)
 	productGroup.GET("/quantity", p.getQuantity)
+	productGroup.GET("/search", p.searchProducts)
 	productGroup.GET(":id", p.getProduct)
-	productGroup.POST("", middleware.AuthorizationMiddleware([]string{"admin"}, nil), p.addProduct)
-	productGroup.POST("/:id/color", middleware.AuthorizationMiddleware([]string{"admin"}, nil), p.uploadProductColorImage)
-	productGroup.POST("/:id/inventory", middleware.AuthorizationMiddleware([]string{"admin"}, nil), p.addProductInventory)
-	productGroup.PUT(":id", middleware.AuthorizationMiddleware([]string{"admin"}, nil), p.updateProduct)
-	productGroup.PUT("/:id/inventory", middleware.AuthorizationMiddleware([]string{"admin"}, nil), p.updateProductInventory)
+	productGroup.POST("", middleware.PermissionMiddleware("product:write"), p.addProduct)
+	productGroup.POST("/:id/color", middleware.PermissionMiddleware("product:write"), p.uploadProductColorImage)
+	productGroup.POST("/:id/inventory", middleware.PermissionMiddleware("product:write"), p.addProductInventory)
+	productGroup.PUT(":id", middleware.PermissionMiddleware("product:write"), p.updateProduct)
+	productGroup.PUT("/:id/inventory", middleware.PermissionMiddleware("product:write"), p.updateProductInventory)
+	productGroup.POST("/inventory/adjustments", middleware.PermissionMiddleware("product:write"), p.adjustInventories)
 }
 
 // GetProduct godoc
@@ -77,11 +79,65 @@ func (p *ProductHandler) getProducts(c *gin.Context) {
 	count, products := p.productService.GetProducts(page, pageSize)
 
 	results := []productModel.Product{}
-	Ids := make([]string, len(results))
 	for _, product := range products {
-		productModel := productModel.FromEntity(product)
-		results = append(results, productModel)
-		Ids = append(Ids, productModel.Id.String())
+		results = append(results, productModel.FromEntity(product))
+	}
+	attachPricesAndImages(p.rpcService, p.rpcServerEndpoint, p.logger, results)
+
+	c.JSON(http.StatusOK, model.SuccessResponse(model.NewPaginationResponse(page, pageSize, count, results)))
+}
+
+// SearchProducts godoc
+//
+//	@Summary		Search products
+//	@Description	Full text search over name, description and sku, ranked by relevance and accent insensitive. Facets count the matching products per color and size
+//	@Tags			products
+//	@Produce		json
+//	@Param			q			query		string											false	"search text, Eg: áo thun"
+//	@Param			color_id	query		[]string										false	"color ids, repeated or comma separated"	collectionFormat(multi)
+//	@Param			size_id		query		[]string										false	"size ids, repeated or comma separated"		collectionFormat(multi)
+//	@Param			status		query		int												false	"1 is active, 2 is inactive. Default is 1"
+//	@Param			min_price	query		number											false	"lowest listed price"
+//	@Param			max_price	query		number											false	"highest listed price"
+//	@Param			page		query		int												false	"page number. Default is 1"			Format(int)
+//	@Param			page_size	query		int												false	"page_size number. Default is 10"	Format(int)
+//	@Success		200			{object}	model.ApiResponse{data=productModel.SearchProductResponse}
+//	@Failure		400			{object}	model.ApiResponse{data=appErr.ValidationError}
+//	@Failure		500			{object}	model.ApiResponse
+//	@Router			/products/search [get]
+func (p *ProductHandler) searchProducts(c *gin.Context) {
+	isSuccess, validationErr := utility.PaginationValidator(c)
+	if !isSuccess {
+		c.Errors = append(c.Errors, &gin.Error{Err: validationErr})
+		return
+	}
+	filter, isSuccess, validationErr := utility.GetSearchFilter(c)
+	if !isSuccess {
+		c.Errors = append(c.Errors, &gin.Error{Err: validationErr})
+		return
+	}
+
+	page, pageSize := utility.GetPaginationQuery(c)
+	searchResult, searchErr := p.productService.SearchProducts(filter, page, pageSize)
+	if searchErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: searchErr})
+		return
+	}
+
+	results := []productModel.Product{}
+	for _, product := range searchResult.Products {
+		results = append(results, productModel.FromEntity(product))
+	}
+	attachPricesAndImages(p.rpcService, p.rpcServerEndpoint, p.logger, results)
+
+	c.JSON(http.StatusOK, model.SuccessResponse(productModel.NewSearchProductResponse(page, pageSize, *searchResult, results)))
+}
+
+// attachPricesAndImages fills the prices and images of the products from the price and image services
+func attachPricesAndImages(rpcService rpc.RpcInterface, rpcServerEndpoint configModel.RpcServerEndpoint, logger zerolog.Logger, results []productModel.Product) {
+	Ids := make([]string, 0, len(results))
+	for _, result := range results {
+		Ids = append(Ids, result.Id.String())
 	}
 	jsonReq, _ := json.Marshal(Ids)
 
@@ -90,16 +146,16 @@ func (p *ProductHandler) getProducts(c *gin.Context) {
 	// ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	// defer cancel()
 	go func() {
-		priceJsonRes <- p.rpcService.Req(p.rpcServerEndpoint.ProductsPrice, string(jsonReq))
+		priceJsonRes <- rpcService.Req(rpcServerEndpoint.ProductsPrice, string(jsonReq))
 	}()
 
 	go func() {
-		imageJsonRes <- p.rpcService.Req(p.rpcServerEndpoint.GetImageByIds, string(jsonReq))
+		imageJsonRes <- rpcService.Req(rpcServerEndpoint.GetImageByIds, string(jsonReq))
 	}()
 
 	var pricesMap map[string]float64
 	if err := json.Unmarshal([]byte(<-priceJsonRes), &pricesMap); err != nil {
-		p.logger.Error().Err(err).Msg("Couldn't unmarshal prices")
+		logger.Error().Err(err).Msg("Couldn't unmarshal prices")
 	} else {
 		for i := range results {
 			results[i].Price = pricesMap[results[i].Id.String()]
@@ -108,14 +164,12 @@ func (p *ProductHandler) getProducts(c *gin.Context) {
 
 	var imagesMap map[string][]string
 	if unmarshalErr := json.Unmarshal([]byte(<-imageJsonRes), &imagesMap); unmarshalErr != nil {
-		p.logger.Error().Err(unmarshalErr).Msg("Couldn't unmarshal prices")
+		logger.Error().Err(unmarshalErr).Msg("Couldn't unmarshal prices")
 	} else {
 		for i := range results {
 			results[i].Images = imagesMap[results[i].Id.String()]
 		}
 	}
-
-	c.JSON(http.StatusOK, model.SuccessResponse(model.NewPaginationResponse(page, pageSize, count, results)))
 }
 
 // GetProduct godoc
@@ -237,23 +291,13 @@ func (p *ProductHandler) updateProduct(c *gin.Context) {
 }
 
 func (p *ProductHandler) getQuantity(c *gin.Context) {
-	productId, uuidParseErr := uuid.Parse(c.Query("productId"))
-	if uuidParseErr != nil {
-		c.Errors = append(c.Errors, &gin.Error{Err: appErr.NewProductError(400, "couldn't parse product id", "couldn't parse product id", nil)})
-		return
-	}
-	sizeId, uuidParseErr := uuid.Parse(c.Query("sizeId"))
-	if uuidParseErr != nil {
-		c.Errors = append(c.Errors, &gin.Error{Err: appErr.NewProductError(400, "couldn't parse size id", "couldn't parse size id", nil)})
-		return
-	}
-	colorId, uuidParseErr := uuid.Parse(c.Query("colorId"))
+	variantId, uuidParseErr := uuid.Parse(c.Query("variantId"))
 	if uuidParseErr != nil {
-		c.Errors = append(c.Errors, &gin.Error{Err: appErr.NewProductError(400, "couldn't parse color id", "couldn't parse color id", nil)})
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr.NewProductError(400, "couldn't parse variant id", "couldn't parse variant id", nil)})
 		return
 	}
 
-	quantity, getErr := p.productService.GetQuantity(productId, sizeId, colorId)
+	quantity, getErr := p.productService.GetQuantity(variantId)
 	if getErr != nil {
 		c.Errors = append(c.Errors, &gin.Error{Err: getErr})
 		return
@@ -310,6 +354,9 @@ func (p *ProductHandler) addProductInventory(c *gin.Context) {
 			ProductId: productId,
 			ColorId:   inventory.ColorId,
 			SizeId:    inventory.SizeId,
+			Sku:       inventory.Sku,
+			Barcode:   inventory.Barcode,
+			Weight:    inventory.Weight,
 			Price:     inventory.Price,
 			Quantity:  inventory.Quantity,
 		})
@@ -333,9 +380,31 @@ func (p *ProductHandler) updateProductInventory(c *gin.Context) {
 		c.Errors = append(c.Errors, &gin.Error{Err: appErr.NewProductError(400, "couldn't parse body", "couldn't parse body", nil)})
 		return
 	}
-	updateErr := p.inventoryService.UpdateInventory(productId, updateProductInventory.ColorId, updateProductInventory.SizeId, updateProductInventory.Quantity, updateProductInventory.Price)
+	updateErr := p.inventoryService.UpdateInventory(productId, updateProductInventory.VariantId, updateProductInventory.Quantity, updateProductInventory.Price)
 	if updateErr != nil {
 		c.Errors = append(c.Errors, &gin.Error{Err: updateErr})
 		return
 	}
 }
+
+func (p *ProductHandler) adjustInventories(c *gin.Context) {
+	var adjustInventory productModel.AdjustInventoryRequest
+	bindErr := c.BindJSON(&adjustInventory)
+	if bindErr != nil {
+		p.logger.Error().Err(bindErr).Msg("")
+		c.Errors = append(c.Errors, &gin.Error{Err: appErr.NewProductError(400, "couldn't parse body", "couldn't parse body", nil)})
+		return
+	}
+	adjustments := make([]inventoryModel.QuantityAdjustment, 0, len(adjustInventory.Adjustments))
+	for _, adjustment := range adjustInventory.Adjustments {
+		adjustments = append(adjustments, inventoryModel.QuantityAdjustment{
+			VariantId:    adjustment.VariantId,
+			ChangeAmount: adjustment.ChangeAmount,
+		})
+	}
+	if adjustErr := p.inventoryService.AdjustQuantities(adjustments); adjustErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: adjustErr})
+		return
+	}
+	c.JSON(200, model.SuccessResponse("Inventories adjusted"))
+}
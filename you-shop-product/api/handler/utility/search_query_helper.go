@@ -0,0 +1,96 @@
+package utility
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/TechwizsonORG/product-service/api/constant"
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/product/model"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetSearchFilter reads the product search filter from the query.
+//
+// Color and size ids can be repeated or comma separated, e.g: color_id=a,b&color_id=c
+func GetSearchFilter(c *gin.Context) (model.ProductSearchFilter, bool, err.ValidationError) {
+	filter := model.ProductSearchFilter{
+		Query: c.Query(constant.SEARCH_QUERY),
+		// The search is public, inactive products only show up when asked for
+		Status: entity.Active,
+	}
+	fields := []err.ValidationErrorField{}
+
+	colorIds, ok := getUuidsQuery(c, constant.COLOR_ID_QUERY)
+	if !ok {
+		fields = append(fields, err.ValidationErrorField{Field: constant.COLOR_ID_QUERY, Message: "Color id must be an uuid"})
+	}
+	filter.ColorIds = colorIds
+
+	sizeIds, ok := getUuidsQuery(c, constant.SIZE_ID_QUERY)
+	if !ok {
+		fields = append(fields, err.ValidationErrorField{Field: constant.SIZE_ID_QUERY, Message: "Size id must be an uuid"})
+	}
+	filter.SizeIds = sizeIds
+
+	if status := c.Query(constant.STATUS_QUERY); status != "" {
+		statusInt, atoiErr := strconv.Atoi(status)
+		if atoiErr != nil || (entity.ProductStatus(statusInt) != entity.Active && entity.ProductStatus(statusInt) != entity.Inactive) {
+			fields = append(fields, err.ValidationErrorField{Field: constant.STATUS_QUERY, Message: "Status must be 1 (active) or 2 (inactive)"})
+		}
+		filter.Status = entity.ProductStatus(statusInt)
+	}
+
+	minPrice, ok := getPriceQuery(c, constant.MIN_PRICE_QUERY)
+	if !ok {
+		fields = append(fields, err.ValidationErrorField{Field: constant.MIN_PRICE_QUERY, Message: "Min price must be a non negative number"})
+	}
+	filter.MinPrice = minPrice
+
+	maxPrice, ok := getPriceQuery(c, constant.MAX_PRICE_QUERY)
+	if !ok {
+		fields = append(fields, err.ValidationErrorField{Field: constant.MAX_PRICE_QUERY, Message: "Max price must be a non negative number"})
+	}
+	filter.MaxPrice = maxPrice
+
+	if filter.MaxPrice > 0 && filter.MinPrice > filter.MaxPrice {
+		fields = append(fields, err.ValidationErrorField{Field: constant.MAX_PRICE_QUERY, Message: "Max price must not be less than min price"})
+	}
+
+	if len(fields) > 0 {
+		return filter, false, err.NewValidationError("Invalid search query", "Invalid search query", fields)
+	}
+	return filter, true, err.ValidationError{}
+}
+
+// getUuidsQuery returns nil when the query is absent
+func getUuidsQuery(c *gin.Context, key string) ([]uuid.UUID, bool) {
+	var ids []uuid.UUID
+	for _, value := range c.QueryArray(key) {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			id, parseErr := uuid.Parse(part)
+			if parseErr != nil {
+				return nil, false
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, true
+}
+
+func getPriceQuery(c *gin.Context, key string) (float64, bool) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, true
+	}
+	price, parseErr := strconv.ParseFloat(value, 64)
+	if parseErr != nil || price < 0 {
+		return 0, false
+	}
+	return price, true
+}
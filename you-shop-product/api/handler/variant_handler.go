@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/TechwizsonORG/product-service/api/middleware"
+	"github.com/TechwizsonORG/product-service/api/model"
+	variantModel "github.com/TechwizsonORG/product-service/api/model/variant"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/variant"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type VariantHandler struct {
+	variantService variant.VariantUseCase
+	logger         zerolog.Logger
+}
+
+func NewVariantHandler(logger zerolog.Logger, variantService variant.VariantUseCase) *VariantHandler {
+	logger = logger.With().Str("handler", "variant").Logger()
+	return &VariantHandler{
+		variantService: variantService,
+		logger:         logger,
+	}
+}
+
+func (v *VariantHandler) VariantRoute(routeGroup *gin.RouterGroup) {
+	routeGroup.GET("/products/variants/barcode/:barcode", v.getVariantByBarcode)
+	variantRoute := routeGroup.Group("/products/:id/variants")
+	variantRoute.GET("", v.getVariants)
+	variantRoute.GET("/:variantId", v.getVariant)
+	variantRoute.POST("", middleware.PermissionMiddleware("product:write"), v.addVariant)
+	variantRoute.PUT("/:variantId", middleware.PermissionMiddleware("product:write"), v.updateVariant)
+	variantRoute.DELETE("/:variantId", middleware.PermissionMiddleware("product:write"), v.deleteVariant)
+}
+
+// GetVariants godoc
+//
+//	@Summary	Get variants of a product
+//	@Tags		variants
+//	@Produce	json
+//	@Param		id	path		string	true	"product id"
+//	@Success	200	{object}	model.ApiResponse{data=[]variantModel.VariantResponse}
+//	@Failure	400	{object}	model.ApiResponse{data=err.ProductError}
+//	@Failure	404	{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/products/{id}/variants [get]
+func (v *VariantHandler) getVariants(c *gin.Context) {
+	productId, ok := v.parseId(c, "id", "product id")
+	if !ok {
+		return
+	}
+	variants, getErr := v.variantService.GetVariants(productId)
+	if getErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: getErr})
+		return
+	}
+	result := make([]variantModel.VariantResponse, 0, len(variants))
+	for _, variantEntity := range variants {
+		result = append(result, *variantModel.FromVariantEntity(variantEntity))
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse(result))
+}
+
+// GetVariant godoc
+//
+//	@Summary	Get variant
+//	@Tags		variants
+//	@Produce	json
+//	@Param		id			path		string	true	"product id"
+//	@Param		variantId	path		string	true	"variant id"
+//	@Success	200			{object}	model.ApiResponse{data=variantModel.VariantResponse}
+//	@Failure	400			{object}	model.ApiResponse{data=err.ProductError}
+//	@Failure	404			{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/products/{id}/variants/{variantId} [get]
+func (v *VariantHandler) getVariant(c *gin.Context) {
+	productId, variantId, ok := v.parseIds(c)
+	if !ok {
+		return
+	}
+	variantEntity, getErr := v.variantService.GetVariant(productId, variantId)
+	if getErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: getErr})
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse(variantModel.FromVariantEntity(*variantEntity)))
+}
+
+// GetVariantByBarcode godoc
+//
+//	@Summary	Get variant by barcode
+//	@Tags		variants
+//	@Produce	json
+//	@Param		barcode	path		string	true	"variant barcode. Eg: 8934563138165"
+//	@Success	200		{object}	model.ApiResponse{data=variantModel.VariantResponse}
+//	@Failure	404		{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/products/variants/barcode/{barcode} [get]
+func (v *VariantHandler) getVariantByBarcode(c *gin.Context) {
+	variantEntity, getErr := v.variantService.GetVariantByBarcode(c.Param("barcode"))
+	if getErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: getErr})
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse(variantModel.FromVariantEntity(*variantEntity)))
+}
+
+// AddVariant godoc
+//
+//	@Summary		Add variant
+//	@Description	Creates the variant along with its inventory and price
+//	@Tags			variants
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"product id"
+//	@Param			variant	body		variantModel.AddVariantRequest	true	"variant body"
+//	@Success		201		{object}	model.ApiResponse{data=variantModel.VariantResponse}
+//	@Failure		400,409	{object}	model.ApiResponse{data=err.ProductError}
+//	@Failure		404		{object}	model.ApiResponse{data=err.ProductError}
+//	@Router			/products/{id}/variants [post]
+func (v *VariantHandler) addVariant(c *gin.Context) {
+	productId, ok := v.parseId(c, "id", "product id")
+	if !ok {
+		return
+	}
+	var variantReq variantModel.AddVariantRequest
+	if bindErr := c.BindJSON(&variantReq); bindErr != nil {
+		v.logger.Error().Err(bindErr).Msg("")
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse request json", "couldn't parse request json", nil)})
+		return
+	}
+	addedVariant, addErr := v.variantService.AddVariant(productId, variantReq.ColorId, variantReq.SizeId, variantReq.Sku, variantReq.Barcode, variantReq.Weight, variantReq.Price, variantReq.Quantity)
+	if addErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: addErr})
+		return
+	}
+	c.JSON(http.StatusCreated, model.NewApiResponse(http.StatusCreated, "Created", true, variantModel.FromVariantEntity(*addedVariant)))
+}
+
+// UpdateVariant godoc
+//
+//	@Summary	Update variant
+//	@Tags		variants
+//	@Accept		json
+//	@Produce	json
+//	@Param		id			path		string								true	"product id"
+//	@Param		variantId	path		string								true	"variant id"
+//	@Param		variant		body		variantModel.UpdateVariantRequest	true	"variant body"
+//	@Success	200			{object}	model.ApiResponse{data=variantModel.VariantResponse}
+//	@Failure	400,409		{object}	model.ApiResponse{data=err.ProductError}
+//	@Failure	404			{object}	model.ApiResponse{data=err.ProductError}
+//	@Router		/products/{id}/variants/{variantId} [put]
+func (v *VariantHandler) updateVariant(c *gin.Context) {
+	productId, variantId, ok := v.parseIds(c)
+	if !ok {
+		return
+	}
+	var variantReq variantModel.UpdateVariantRequest
+	if bindErr := c.BindJSON(&variantReq); bindErr != nil {
+		v.logger.Error().Err(bindErr).Msg("")
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse request json", "couldn't parse request json", nil)})
+		return
+	}
+	isActive := variantReq.IsActive == nil || *variantReq.IsActive
+	updatedVariant, updateErr := v.variantService.UpdateVariant(productId, variantId, variantReq.Sku, variantReq.Barcode, variantReq.Weight, isActive)
+	if updateErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: updateErr})
+		return
+	}
+	c.JSON(http.StatusOK, model.SuccessResponse(variantModel.FromVariantEntity(*updatedVariant)))
+}
+
+// DeleteVariant godoc
+//
+//	@Summary		Delete variant
+//	@Description	The variant is kept for existing orders but can't be ordered anymore
+//	@Tags			variants
+//	@Param			id			path	string	true	"product id"
+//	@Param			variantId	path	string	true	"variant id"
+//	@Success		204
+//	@Failure		400	{object}	model.ApiResponse{data=err.ProductError}
+//	@Failure		404	{object}	model.ApiResponse{data=err.ProductError}
+//	@Router			/products/{id}/variants/{variantId} [delete]
+func (v *VariantHandler) deleteVariant(c *gin.Context) {
+	productId, variantId, ok := v.parseIds(c)
+	if !ok {
+		return
+	}
+	if deleteErr := v.variantService.DeleteVariant(productId, variantId); deleteErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: deleteErr})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (v *VariantHandler) parseIds(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	productId, ok := v.parseId(c, "id", "product id")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	variantId, ok := v.parseId(c, "variantId", "variant id")
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return productId, variantId, true
+}
+
+func (v *VariantHandler) parseId(c *gin.Context, param string, name string) (uuid.UUID, bool) {
+	id, parseErr := uuid.Parse(c.Param(param))
+	if parseErr != nil {
+		c.Errors = append(c.Errors, &gin.Error{Err: err.NewProductError(400, "couldn't parse "+name, "couldn't parse "+name, nil)})
+		return uuid.Nil, false
+	}
+	return id, true
+}
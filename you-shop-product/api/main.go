@@ -17,10 +17,14 @@ import (
 	"github.com/TechwizsonORG/product-service/infrastructure/repository"
 	rpcImpl "github.com/TechwizsonORG/product-service/infrastructure/rpc"
 	"github.com/TechwizsonORG/product-service/job"
+	"github.com/TechwizsonORG/product-service/usecase/category"
+	"github.com/TechwizsonORG/product-service/usecase/collection"
 	"github.com/TechwizsonORG/product-service/usecase/color"
 	"github.com/TechwizsonORG/product-service/usecase/inventory"
 	"github.com/TechwizsonORG/product-service/usecase/product"
+	"github.com/TechwizsonORG/product-service/usecase/reservation"
 	"github.com/TechwizsonORG/product-service/usecase/size"
+	"github.com/TechwizsonORG/product-service/usecase/variant"
 	"github.com/TechwizsonORG/product-service/util"
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
@@ -72,25 +76,42 @@ func main() {
 	inventoryRepo := repository.NewInventoryRepository(db, logger)
 	colorRepo := repository.NewColorRepository(db)
 	sizeRepo := repository.NewSizeRepository(db)
+	categoryRepo := repository.NewCategoryRepository(db)
+	collectionRepo := repository.NewCollectionRepository(db)
+	variantRepo := repository.NewVariantRepository(db)
+	reservationRepo := repository.NewReservationRepository(db)
 	msgQueue := rabbitmq.NewDefaultMessageQueue(*rabbitMqConfig, logger)
 	rpcService := rpcImpl.NewRpcService(*rabbitMqConfig, logger)
 
 	// service
-	productService := product.NewService(*httpEndpoint, productRepo, logger, msgQueue, rpcService, *rpcServerEndpoint, inventoryRepo)
+	productService := product.NewService(*httpEndpoint, productRepo, logger, msgQueue, rpcService, *rpcServerEndpoint, inventoryRepo, middleware.NewClientCredentialsClient(logger, *httpEndpoint))
 	inventoryService := inventory.NewInventoryService(logger, inventoryRepo, msgQueue, rpcService, *rpcServerEndpoint)
 	colorService := color.NewColorService(logger, colorRepo)
 	sizeSerivce := size.NewSizeService(sizeRepo, logger)
+	categoryService := category.NewCategoryService(logger, categoryRepo, productRepo)
+	collectionService := collection.NewCollectionService(logger, collectionRepo, productRepo)
+	variantService := variant.NewVariantService(logger, variantRepo, productRepo, inventoryService)
+	reservationService := reservation.NewReservationService(logger, reservationRepo, inventoryService)
+	if backfillErr := variantService.BackfillVariants(); backfillErr != nil {
+		logger.Error().Msg("Failed to backfill the variants of legacy inventories")
+	}
 
 	// handler
 	productHandler := handler.NewProductHandler(productService, rpcService, *rpcServerEndpoint, logger, inventoryService)
 	colorHandler := handler.NewColorHandler(logger, colorService)
 	sizeHandler := handler.NewSizeHandler(sizeSerivce, logger)
+	categoryHandler := handler.NewCategoryHandler(logger, categoryService, rpcService, *rpcServerEndpoint)
+	collectionHandler := handler.NewCollectionHandler(logger, collectionService, rpcService, *rpcServerEndpoint)
+	variantHandler := handler.NewVariantHandler(logger, variantService)
 
 	// job
 	job := job.NewJob(logger)
 	background.Go(logger, job.CheckProductQuantity(*rpcService, productService))
 	background.Go(logger, job.GetProductByIds(*rpcService, productService))
-	background.Go(logger, job.OrderUpdatedHandler(msgQueue, inventoryService))
+	background.Go(logger, job.ReserveInventory(*rpcService, reservationService))
+	background.Go(logger, job.ReleaseInventory(*rpcService, reservationService))
+	background.Go(logger, job.ReleaseExpiredReservations(reservationService, time.Minute))
+	background.Go(logger, job.OrderUpdatedHandler(msgQueue, inventoryService, reservationService))
 
 	// gin
 	gin.SetMode(mode)
@@ -121,6 +142,9 @@ func main() {
 	productHandler.ProductRoutes(v1)
 	colorHandler.ColorRoute(v1)
 	sizeHandler.SizeRoute(v1)
+	categoryHandler.CategoryRoute(v1)
+	collectionHandler.CollectionRoute(v1)
+	variantHandler.VariantRoute(v1)
 
 	logger.Info().Msg("Application is running")
 	router.Run(fmt.Sprintf("%s:%d", srvConfig.Host, srvConfig.Port))
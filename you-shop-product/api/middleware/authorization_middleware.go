@@ -43,3 +43,23 @@ func AuthorizationMiddleware(requireRole, requireScope []string) gin.HandlerFunc
 		c.Next()
 	}
 }
+
+// PermissionMiddleware requires every given permission, they are the scopes granted to the user
+// directly or through their roles, e.g. product:write
+func PermissionMiddleware(requirePermission ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userId := c.GetHeader("userId"); strings.Compare("", userId) == 0 {
+			c.JSON(401, model.NewApiResponse(401, "Unauthorized", false, nil))
+			c.Abort()
+			return
+		}
+
+		userPermissionArray := strings.Split(c.Request.Header.Get("scope"), ",")
+		if !util.ContainsAll(requirePermission, userPermissionArray) {
+			c.JSON(403, model.NewApiResponse(403, "Not allowed", false, nil))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,12 @@
+package category
+
+import "github.com/google/uuid"
+
+type CategoryRequest struct {
+	Name string `json:"name"`
+	// Generated from the name when empty, Eg: ao-thun
+	Slug string `json:"slug"`
+	// Empty for a root category
+	ParentId  uuid.UUID `json:"parent_id"`
+	SortOrder int       `json:"sort_order"`
+}
@@ -0,0 +1,50 @@
+package category
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/usecase/category/model"
+	"github.com/google/uuid"
+)
+
+type CategoryResponse struct {
+	Id        uuid.UUID  `json:"id"`
+	ParentId  *uuid.UUID `json:"parent_id"`
+	Name      string     `json:"name"`
+	Slug      string     `json:"slug"`
+	SortOrder int        `json:"sort_order"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type CategoryTreeResponse struct {
+	CategoryResponse
+	Children []CategoryTreeResponse `json:"children"`
+}
+
+func FromCategoryEntity(category entity.Category) *CategoryResponse {
+	response := &CategoryResponse{
+		Id:        category.Id,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		SortOrder: category.SortOrder,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+	if !category.IsRoot() {
+		response.ParentId = &category.ParentId
+	}
+	return response
+}
+
+func FromCategoryNodes(nodes []*model.CategoryNode) []CategoryTreeResponse {
+	result := make([]CategoryTreeResponse, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, CategoryTreeResponse{
+			CategoryResponse: *FromCategoryEntity(node.Category),
+			Children:         FromCategoryNodes(node.Children),
+		})
+	}
+	return result
+}
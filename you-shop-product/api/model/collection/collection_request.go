@@ -0,0 +1,15 @@
+package collection
+
+import "github.com/google/uuid"
+
+type CollectionRequest struct {
+	Name string `json:"name"`
+	// Generated from the name when empty, Eg: summer-sale
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+}
+
+type SetCollectionProductsRequest struct {
+	// In display order
+	ProductIds []uuid.UUID `json:"product_ids"`
+}
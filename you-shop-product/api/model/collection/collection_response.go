@@ -0,0 +1,28 @@
+package collection
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/google/uuid"
+)
+
+type CollectionResponse struct {
+	Id          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func FromCollectionEntity(collection entity.Collection) *CollectionResponse {
+	return &CollectionResponse{
+		Id:          collection.Id,
+		Name:        collection.Name,
+		Slug:        collection.Slug,
+		Description: collection.Description,
+		CreatedAt:   collection.CreatedAt,
+		UpdatedAt:   collection.UpdatedAt,
+	}
+}
@@ -0,0 +1,14 @@
+package product
+
+import "github.com/google/uuid"
+
+// AdjustInventoryRequest applies all adjustments in one transaction, none is applied when a quantity would become negative
+type AdjustInventoryRequest struct {
+	Adjustments []InventoryAdjustment
+}
+
+type InventoryAdjustment struct {
+	VariantId uuid.UUID
+	// A negative amount takes stock out
+	ChangeAmount int
+}
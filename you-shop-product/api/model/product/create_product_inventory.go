@@ -6,9 +6,13 @@ type CreateProductInventory struct {
 	Inventories []Inventory
 }
 
+// Inventory creates a variant of the product with its stock
 type Inventory struct {
 	SizeId   uuid.UUID
 	ColorId  uuid.UUID
+	Sku      string
+	Barcode  string
+	Weight   float64
 	Price    float64
 	Quantity int
 }
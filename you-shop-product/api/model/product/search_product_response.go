@@ -0,0 +1,45 @@
+package product
+
+import (
+	"github.com/TechwizsonORG/product-service/api/model"
+	productModel "github.com/TechwizsonORG/product-service/usecase/product/model"
+	"github.com/google/uuid"
+)
+
+type SearchProductResponse struct {
+	*model.PaginationResponse
+	Facets SearchFacets `json:"facets"`
+}
+
+type SearchFacets struct {
+	Colors []Facet `json:"colors"`
+	Sizes  []Facet `json:"sizes"`
+}
+
+type Facet struct {
+	Id    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Count int       `json:"count"`
+}
+
+func NewSearchProductResponse(page, pageSize int, result productModel.ProductSearchResult, items []Product) *SearchProductResponse {
+	return &SearchProductResponse{
+		PaginationResponse: model.NewPaginationResponse(page, pageSize, result.Total, items),
+		Facets: SearchFacets{
+			Colors: fromFacets(result.ColorFacets),
+			Sizes:  fromFacets(result.SizeFacets),
+		},
+	}
+}
+
+func fromFacets(facets []productModel.Facet) []Facet {
+	result := make([]Facet, 0, len(facets))
+	for _, facet := range facets {
+		result = append(result, Facet{
+			Id:    facet.Id,
+			Name:  facet.Name,
+			Count: facet.Count,
+		})
+	}
+	return result
+}
@@ -0,0 +1,23 @@
+package variant
+
+import "github.com/google/uuid"
+
+type AddVariantRequest struct {
+	ColorId uuid.UUID `json:"colorId"`
+	SizeId  uuid.UUID `json:"sizeId"`
+	// Optional, unique across variants
+	Sku string `json:"sku"`
+	// Optional, unique across variants. Eg: 8934563138165
+	Barcode  string  `json:"barcode"`
+	Weight   float64 `json:"weight"`
+	Price    float64 `json:"price"`
+	Quantity int     `json:"quantity"`
+}
+
+type UpdateVariantRequest struct {
+	Sku     string  `json:"sku"`
+	Barcode string  `json:"barcode"`
+	Weight  float64 `json:"weight"`
+	// Inactive variants can't be ordered, default is true
+	IsActive *bool `json:"isActive"`
+}
@@ -0,0 +1,36 @@
+package variant
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/google/uuid"
+)
+
+type VariantResponse struct {
+	Id        uuid.UUID `json:"id"`
+	ProductId uuid.UUID `json:"product_id"`
+	ColorId   uuid.UUID `json:"color_id"`
+	SizeId    uuid.UUID `json:"size_id"`
+	Sku       string    `json:"sku"`
+	Barcode   string    `json:"barcode"`
+	Weight    float64   `json:"weight"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func FromVariantEntity(variant entity.Variant) *VariantResponse {
+	return &VariantResponse{
+		Id:        variant.Id,
+		ProductId: variant.ProductId,
+		ColorId:   variant.ColorId,
+		SizeId:    variant.SizeId,
+		Sku:       variant.Sku,
+		Barcode:   variant.Barcode,
+		Weight:    variant.Weight,
+		IsActive:  variant.IsActive,
+		CreatedAt: variant.CreatedAt,
+		UpdatedAt: variant.UpdatedAt,
+	}
+}
@@ -77,14 +77,18 @@ func Init() (
 	}
 
 	rpcServerEndpoint = &model.RpcServerEndpoint{
-		ProductsPrice: envMap["RPC_SERVER_PRODUCTS_PRICE"],
-		GetImageByIds: envMap["RPC_SERVER_OWNERS_IMAGES"],
-		UpdatePrice:   envMap["RPC_SERVER_UPDATE_PRICE"],
+		ProductsPrice:         envMap["RPC_SERVER_PRODUCTS_PRICE"],
+		GetImageByIds:         envMap["RPC_SERVER_OWNERS_IMAGES"],
+		UpdatePrice:           envMap["RPC_SERVER_UPDATE_PRICE"],
+		FilterProductsByPrice: envMap["RPC_SERVER_FILTER_PRODUCTS_BY_PRICE"],
 	}
 
 	httpEndpoint = &model.HttpEndpoint{
 		UploadServerUrl: envMap["UPLOAD_SERVER_URL"],
 		AuthServerUrl:   envMap["AUTH_SERVER_URL"],
+		ClientId:        envMap["AUTH_CLIENT_ID"],
+		ClientSecret:    envMap["AUTH_CLIENT_SECRET"],
+		ClientScope:     envMap["AUTH_CLIENT_SCOPE"],
 	}
 	return databaseConfig, serverConfig, logConfig, rabbitMqConfig, rpcServerEndpoint, httpEndpoint, mode
 }
@@ -3,4 +3,9 @@ package model
 type HttpEndpoint struct {
 	UploadServerUrl string
 	AuthServerUrl   string
+	// Credentials used to obtain service tokens from the auth server for outbound calls
+	ClientId     string
+	ClientSecret string
+	// Space separated scopes requested for the service token
+	ClientScope string
 }
@@ -1,7 +1,8 @@
 package model
 
 type RpcServerEndpoint struct {
-	ProductsPrice string
-	GetImageByIds string
-	UpdatePrice   string
+	ProductsPrice         string
+	GetImageByIds         string
+	UpdatePrice           string
+	FilterProductsByPrice string
 }
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/product-service/util"
+	"github.com/google/uuid"
+)
+
+type Category struct {
+	Id uuid.UUID
+	// uuid.Nil for a root category
+	ParentId  uuid.UUID
+	Name      string
+	Slug      string
+	SortOrder int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewCategory(name, slug string, parentId uuid.UUID, sortOrder int) *Category {
+	current := util.GetCurrentUtcTime(7)
+	return &Category{
+		Id:        uuid.New(),
+		ParentId:  parentId,
+		Name:      name,
+		Slug:      slug,
+		SortOrder: sortOrder,
+		CreatedAt: current,
+		UpdatedAt: current,
+	}
+}
+
+func (c *Category) Update(name, slug string, parentId uuid.UUID, sortOrder int) {
+	c.Name = name
+	c.Slug = slug
+	c.ParentId = parentId
+	c.SortOrder = sortOrder
+	c.UpdatedAt = util.GetCurrentUtcTime(7)
+}
+
+func (c *Category) IsRoot() bool {
+	return c.ParentId == uuid.Nil
+}
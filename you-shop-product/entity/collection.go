@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/product-service/util"
+	"github.com/google/uuid"
+)
+
+// Collection is a manually curated and ordered list of products, e.g: "Summer sale"
+type Collection struct {
+	Id          uuid.UUID
+	Name        string
+	Slug        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewCollection(name, slug, description string) *Collection {
+	current := util.GetCurrentUtcTime(7)
+	return &Collection{
+		Id:          uuid.New(),
+		Name:        name,
+		Slug:        slug,
+		Description: description,
+		CreatedAt:   current,
+		UpdatedAt:   current,
+	}
+}
+
+func (c *Collection) Update(name, slug, description string) {
+	c.Name = name
+	c.Slug = slug
+	c.Description = description
+	c.UpdatedAt = util.GetCurrentUtcTime(7)
+}
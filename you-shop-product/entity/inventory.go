@@ -3,6 +3,7 @@ package entity
 import "github.com/google/uuid"
 
 type Inventory struct {
+	VariantId uuid.UUID
 	ColorId   uuid.UUID
 	ProductId uuid.UUID
 	SizeId    uuid.UUID
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/product-service/util"
+	"github.com/google/uuid"
+)
+
+type ReservationStatus int64
+
+const (
+	Reserved  ReservationStatus = 1 // Holding the stock for the order
+	Committed ReservationStatus = 2 // Deducted from the inventory when the order was confirmed
+	Released  ReservationStatus = 3 // The order was canceled or failed
+	Expired   ReservationStatus = 4 // Released by the background job once expired
+)
+
+// Reservation holds a quantity of a variant for an order, the available quantity is the on-hand quantity minus the held ones
+type Reservation struct {
+	Id        uuid.UUID
+	OrderId   uuid.UUID
+	VariantId uuid.UUID
+	Quantity  int
+	Status    ReservationStatus
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewReservation(orderId, variantId uuid.UUID, quantity int, expiresAt time.Time) *Reservation {
+	current := util.GetCurrentUtcTime(7)
+	return &Reservation{
+		Id:        uuid.New(),
+		OrderId:   orderId,
+		VariantId: variantId,
+		Quantity:  quantity,
+		Status:    Reserved,
+		ExpiresAt: expiresAt,
+		CreatedAt: current,
+		UpdatedAt: current,
+	}
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/product-service/util"
+	"github.com/google/uuid"
+)
+
+// Variant is a sellable color and size of a product, inventory, price and order items reference it
+type Variant struct {
+	Id        uuid.UUID
+	ProductId uuid.UUID
+	ColorId   uuid.UUID
+	SizeId    uuid.UUID
+	Sku       string
+	// Scanned by the warehouse, e.g: an EAN-13 code
+	Barcode   string
+	Weight    float64
+	IsActive  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// legacyVariantNamespace must match the one of the price service
+var legacyVariantNamespace = uuid.MustParse("3c8f2a5e-9d41-4b6e-8f0a-7e2d5c1b9a64")
+
+// LegacyVariantId derives the id of the variant backfilled for stock which existed before variants did.
+// The price service derives the same id from the product, color and size of its legacy prices
+func LegacyVariantId(productId, colorId, sizeId uuid.UUID) uuid.UUID {
+	return uuid.NewSHA1(legacyVariantNamespace, []byte(productId.String()+"/"+colorId.String()+"/"+sizeId.String()))
+}
+
+func NewVariant(productId, colorId, sizeId uuid.UUID, sku, barcode string, weight float64) *Variant {
+	current := util.GetCurrentUtcTime(7)
+	return &Variant{
+		Id:        uuid.New(),
+		ProductId: productId,
+		ColorId:   colorId,
+		SizeId:    sizeId,
+		Sku:       sku,
+		Barcode:   barcode,
+		Weight:    weight,
+		IsActive:  true,
+		CreatedAt: current,
+		UpdatedAt: current,
+	}
+}
+
+func (v *Variant) Update(sku, barcode string, weight float64, isActive bool) {
+	v.Sku = sku
+	v.Barcode = barcode
+	v.Weight = weight
+	v.IsActive = isActive
+	v.UpdatedAt = util.GetCurrentUtcTime(7)
+}
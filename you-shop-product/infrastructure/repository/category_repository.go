@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/google/uuid"
+)
+
+// categoryTree selects the id of the category $1 and of all its descendants
+const categoryTree = `
+	WITH RECURSIVE tree AS (
+		SELECT c.id FROM category c WHERE c.id = $1
+		UNION ALL
+		SELECT c.id FROM category c JOIN tree t ON c.parent_id = t.id
+	)
+`
+
+type CategoryRepository struct {
+	db *sql.DB
+}
+
+func NewCategoryRepository(db *sql.DB) *CategoryRepository {
+	return &CategoryRepository{
+		db: db,
+	}
+}
+
+func (c *CategoryRepository) AddCategory(category *entity.Category) error {
+	query := `
+		INSERT INTO category (id, parent_id, name, slug, sort_order, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := c.db.Exec(query, category.Id, nullUuid(category.ParentId), category.Name, category.Slug, category.SortOrder, category.CreatedAt, category.UpdatedAt)
+	return err
+}
+
+func (c *CategoryRepository) UpdateCategory(category *entity.Category) error {
+	query := `
+		UPDATE category
+		SET
+			parent_id = $1,
+			name = $2,
+			slug = $3,
+			sort_order = $4,
+			updated_at = $5
+		WHERE id = $6
+	`
+	_, err := c.db.Exec(query, nullUuid(category.ParentId), category.Name, category.Slug, category.SortOrder, category.UpdatedAt, category.Id)
+	return err
+}
+
+func (c *CategoryRepository) DeleteCategory(id uuid.UUID) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM product_category WHERE category_id = $1`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM category WHERE id = $1`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (c *CategoryRepository) GetCategories() ([]entity.Category, error) {
+	query := `
+		SELECT
+			c.id,
+			c.parent_id,
+			c.name,
+			c.slug,
+			c.sort_order,
+			c.created_at,
+			c.updated_at
+		FROM category c
+		ORDER BY c.sort_order, c.name
+	`
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := []entity.Category{}
+	for rows.Next() {
+		category, scanErr := scanCategory(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		result = append(result, *category)
+	}
+	return result, nil
+}
+
+func (c *CategoryRepository) GetCategoryById(id uuid.UUID) (*entity.Category, error) {
+	return c.getCategory(`WHERE c.id = $1`, id)
+}
+
+func (c *CategoryRepository) GetCategoryBySlug(slug string) (*entity.Category, error) {
+	return c.getCategory(`WHERE c.slug = $1`, slug)
+}
+
+func (c *CategoryRepository) getCategory(condition string, arg any) (*entity.Category, error) {
+	query := `
+		SELECT
+			c.id,
+			c.parent_id,
+			c.name,
+			c.slug,
+			c.sort_order,
+			c.created_at,
+			c.updated_at
+		FROM category c
+	` + condition
+	category, err := scanCategory(c.db.QueryRow(query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return category, err
+}
+
+func (c *CategoryRepository) IsSlugExisted(slug string, excludeId uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM category c WHERE c.slug = $1 AND c.id <> $2)
+	`
+	var isExisted bool
+	err := c.db.QueryRow(query, slug, excludeId).Scan(&isExisted)
+	return isExisted, err
+}
+
+func (c *CategoryRepository) HasChildren(id uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM category c WHERE c.parent_id = $1)
+	`
+	var hasChildren bool
+	err := c.db.QueryRow(query, id).Scan(&hasChildren)
+	return hasChildren, err
+}
+
+func (c *CategoryRepository) AddProduct(categoryId, productId uuid.UUID) error {
+	query := `
+		INSERT INTO product_category (category_id, product_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (category_id, product_id) DO NOTHING
+	`
+	_, err := c.db.Exec(query, categoryId, productId)
+	return err
+}
+
+func (c *CategoryRepository) RemoveProduct(categoryId, productId uuid.UUID) (bool, error) {
+	query := `
+		DELETE FROM product_category WHERE category_id = $1 AND product_id = $2
+	`
+	result, err := c.db.Exec(query, categoryId, productId)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (c *CategoryRepository) CountProducts(categoryId uuid.UUID) (int, error) {
+	query := categoryTree + `
+		SELECT COUNT(*)
+		FROM product p
+		WHERE p.deleted_at IS NULL
+			AND p.status = 1
+			AND EXISTS (
+				SELECT 1 FROM product_category pc
+				JOIN tree t ON t.id = pc.category_id
+				WHERE pc.product_id = p.id
+			)
+	`
+	var count int
+	err := c.db.QueryRow(query, categoryId).Scan(&count)
+	return count, err
+}
+
+// GetProducts returns the active products of the category and of its descendants
+func (c *CategoryRepository) GetProducts(categoryId uuid.UUID, page int, pageSize int) ([]entity.Product, error) {
+	query := categoryTree + `
+		SELECT
+			p.id::uuid,
+			p.status,
+			p.name,
+			p.description,
+			p.sku,
+			p.created_at,
+			p.updated_at,
+			p.thumbnail
+		FROM product p
+		WHERE p.deleted_at IS NULL
+			AND p.status = 1
+			AND EXISTS (
+				SELECT 1 FROM product_category pc
+				JOIN tree t ON t.id = pc.category_id
+				WHERE pc.product_id = p.id
+			)
+		ORDER BY p.created_at DESC
+		LIMIT $2
+		OFFSET $3
+	`
+	rows, err := c.db.Query(query, categoryId, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanProducts(rows)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanCategory(row rowScanner) (*entity.Category, error) {
+	var category entity.Category
+	var parentId uuid.NullUUID
+	if err := row.Scan(&category.Id, &parentId, &category.Name, &category.Slug, &category.SortOrder, &category.CreatedAt, &category.UpdatedAt); err != nil {
+		return nil, err
+	}
+	category.ParentId = parentId.UUID
+	return &category, nil
+}
+
+func nullUuid(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: id != uuid.Nil}
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/google/uuid"
+)
+
+type CollectionRepository struct {
+	db *sql.DB
+}
+
+func NewCollectionRepository(db *sql.DB) *CollectionRepository {
+	return &CollectionRepository{
+		db: db,
+	}
+}
+
+func (c *CollectionRepository) AddCollection(collection *entity.Collection) error {
+	query := `
+		INSERT INTO collection (id, name, slug, description, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := c.db.Exec(query, collection.Id, collection.Name, collection.Slug, collection.Description, collection.CreatedAt, collection.UpdatedAt)
+	return err
+}
+
+func (c *CollectionRepository) UpdateCollection(collection *entity.Collection) error {
+	query := `
+		UPDATE collection
+		SET
+			name = $1,
+			slug = $2,
+			description = $3,
+			updated_at = $4
+		WHERE id = $5
+	`
+	_, err := c.db.Exec(query, collection.Name, collection.Slug, collection.Description, collection.UpdatedAt, collection.Id)
+	return err
+}
+
+func (c *CollectionRepository) DeleteCollection(id uuid.UUID) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM collection_product WHERE collection_id = $1`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM collection WHERE id = $1`, id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (c *CollectionRepository) GetCollections() ([]entity.Collection, error) {
+	query := `
+		SELECT
+			c.id,
+			c.name,
+			c.slug,
+			c.description,
+			c.created_at,
+			c.updated_at
+		FROM collection c
+		ORDER BY c.name
+	`
+	rows, err := c.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := []entity.Collection{}
+	for rows.Next() {
+		collection, scanErr := scanCollection(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		result = append(result, *collection)
+	}
+	return result, nil
+}
+
+func (c *CollectionRepository) GetCollectionById(id uuid.UUID) (*entity.Collection, error) {
+	return c.getCollection(`WHERE c.id = $1`, id)
+}
+
+func (c *CollectionRepository) GetCollectionBySlug(slug string) (*entity.Collection, error) {
+	return c.getCollection(`WHERE c.slug = $1`, slug)
+}
+
+func (c *CollectionRepository) getCollection(condition string, arg any) (*entity.Collection, error) {
+	query := `
+		SELECT
+			c.id,
+			c.name,
+			c.slug,
+			c.description,
+			c.created_at,
+			c.updated_at
+		FROM collection c
+	` + condition
+	collection, err := scanCollection(c.db.QueryRow(query, arg))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return collection, err
+}
+
+func (c *CollectionRepository) IsSlugExisted(slug string, excludeId uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM collection c WHERE c.slug = $1 AND c.id <> $2)
+	`
+	var isExisted bool
+	err := c.db.QueryRow(query, slug, excludeId).Scan(&isExisted)
+	return isExisted, err
+}
+
+// SetProducts replaces the products of the collection, their order is kept as the position
+func (c *CollectionRepository) SetProducts(collectionId uuid.UUID, productIds []uuid.UUID) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM collection_product WHERE collection_id = $1`, collectionId); err != nil {
+		tx.Rollback()
+		return err
+	}
+	stmt, err := tx.Prepare(`
+		INSERT INTO collection_product (collection_id, product_id, position, created_at)
+		VALUES ($1, $2, $3, NOW())
+	`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+	for position, productId := range productIds {
+		if _, err := stmt.Exec(collectionId, productId, position); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (c *CollectionRepository) CountProducts(collectionId uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM collection_product cp
+		JOIN product p ON p.id = cp.product_id
+		WHERE cp.collection_id = $1 AND p.deleted_at IS NULL AND p.status = 1
+	`
+	var count int
+	err := c.db.QueryRow(query, collectionId).Scan(&count)
+	return count, err
+}
+
+// GetProducts returns the active products of the collection in their curated order
+func (c *CollectionRepository) GetProducts(collectionId uuid.UUID, page int, pageSize int) ([]entity.Product, error) {
+	query := `
+		SELECT
+			p.id::uuid,
+			p.status,
+			p.name,
+			p.description,
+			p.sku,
+			p.created_at,
+			p.updated_at,
+			p.thumbnail
+		FROM collection_product cp
+		JOIN product p ON p.id = cp.product_id
+		WHERE cp.collection_id = $1 AND p.deleted_at IS NULL AND p.status = 1
+		ORDER BY cp.position
+		LIMIT $2
+		OFFSET $3
+	`
+	rows, err := c.db.Query(query, collectionId, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanProducts(rows)
+}
+
+func scanCollection(row rowScanner) (*entity.Collection, error) {
+	var collection entity.Collection
+	var description sql.NullString
+	if err := row.Scan(&collection.Id, &collection.Name, &collection.Slug, &description, &collection.CreatedAt, &collection.UpdatedAt); err != nil {
+		return nil, err
+	}
+	collection.Description = description.String
+	return &collection, nil
+}
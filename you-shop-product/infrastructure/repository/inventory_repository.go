@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"sort"
 
 	"github.com/TechwizsonORG/product-service/entity"
 	"github.com/TechwizsonORG/product-service/usecase/inventory/model"
@@ -23,15 +24,24 @@ func NewInventoryRepository(db *sql.DB, log zerolog.Logger) *InventoryRepository
 	return &InventoryRepository{db: db, log: logger}
 
 }
-func (p *InventoryRepository) GetQuantity(productId, sizeId, colorId uuid.UUID) (int, error) {
+
+// GetQuantity returns the available quantity, the on-hand quantity minus the live reservations
+func (p *InventoryRepository) GetQuantity(variantId uuid.UUID) (int, error) {
 	query := `
 		SELECT
-			COALESCE(i.quantity, 0) AS quantity
+			COALESCE(i.quantity, 0) - COALESCE((
+				SELECT SUM(r.quantity)
+				FROM inventory_reservation r
+				WHERE r.variant_id = i.variant_id AND r.status = $2 AND r.expires_at > NOW()
+			), 0) AS quantity
 		FROM inventory i
+		JOIN variant v ON i.variant_id = v.id
 		JOIN product p ON i.product_id = p.id
-		WHERE i.product_id = $1 AND i.size_id = $2 AND i.color_id = $3 AND p.deleted_at IS NULL AND p.status = 1
+		WHERE i.variant_id = $1
+			AND v.deleted_at IS NULL AND v.is_active = true
+			AND p.deleted_at IS NULL AND p.status = 1
 	`
-	row := p.db.QueryRow(query, productId, sizeId, colorId)
+	row := p.db.QueryRow(query, variantId, entity.Reserved)
 	var quantity int
 	scanErr := row.Scan(&quantity)
 	if scanErr != nil {
@@ -41,23 +51,28 @@ func (p *InventoryRepository) GetQuantity(productId, sizeId, colorId uuid.UUID)
 	return quantity, nil
 }
 
+// AddInventories creates the variants along with their inventories
 func (p *InventoryRepository) AddInventories(createInventories []model.CreateInventory) error {
-	query := `
-		INSERT INTO inventory (product_id, size_id, color_id, quantity, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+	variantQuery := `
+		INSERT INTO variant (id, product_id, color_id, size_id, sku, barcode, weight, is_active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, true, $8, $9)
+	`
+	inventoryQuery := `
+		INSERT INTO inventory (variant_id, product_id, size_id, color_id, quantity, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 	tx, err := p.db.Begin()
 	if err != nil {
 		return err
 	}
 	for _, inventory := range createInventories {
-		stmt, err := tx.Prepare(query)
+		current := util.GetCurrentUtcTime(7)
+		_, err = tx.Exec(variantQuery, inventory.VariantId, inventory.ProductId, inventory.ColorId, inventory.SizeId, nullString(inventory.Sku), nullString(inventory.Barcode), inventory.Weight, current, current)
 		if err != nil {
 			tx.Rollback()
 			return err
 		}
-		defer stmt.Close()
-		_, err = stmt.Exec(inventory.ProductId, inventory.SizeId, inventory.ColorId, inventory.Quantity, util.GetCurrentUtcTime(7), util.GetCurrentUtcTime(7))
+		_, err = tx.Exec(inventoryQuery, inventory.VariantId, inventory.ProductId, inventory.SizeId, inventory.ColorId, inventory.Quantity, current, current)
 		if err != nil {
 			tx.Rollback()
 			return err
@@ -66,24 +81,23 @@ func (p *InventoryRepository) AddInventories(createInventories []model.CreateInv
 	return tx.Commit()
 }
 
-func (i *InventoryRepository) GetInventory(productId uuid.UUID, colorId uuid.UUID, sizeId uuid.UUID) (inventory *entity.Inventory, e error) {
+func (i *InventoryRepository) GetInventory(variantId uuid.UUID) (inventory *entity.Inventory, e error) {
 	query := `
 		SELECT
+			i.variant_id,
 			i.product_id,
 			i.size_id,
 			i.color_id,
 			i.quantity
 		FROM inventory i
-		WHERE i.color_id = $1
-			AND i.product_id = $2
-			AND i.size_id = $3
+		WHERE i.variant_id = $1
 	`
-	row := i.db.QueryRow(query, colorId, productId, sizeId)
+	row := i.db.QueryRow(query, variantId)
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
 	inventory = &entity.Inventory{}
-	scanErr := row.Scan(&inventory.ProductId, &inventory.SizeId, &inventory.ColorId, &inventory.Quantity)
+	scanErr := row.Scan(&inventory.VariantId, &inventory.ProductId, &inventory.SizeId, &inventory.ColorId, &inventory.Quantity)
 	if scanErr != nil {
 		if scanErr.Error() == sql.ErrNoRows.Error() {
 			return nil, nil
@@ -92,23 +106,87 @@ func (i *InventoryRepository) GetInventory(productId uuid.UUID, colorId uuid.UUI
 	}
 	return inventory, nil
 }
-func (i *InventoryRepository) UpdateInventory(updateInventory *entity.Inventory) error {
+// UpdateInventory sets the quantity in a single statement, only when it still covers the live reservations
+func (i *InventoryRepository) UpdateInventory(updateInventory *entity.Inventory) (bool, error) {
 	query := `
-		UPDATE inventory
+		UPDATE inventory i
 		SET
-			quantity = $1
-		WHERE color_id = $2
-			AND product_id = $3
-			AND size_id = $4
+			quantity = $1,
+			updated_at = $2
+		WHERE i.variant_id = $3 AND i.product_id = $4 AND $1 >= (
+			SELECT COALESCE(SUM(r.quantity), 0)
+			FROM inventory_reservation r
+			WHERE r.variant_id = i.variant_id AND r.status = $5 AND r.expires_at > NOW()
+		)
 	`
-	stmt, err := i.db.Prepare(query)
+	result, err := i.db.Exec(query, updateInventory.Quantity, util.GetCurrentUtcTime(7), updateInventory.VariantId, updateInventory.ProductId, entity.Reserved)
 	if err != nil {
-		return err
+		return false, err
 	}
-	defer stmt.Close()
-	_, err = stmt.Exec(updateInventory.Quantity, updateInventory.ColorId, updateInventory.ProductId, updateInventory.SizeId)
+	affected, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// adjustQuantityQuery never takes out the stock held by live reservations, adding stock always succeeds
+const adjustQuantityQuery = `
+	UPDATE inventory i
+	SET
+		quantity = i.quantity + $1,
+		updated_at = $2
+	WHERE i.variant_id = $3 AND ($1 >= 0 OR i.quantity + $1 >= (
+		SELECT COALESCE(SUM(r.quantity), 0)
+		FROM inventory_reservation r
+		WHERE r.variant_id = i.variant_id AND r.status = $4 AND r.expires_at > NOW()
+	))
+`
+
+// AdjustQuantity checks and changes the quantity in a single statement so concurrent adjustments can't overwrite each other
+func (i *InventoryRepository) AdjustQuantity(variantId uuid.UUID, changeAmount int) (bool, error) {
+	result, err := i.db.Exec(adjustQuantityQuery, changeAmount, util.GetCurrentUtcTime(7), variantId, entity.Reserved)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (i *InventoryRepository) AdjustQuantities(adjustments []model.QuantityAdjustment) (uuid.UUID, error) {
+	// Same locking order in every transaction to avoid deadlocks
+	sorted := make([]model.QuantityAdjustment, len(adjustments))
+	copy(sorted, adjustments)
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].VariantId.String() < sorted[b].VariantId.String()
+	})
+	tx, err := i.db.Begin()
+	if err != nil {
+		return uuid.Nil, err
 	}
-	return nil
+	current := util.GetCurrentUtcTime(7)
+	for _, adjustment := range sorted {
+		result, err := tx.Exec(adjustQuantityQuery, adjustment.ChangeAmount, current, adjustment.VariantId, entity.Reserved)
+		if err != nil {
+			tx.Rollback()
+			return uuid.Nil, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return uuid.Nil, err
+		}
+		if affected != 1 {
+			return adjustment.VariantId, tx.Rollback()
+		}
+	}
+	return uuid.Nil, tx.Commit()
+}
+
+// nullString stores an empty optional value as NULL so unique columns accept many of them
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
 }
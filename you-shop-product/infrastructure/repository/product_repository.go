@@ -6,6 +6,7 @@ import (
 
 	"github.com/TechwizsonORG/product-service/entity"
 	appErr "github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/product/model"
 	"github.com/TechwizsonORG/product-service/util"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -26,8 +27,46 @@ func NewProductRepository(db *sql.DB, log zerolog.Logger) *ProductRepository {
 
 }
 
-func (p *ProductRepository) Search(query string) ([]entity.Product, appErr.ApplicationError) {
+// searchMatchedProducts selects the ids and ranks of the products matching a search filter.
+// The document is the stored search_document column, see the README for its definition and GIN index.
+//
+// $1 is the text query, $2 the status, $3 the color ids, $4 the size ids and $5 the product ids
+const searchMatchedProducts = `
+	SELECT
+		p.id,
+		ts_rank(p.search_document, s.query) AS rank
+	FROM product p
+	CROSS JOIN (
+		SELECT websearch_to_tsquery('simple', immutable_unaccent($1)) AS query
+	) s
+	WHERE p.deleted_at IS NULL
+		AND ($1 = '' OR p.search_document @@ s.query OR LOWER(p.sku) = LOWER($1) OR EXISTS (
+			SELECT 1 FROM variant v
+			WHERE v.product_id = p.id AND v.deleted_at IS NULL AND (LOWER(v.sku) = LOWER($1) OR v.barcode = $1)
+		))
+		AND ($2 = 0 OR p.status = $2)
+		AND ($5::uuid[] IS NULL OR p.id = ANY($5::uuid[]))
+		AND (($3::uuid[] IS NULL AND $4::uuid[] IS NULL) OR EXISTS (
+			SELECT 1 FROM variant v
+			WHERE v.product_id = p.id AND v.deleted_at IS NULL AND v.is_active = true
+				AND ($3::uuid[] IS NULL OR v.color_id = ANY($3::uuid[]))
+				AND ($4::uuid[] IS NULL OR v.size_id = ANY($4::uuid[]))
+		))
+`
+
+func searchArgs(filter model.ProductSearchFilter) []any {
+	return []any{
+		filter.Query,
+		filter.Status,
+		pq.Array(filter.ColorIds),
+		pq.Array(filter.SizeIds),
+		pq.Array(filter.ProductIds),
+	}
+}
+
+func (p *ProductRepository) Search(filter model.ProductSearchFilter, page int, pageSize int) ([]entity.Product, appErr.ApplicationError) {
 	queryString := `
+		WITH matched AS (` + searchMatchedProducts + `)
 		SELECT
 			p.id::uuid,
 			p.status,
@@ -37,17 +76,21 @@ func (p *ProductRepository) Search(query string) ([]entity.Product, appErr.Appli
 			p.created_at,
 			p.updated_at,
 			p.thumbnail
-		FROM product p
-		WHERE p.name LIKE $1 AND p.deleted_at IS NULL
+		FROM matched m
+		JOIN product p ON p.id = m.id
+		ORDER BY m.rank DESC, p.created_at DESC
+		LIMIT $6
+		OFFSET $7
 	`
-	var products []entity.Product
-	rows, err := p.db.Query(queryString, "%"+query+"%")
+	offset := (page - 1) * pageSize
+	rows, err := p.db.Query(queryString, append(searchArgs(filter), pageSize, offset)...)
 	if err != nil {
-		p.log.Error().Err(err).Msg("Errored occurred")
+		p.log.Error().Err(err).Msg("")
 		return nil, appErr.CommonError()
 	}
 	defer rows.Close()
 
+	products := []entity.Product{}
 	for rows.Next() {
 		var id uuid.UUID
 		var status entity.ProductStatus
@@ -59,7 +102,7 @@ func (p *ProductRepository) Search(query string) ([]entity.Product, appErr.Appli
 		var thumbnail sql.NullString
 		err := rows.Scan(&id, &status, &name, &description, &sku, &createdAt, &updatedAt, &thumbnail)
 		if err != nil {
-			p.log.Err(err).Msg("Error occurred")
+			p.log.Err(err).Msg("")
 			return nil, appErr.CommonError()
 		}
 		product := entity.Product{
@@ -77,6 +120,79 @@ func (p *ProductRepository) Search(query string) ([]entity.Product, appErr.Appli
 	return products, nil
 }
 
+func (p *ProductRepository) CountSearch(filter model.ProductSearchFilter) (int, appErr.ApplicationError) {
+	queryString := `
+		WITH matched AS (` + searchMatchedProducts + `)
+		SELECT COUNT(*) FROM matched
+	`
+	row := p.db.QueryRow(queryString, searchArgs(filter)...)
+	var count int
+	if err := row.Scan(&count); err != nil {
+		p.log.Error().Err(err).Msg("")
+		return 0, appErr.CommonError()
+	}
+	return count, nil
+}
+
+// GetSearchColorFacets counts the matched products per color. The color filter is left out so every color
+// stays selectable, the size filter still applies to the same variant
+func (p *ProductRepository) GetSearchColorFacets(filter model.ProductSearchFilter) ([]model.Facet, appErr.ApplicationError) {
+	filter.ColorIds = nil
+	queryString := `
+		WITH matched AS (` + searchMatchedProducts + `)
+		SELECT
+			c.id,
+			c.name,
+			COUNT(DISTINCT v.product_id)
+		FROM matched m
+		JOIN variant v ON v.product_id = m.id AND v.deleted_at IS NULL AND v.is_active = true
+		JOIN color c ON c.id = v.color_id
+		WHERE $4::uuid[] IS NULL OR v.size_id = ANY($4::uuid[])
+		GROUP BY c.id, c.name
+		ORDER BY c.name
+	`
+	return p.getFacets(queryString, filter)
+}
+
+// GetSearchSizeFacets counts the matched products per size, see GetSearchColorFacets
+func (p *ProductRepository) GetSearchSizeFacets(filter model.ProductSearchFilter) ([]model.Facet, appErr.ApplicationError) {
+	filter.SizeIds = nil
+	queryString := `
+		WITH matched AS (` + searchMatchedProducts + `)
+		SELECT
+			s.id,
+			s.name,
+			COUNT(DISTINCT v.product_id)
+		FROM matched m
+		JOIN variant v ON v.product_id = m.id AND v.deleted_at IS NULL AND v.is_active = true
+		JOIN "size" s ON s.id = v.size_id
+		WHERE $3::uuid[] IS NULL OR v.color_id = ANY($3::uuid[])
+		GROUP BY s.id, s.name
+		ORDER BY s.name
+	`
+	return p.getFacets(queryString, filter)
+}
+
+func (p *ProductRepository) getFacets(queryString string, filter model.ProductSearchFilter) ([]model.Facet, appErr.ApplicationError) {
+	rows, err := p.db.Query(queryString, searchArgs(filter)...)
+	if err != nil {
+		p.log.Error().Err(err).Msg("")
+		return nil, appErr.CommonError()
+	}
+	defer rows.Close()
+
+	facets := []model.Facet{}
+	for rows.Next() {
+		var facet model.Facet
+		if err := rows.Scan(&facet.Id, &facet.Name, &facet.Count); err != nil {
+			p.log.Error().Err(err).Msg("")
+			return nil, appErr.CommonError()
+		}
+		facets = append(facets, facet)
+	}
+	return facets, nil
+}
+
 func (p *ProductRepository) Count() (int, appErr.ApplicationError) {
 	queryString := `
 		SELECT COUNT(*) FROM product p
@@ -369,3 +485,32 @@ func (s *ProductRepository) AddProductImages(productImages []entity.ProductImage
 	tx.Commit()
 	return nil
 }
+
+// scanProducts reads the rows selected with the same columns as List
+func scanProducts(rows *sql.Rows) ([]entity.Product, error) {
+	products := []entity.Product{}
+	for rows.Next() {
+		var id uuid.UUID
+		var status entity.ProductStatus
+		var name sql.NullString
+		var description sql.NullString
+		var sku sql.NullString
+		var createdAt time.Time
+		var updatedAt time.Time
+		var thumbnail sql.NullString
+		if err := rows.Scan(&id, &status, &name, &description, &sku, &createdAt, &updatedAt, &thumbnail); err != nil {
+			return nil, err
+		}
+		products = append(products, entity.Product{
+			Id:          id,
+			Status:      status,
+			Name:        name.String,
+			Description: description.String,
+			Sku:         sku.String,
+			CreatedAt:   createdAt,
+			UpdatedAt:   updatedAt,
+			Thumbnail:   thumbnail.String,
+		})
+	}
+	return products, nil
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/util"
+	"github.com/google/uuid"
+)
+
+type ReservationRepository struct {
+	db *sql.DB
+}
+
+func NewReservationRepository(db *sql.DB) *ReservationRepository {
+	return &ReservationRepository{
+		db: db,
+	}
+}
+
+// Reserve locks the inventory rows so concurrent reservations of the same variant are checked one after another
+func (r *ReservationRepository) Reserve(reservations []*entity.Reservation) ([]uuid.UUID, error) {
+	onHandQuery := `
+		SELECT i.quantity
+		FROM inventory i
+		JOIN variant v ON i.variant_id = v.id
+		JOIN product p ON i.product_id = p.id
+		WHERE i.variant_id = $1
+			AND v.deleted_at IS NULL AND v.is_active = true
+			AND p.deleted_at IS NULL AND p.status = 1
+		FOR UPDATE OF i
+	`
+	insertQuery := `
+		INSERT INTO inventory_reservation (id, order_id, variant_id, quantity, status, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	// Same locking order in every transaction to avoid deadlocks
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].VariantId.String() < reservations[j].VariantId.String()
+	})
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	shortVariantIds := []uuid.UUID{}
+	for _, reservation := range reservations {
+		var onHand int
+		err = tx.QueryRow(onHandQuery, reservation.VariantId).Scan(&onHand)
+		if errors.Is(err, sql.ErrNoRows) {
+			shortVariantIds = append(shortVariantIds, reservation.VariantId)
+			continue
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		reserved, err := reservedQuantity(tx, reservation.VariantId)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if onHand-reserved < reservation.Quantity {
+			shortVariantIds = append(shortVariantIds, reservation.VariantId)
+		}
+	}
+	if len(shortVariantIds) > 0 {
+		return shortVariantIds, tx.Rollback()
+	}
+	for _, reservation := range reservations {
+		_, err = tx.Exec(insertQuery, reservation.Id, reservation.OrderId, reservation.VariantId, reservation.Quantity, reservation.Status, reservation.ExpiresAt, reservation.CreatedAt, reservation.UpdatedAt)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	return nil, tx.Commit()
+}
+
+// Commit runs in one transaction and records the directly deducted quantities as committed reservations,
+// so committing the same order again deducts nothing twice. Nothing is committed when a variant is short
+func (r *ReservationRepository) Commit(orderId uuid.UUID, quantities map[uuid.UUID]int) ([]uuid.UUID, error) {
+	commitQuery := `
+		UPDATE inventory_reservation
+		SET
+			status = $1,
+			updated_at = $2
+		WHERE order_id = $3 AND status = $4 AND expires_at > NOW()
+		RETURNING variant_id, quantity
+	`
+	committedQuery := `
+		SELECT r.variant_id, SUM(r.quantity)
+		FROM inventory_reservation r
+		WHERE r.order_id = $1 AND r.status = $2
+		GROUP BY r.variant_id
+	`
+	insertQuery := `
+		INSERT INTO inventory_reservation (id, order_id, variant_id, quantity, status, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	current := util.GetCurrentUtcTime(7)
+	live, err := sumByVariant(tx, commitQuery, entity.Committed, current, orderId, entity.Reserved)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	committed, err := sumByVariant(tx, committedQuery, orderId, entity.Committed)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	variantIds := make([]uuid.UUID, 0, len(quantities)+len(live))
+	for variantId := range quantities {
+		variantIds = append(variantIds, variantId)
+	}
+	for variantId := range live {
+		if _, ok := quantities[variantId]; !ok {
+			variantIds = append(variantIds, variantId)
+		}
+	}
+	// Same locking order in every transaction to avoid deadlocks
+	sort.Slice(variantIds, func(i, j int) bool {
+		return variantIds[i].String() < variantIds[j].String()
+	})
+	shortVariantIds := []uuid.UUID{}
+	for _, variantId := range variantIds {
+		// The committed reservations no longer hold stock, the deductions leave the other live reservations covered
+		if live[variantId] > 0 {
+			deducted, err := deduct(tx, variantId, live[variantId], current)
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			if !deducted {
+				shortVariantIds = append(shortVariantIds, variantId)
+				continue
+			}
+		}
+		// The reservation expired before the order was confirmed
+		remaining := quantities[variantId] - committed[variantId]
+		if remaining <= 0 {
+			continue
+		}
+		deducted, err := deduct(tx, variantId, remaining, current)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if !deducted {
+			shortVariantIds = append(shortVariantIds, variantId)
+			continue
+		}
+		_, err = tx.Exec(insertQuery, uuid.New(), orderId, variantId, remaining, entity.Committed, current, current, current)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if len(shortVariantIds) > 0 {
+		return shortVariantIds, tx.Rollback()
+	}
+	return nil, tx.Commit()
+}
+
+func (r *ReservationRepository) Release(orderId uuid.UUID) (int64, error) {
+	query := `
+		UPDATE inventory_reservation
+		SET
+			status = $1,
+			updated_at = $2
+		WHERE order_id = $3 AND status = $4
+	`
+	result, err := r.db.Exec(query, entity.Released, util.GetCurrentUtcTime(7), orderId, entity.Reserved)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *ReservationRepository) ExpireReservations() (int64, error) {
+	query := `
+		UPDATE inventory_reservation
+		SET
+			status = $1,
+			updated_at = $2
+		WHERE status = $3 AND expires_at <= NOW()
+	`
+	result, err := r.db.Exec(query, entity.Expired, util.GetCurrentUtcTime(7), entity.Reserved)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// reservedQuantity sums the live reservations of the variant, expired ones no longer hold stock even before the job releases them
+func reservedQuantity(q interface {
+	QueryRow(query string, args ...any) *sql.Row
+}, variantId uuid.UUID) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(r.quantity), 0)
+		FROM inventory_reservation r
+		WHERE r.variant_id = $1 AND r.status = $2 AND r.expires_at > NOW()
+	`
+	var reserved int
+	err := q.QueryRow(query, variantId, entity.Reserved).Scan(&reserved)
+	return reserved, err
+}
+
+// sumByVariant reads the variant id and quantity pairs returned by the query
+func sumByVariant(tx *sql.Tx, query string, args ...any) (map[uuid.UUID]int, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	quantities := map[uuid.UUID]int{}
+	for rows.Next() {
+		var variantId uuid.UUID
+		var quantity int
+		if err = rows.Scan(&variantId, &quantity); err != nil {
+			return nil, err
+		}
+		quantities[variantId] += quantity
+	}
+	return quantities, rows.Err()
+}
+
+// deduct takes the quantity out of the inventory with the same guard as the inventory adjustments
+func deduct(tx *sql.Tx, variantId uuid.UUID, quantity int, current time.Time) (bool, error) {
+	result, err := tx.Exec(adjustQuantityQuery, -quantity, current, variantId, entity.Reserved)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/util"
+	"github.com/google/uuid"
+)
+
+type VariantRepository struct {
+	db *sql.DB
+}
+
+func NewVariantRepository(db *sql.DB) *VariantRepository {
+	return &VariantRepository{
+		db: db,
+	}
+}
+
+const selectVariant = `
+	SELECT
+		v.id,
+		v.product_id,
+		v.color_id,
+		v.size_id,
+		COALESCE(v.sku, ''),
+		COALESCE(v.barcode, ''),
+		v.weight,
+		v.is_active,
+		v.created_at,
+		v.updated_at
+	FROM variant v
+`
+
+func (v *VariantRepository) GetVariants(productId uuid.UUID) ([]entity.Variant, error) {
+	query := selectVariant + `
+		WHERE v.product_id = $1 AND v.deleted_at IS NULL
+		ORDER BY v.created_at
+	`
+	rows, err := v.db.Query(query, productId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := []entity.Variant{}
+	for rows.Next() {
+		variant, scanErr := scanVariant(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		result = append(result, *variant)
+	}
+	return result, nil
+}
+
+func (v *VariantRepository) GetVariantById(id uuid.UUID) (*entity.Variant, error) {
+	return v.getVariant(`WHERE v.id = $1 AND v.deleted_at IS NULL`, id)
+}
+
+func (v *VariantRepository) GetVariantByBarcode(barcode string) (*entity.Variant, error) {
+	return v.getVariant(`WHERE v.barcode = $1 AND v.deleted_at IS NULL`, barcode)
+}
+
+func (v *VariantRepository) UpdateVariant(variant *entity.Variant) error {
+	query := `
+		UPDATE variant
+		SET
+			sku = $1,
+			barcode = $2,
+			weight = $3,
+			is_active = $4,
+			updated_at = $5
+		WHERE id = $6
+	`
+	_, err := v.db.Exec(query, nullString(variant.Sku), nullString(variant.Barcode), variant.Weight, variant.IsActive, variant.UpdatedAt, variant.Id)
+	return err
+}
+
+// DeleteVariant marks the variant as deleted, order items keep referencing it
+func (v *VariantRepository) DeleteVariant(id uuid.UUID) error {
+	query := `
+		UPDATE variant
+		SET
+			is_active = false,
+			deleted_at = NOW()
+		WHERE id = $1
+	`
+	_, err := v.db.Exec(query, id)
+	return err
+}
+
+func (v *VariantRepository) IsSkuExisted(sku string, excludeId uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM variant
+			WHERE LOWER(sku) = LOWER($1) AND id <> $2 AND deleted_at IS NULL
+		)
+	`
+	var isExisted bool
+	err := v.db.QueryRow(query, sku, excludeId).Scan(&isExisted)
+	return isExisted, err
+}
+
+func (v *VariantRepository) IsBarcodeExisted(barcode string, excludeId uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM variant
+			WHERE barcode = $1 AND id <> $2 AND deleted_at IS NULL
+		)
+	`
+	var isExisted bool
+	err := v.db.QueryRow(query, barcode, excludeId).Scan(&isExisted)
+	return isExisted, err
+}
+
+func (v *VariantRepository) IsOptionExisted(productId, colorId, sizeId uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM variant
+			WHERE product_id = $1 AND color_id = $2 AND size_id = $3 AND deleted_at IS NULL
+		)
+	`
+	var isExisted bool
+	err := v.db.QueryRow(query, productId, colorId, sizeId).Scan(&isExisted)
+	return isExisted, err
+}
+
+// BackfillVariants creates a variant for every product, color and size which was stocked before variants existed
+// and links the inventories to it. An existing variant of the same option is reused
+func (v *VariantRepository) BackfillVariants() (int, error) {
+	tx, err := v.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	rows, err := tx.Query(`
+		SELECT DISTINCT i.product_id, i.color_id, i.size_id
+		FROM inventory i
+		WHERE i.variant_id IS NULL
+	`)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	options := [][3]uuid.UUID{}
+	for rows.Next() {
+		var option [3]uuid.UUID
+		if err = rows.Scan(&option[0], &option[1], &option[2]); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return 0, err
+		}
+		options = append(options, option)
+	}
+	rows.Close()
+
+	variantQuery := `
+		INSERT INTO variant (id, product_id, color_id, size_id, weight, is_active, created_at, updated_at)
+		SELECT $1, $2, $3, $4, 0, true, $5, $5
+		WHERE NOT EXISTS (
+			SELECT 1 FROM variant
+			WHERE product_id = $2 AND color_id = $3 AND size_id = $4 AND deleted_at IS NULL
+		)
+		ON CONFLICT (id) DO NOTHING
+	`
+	current := util.GetCurrentUtcTime(7)
+	for _, option := range options {
+		if _, err = tx.Exec(variantQuery, entity.LegacyVariantId(option[0], option[1], option[2]), option[0], option[1], option[2], current); err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+
+	inventoryQuery := `
+		UPDATE inventory i
+		SET
+			variant_id = v.id,
+			updated_at = $1
+		FROM variant v
+		WHERE i.variant_id IS NULL
+			AND v.product_id = i.product_id AND v.color_id = i.color_id AND v.size_id = i.size_id
+			AND v.deleted_at IS NULL
+	`
+	if _, err = tx.Exec(inventoryQuery, current); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	return len(options), tx.Commit()
+}
+
+func (v *VariantRepository) getVariant(where string, arg any) (*entity.Variant, error) {
+	variant, err := scanVariant(v.db.QueryRow(selectVariant+where, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return variant, nil
+}
+
+func scanVariant(row rowScanner) (*entity.Variant, error) {
+	var variant entity.Variant
+	if err := row.Scan(&variant.Id, &variant.ProductId, &variant.ColorId, &variant.SizeId, &variant.Sku, &variant.Barcode, &variant.Weight, &variant.IsActive, &variant.CreatedAt, &variant.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &variant, nil
+}
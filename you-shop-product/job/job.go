@@ -2,13 +2,18 @@ package job
 
 import (
 	"encoding/json"
+	"net/http"
+	"time"
 
 	"github.com/TechwizsonORG/product-service/background"
 	"github.com/TechwizsonORG/product-service/infrastructure/rpc"
 	"github.com/TechwizsonORG/product-service/usecase/inventory"
+	inventoryModel "github.com/TechwizsonORG/product-service/usecase/inventory/model"
 	messagequeue "github.com/TechwizsonORG/product-service/usecase/message_queue"
 	"github.com/TechwizsonORG/product-service/usecase/message_queue/event"
 	"github.com/TechwizsonORG/product-service/usecase/product"
+	"github.com/TechwizsonORG/product-service/usecase/reservation"
+	reservationModel "github.com/TechwizsonORG/product-service/usecase/reservation/model"
 	"github.com/TechwizsonORG/product-service/usecase/rpc/model"
 	"github.com/rs/zerolog"
 )
@@ -39,7 +44,7 @@ func (j *Job) CheckProductQuantity(rpc rpc.Service, productService product.UseCa
 				return string(defaultRes)
 			}
 
-			ok, checkErr := productService.CheckProductQuantity(checkProductQuantity.ProductId, checkProductQuantity.ColorId, checkProductQuantity.SizeId, checkProductQuantity.RequireQuantity)
+			ok, checkErr := productService.CheckProductQuantity(checkProductQuantity.VariantId, checkProductQuantity.RequireQuantity)
 			if checkErr != nil {
 				j.logger.Error().Err(checkErr).Msg("")
 				return string(defaultRes)
@@ -79,22 +84,106 @@ func (j *Job) GetProductByIds(rpcService rpc.Service, productService product.Use
 	}
 }
 
-func (j *Job) OrderUpdatedHandler(msq messagequeue.MessageQueue, inventory inventory.InventoryUseCase) background.JobFunc {
+func (j *Job) ReserveInventory(rpcService rpc.Service, reservationService reservation.ReservationUseCase) background.JobFunc {
 	return func() {
-		msq.Consume(
+		rpcService.NewRpcQueue("reserve_inventory", func(data string) string {
+			defaultRes, _ := json.Marshal(model.ReserveInventoryResponse{IsReserved: false})
+			var request model.ReserveInventoryRequest
+			if parseErr := json.Unmarshal([]byte(data), &request); parseErr != nil {
+				j.logger.Error().Err(parseErr).Msg("")
+				return string(defaultRes)
+			}
+			items := make([]reservationModel.ReserveItem, 0, len(request.Items))
+			for _, item := range request.Items {
+				items = append(items, reservationModel.ReserveItem{VariantId: item.VariantId, Quantity: item.Quantity})
+			}
+			result, reserveErr := reservationService.Reserve(request.OrderId, items)
+			if reserveErr != nil {
+				j.logger.Error().Err(reserveErr).Msg("")
+				return string(defaultRes)
+			}
+			res, parseErr := json.Marshal(model.ReserveInventoryResponse{
+				IsReserved:      result.IsReserved,
+				ExpiresAt:       result.ExpiresAt,
+				ShortVariantIds: result.ShortVariantIds,
+			})
+			if parseErr != nil {
+				j.logger.Error().Err(parseErr).Msg("")
+				return string(defaultRes)
+			}
+			return string(res)
+		})
+	}
+}
+
+func (j *Job) ReleaseInventory(rpcService rpc.Service, reservationService reservation.ReservationUseCase) background.JobFunc {
+	return func() {
+		rpcService.NewRpcQueue("release_inventory", func(data string) string {
+			response := model.ReleaseInventoryResponse{IsReleased: false}
+			defaultRes, _ := json.Marshal(response)
+			var request model.ReleaseInventoryRequest
+			if parseErr := json.Unmarshal([]byte(data), &request); parseErr != nil {
+				j.logger.Error().Err(parseErr).Msg("")
+				return string(defaultRes)
+			}
+			if releaseErr := reservationService.Release(request.OrderId); releaseErr != nil {
+				return string(defaultRes)
+			}
+			response.IsReleased = true
+			res, _ := json.Marshal(response)
+			return string(res)
+		})
+	}
+}
+
+func (j *Job) ReleaseExpiredReservations(reservationService reservation.ReservationUseCase, interval time.Duration) background.JobFunc {
+	return func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			j.logger.Debug().Msg("Release expired reservations from background job")
+			if releaseErr := reservationService.ReleaseExpired(); releaseErr != nil {
+				j.logger.Error().Err(releaseErr).Msg("")
+			}
+		}
+	}
+}
+
+func (j *Job) OrderUpdatedHandler(msq messagequeue.MessageQueue, inventory inventory.InventoryUseCase, reservationService reservation.ReservationUseCase) background.JobFunc {
+	return func() {
+		msq.ConsumeDurable(
 			*messagequeue.NewDefaultExchangeConfig("you_shop", messagequeue.Topic),
-			*messagequeue.NewDefaultQueueConfig("product_consumer_updated_order", "order.updated"),
+			*messagequeue.NewDurableQueueConfig("product_consumer_updated_order", "order.updated"),
 			func(data string) error {
 				var updatedOrderEvent event.UpdatedOrderEvent
-				json.Unmarshal([]byte(data), &updatedOrderEvent)
+				if parseErr := json.Unmarshal([]byte(data), &updatedOrderEvent); parseErr != nil {
+					// A malformed event never succeeds, retrying it would block the queue
+					j.logger.Error().Err(parseErr).Msgf("Dropped malformed order updated event %s", data)
+					return nil
+				}
 				if updatedOrderEvent.Status == event.Confirmed {
-					for _ , item := range updatedOrderEvent.Items {
-						inventory.ChangeQuantity(item.ProductId, item.ColorId, item.SizeId, -item.Quantity)
+					items := make([]reservationModel.ReserveItem, 0, len(updatedOrderEvent.Items))
+					for _, item := range updatedOrderEvent.Items {
+						items = append(items, reservationModel.ReserveItem{VariantId: item.VariantId, Quantity: item.Quantity})
+					}
+					commitErr := reservationService.Commit(updatedOrderEvent.Id, items)
+					// Retrying doesn't bring the missing stock back, the shortage is logged for the staff to handle
+					if commitErr != nil && commitErr.Code() != http.StatusConflict {
+						return commitErr
+					}
+				}
+				if updatedOrderEvent.Status == event.Canceled || updatedOrderEvent.Status == event.Failed {
+					if releaseErr := reservationService.Release(updatedOrderEvent.Id); releaseErr != nil {
+						return releaseErr
 					}
 				}
 				if updatedOrderEvent.Status == event.Returned {
-					for _ , item := range updatedOrderEvent.Items {
-						inventory.ChangeQuantity(item.ProductId, item.ColorId, item.SizeId, item.Quantity)
+					adjustments := make([]inventoryModel.QuantityAdjustment, 0, len(updatedOrderEvent.Items))
+					for _, item := range updatedOrderEvent.Items {
+						adjustments = append(adjustments, inventoryModel.QuantityAdjustment{VariantId: item.VariantId, ChangeAmount: item.Quantity})
+					}
+					if adjustErr := inventory.AdjustQuantities(adjustments); adjustErr != nil {
+						return adjustErr
 					}
 				}
 				return nil
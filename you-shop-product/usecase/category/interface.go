@@ -0,0 +1,35 @@
+package category
+
+import (
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/category/model"
+	"github.com/google/uuid"
+)
+
+type CategoryUseCase interface {
+	GetCategoryTree() []*model.CategoryNode
+	GetCategory(id uuid.UUID) (*entity.Category, err.ApplicationError)
+	AddCategory(name, slug string, parentId uuid.UUID, sortOrder int) (*entity.Category, err.ApplicationError)
+	UpdateCategory(id uuid.UUID, name, slug string, parentId uuid.UUID, sortOrder int) (*entity.Category, err.ApplicationError)
+	DeleteCategory(id uuid.UUID) err.ApplicationError
+	AddProduct(categoryId, productId uuid.UUID) err.ApplicationError
+	RemoveProduct(categoryId, productId uuid.UUID) err.ApplicationError
+	// GetCategoryProducts includes the products of the descendant categories
+	GetCategoryProducts(slug string, page int, pageSize int) (count int, products []entity.Product, appErr err.ApplicationError)
+}
+
+type CategoryRepository interface {
+	AddCategory(*entity.Category) error
+	UpdateCategory(*entity.Category) error
+	DeleteCategory(id uuid.UUID) error
+	GetCategories() ([]entity.Category, error)
+	GetCategoryById(id uuid.UUID) (*entity.Category, error)
+	GetCategoryBySlug(slug string) (*entity.Category, error)
+	IsSlugExisted(slug string, excludeId uuid.UUID) (bool, error)
+	HasChildren(id uuid.UUID) (bool, error)
+	AddProduct(categoryId, productId uuid.UUID) error
+	RemoveProduct(categoryId, productId uuid.UUID) (bool, error)
+	CountProducts(categoryId uuid.UUID) (int, error)
+	GetProducts(categoryId uuid.UUID, page int, pageSize int) ([]entity.Product, error)
+}
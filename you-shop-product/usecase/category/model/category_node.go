@@ -0,0 +1,8 @@
+package model
+
+import "github.com/TechwizsonORG/product-service/entity"
+
+type CategoryNode struct {
+	entity.Category
+	Children []*CategoryNode
+}
@@ -0,0 +1,217 @@
+package category
+
+import (
+	"strings"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/category/model"
+	"github.com/TechwizsonORG/product-service/usecase/product"
+	"github.com/TechwizsonORG/product-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type CategoryService struct {
+	logger       zerolog.Logger
+	categoryRepo CategoryRepository
+	productRepo  product.Reader
+}
+
+func NewCategoryService(logger zerolog.Logger, categoryRepo CategoryRepository, productRepo product.Reader) *CategoryService {
+	logger = logger.With().Str("usecase", "category").Logger()
+	return &CategoryService{
+		logger:       logger,
+		categoryRepo: categoryRepo,
+		productRepo:  productRepo,
+	}
+}
+
+func (c *CategoryService) GetCategoryTree() []*model.CategoryNode {
+	categories, getErr := c.categoryRepo.GetCategories()
+	if getErr != nil {
+		c.logger.Error().Err(getErr).Msg("")
+		return make([]*model.CategoryNode, 0)
+	}
+	nodes := make(map[uuid.UUID]*model.CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.Id] = &model.CategoryNode{Category: category, Children: []*model.CategoryNode{}}
+	}
+	// Categories are sorted, appending in the same order keeps the children sorted
+	roots := make([]*model.CategoryNode, 0)
+	for _, category := range categories {
+		node := nodes[category.Id]
+		if parent, ok := nodes[category.ParentId]; ok {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+func (c *CategoryService) GetCategory(id uuid.UUID) (*entity.Category, err.ApplicationError) {
+	category, getErr := c.categoryRepo.GetCategoryById(id)
+	if getErr != nil {
+		c.logger.Error().Err(getErr).Msg("")
+		return nil, err.CommonError()
+	}
+	if category == nil {
+		return nil, err.NotFoundProductError("category not found")
+	}
+	return category, nil
+}
+
+func (c *CategoryService) AddCategory(name, slug string, parentId uuid.UUID, sortOrder int) (*entity.Category, err.ApplicationError) {
+	name, slug, validateErr := c.validateCategory(uuid.Nil, name, slug, parentId)
+	if validateErr != nil {
+		return nil, validateErr
+	}
+	category := entity.NewCategory(name, slug, parentId, sortOrder)
+	if addErr := c.categoryRepo.AddCategory(category); addErr != nil {
+		c.logger.Error().Err(addErr).Msg("")
+		return nil, err.NewProductError(500, "adding category failed", "adding category failed", nil)
+	}
+	return category, nil
+}
+
+func (c *CategoryService) UpdateCategory(id uuid.UUID, name, slug string, parentId uuid.UUID, sortOrder int) (*entity.Category, err.ApplicationError) {
+	category, getErr := c.GetCategory(id)
+	if getErr != nil {
+		return nil, getErr
+	}
+	name, slug, validateErr := c.validateCategory(id, name, slug, parentId)
+	if validateErr != nil {
+		return nil, validateErr
+	}
+	if parentId != uuid.Nil && c.isSelfOrDescendant(id, parentId) {
+		return nil, err.NewProductError(400, "invalid parent category", "a category can't be moved under itself or its descendants", nil)
+	}
+	category.Update(name, slug, parentId, sortOrder)
+	if updateErr := c.categoryRepo.UpdateCategory(category); updateErr != nil {
+		c.logger.Error().Err(updateErr).Msg("")
+		return nil, err.NewProductError(500, "updating category failed", "updating category failed", nil)
+	}
+	return category, nil
+}
+
+func (c *CategoryService) DeleteCategory(id uuid.UUID) err.ApplicationError {
+	if _, getErr := c.GetCategory(id); getErr != nil {
+		return getErr
+	}
+	hasChildren, checkErr := c.categoryRepo.HasChildren(id)
+	if checkErr != nil {
+		c.logger.Error().Err(checkErr).Msg("")
+		return err.CommonError()
+	}
+	if hasChildren {
+		return err.NewProductError(409, "category has children", "move or delete the child categories first", nil)
+	}
+	if deleteErr := c.categoryRepo.DeleteCategory(id); deleteErr != nil {
+		c.logger.Error().Err(deleteErr).Msg("")
+		return err.NewProductError(500, "deleting category failed", "deleting category failed", nil)
+	}
+	return nil
+}
+
+func (c *CategoryService) AddProduct(categoryId, productId uuid.UUID) err.ApplicationError {
+	if _, getErr := c.GetCategory(categoryId); getErr != nil {
+		return getErr
+	}
+	isExisted, checkErr := c.productRepo.IsIdExisted(productId)
+	if checkErr != nil {
+		return checkErr
+	}
+	if !isExisted {
+		return err.NotFoundProductErrorWithId(productId.String())
+	}
+	if addErr := c.categoryRepo.AddProduct(categoryId, productId); addErr != nil {
+		c.logger.Error().Err(addErr).Msg("")
+		return err.NewProductError(500, "adding product to category failed", "adding product to category failed", nil)
+	}
+	return nil
+}
+
+func (c *CategoryService) RemoveProduct(categoryId, productId uuid.UUID) err.ApplicationError {
+	isRemoved, removeErr := c.categoryRepo.RemoveProduct(categoryId, productId)
+	if removeErr != nil {
+		c.logger.Error().Err(removeErr).Msg("")
+		return err.NewProductError(500, "removing product from category failed", "removing product from category failed", nil)
+	}
+	if !isRemoved {
+		return err.NotFoundProductError("product is not in the category")
+	}
+	return nil
+}
+
+func (c *CategoryService) GetCategoryProducts(slug string, page int, pageSize int) (int, []entity.Product, err.ApplicationError) {
+	category, getErr := c.categoryRepo.GetCategoryBySlug(slug)
+	if getErr != nil {
+		c.logger.Error().Err(getErr).Msg("")
+		return 0, nil, err.CommonError()
+	}
+	if category == nil {
+		return 0, nil, err.NotFoundProductError("category not found")
+	}
+	count, countErr := c.categoryRepo.CountProducts(category.Id)
+	if countErr != nil {
+		c.logger.Error().Err(countErr).Msg("")
+		return 0, nil, err.CommonError()
+	}
+	products, getProductsErr := c.categoryRepo.GetProducts(category.Id, page, pageSize)
+	if getProductsErr != nil {
+		c.logger.Error().Err(getProductsErr).Msg("")
+		return 0, nil, err.CommonError()
+	}
+	return count, products, nil
+}
+
+// validateCategory trims the name and fills the slug from the name when it's empty
+func (c *CategoryService) validateCategory(id uuid.UUID, name, slug string, parentId uuid.UUID) (string, string, err.ApplicationError) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "", err.NewProductError(400, "name is required", "name is required", nil)
+	}
+	if slug == "" {
+		slug = util.Slugify(name)
+	}
+	if !util.IsSlug(slug) {
+		return "", "", err.NewProductError(400, "invalid slug", "slug must only contain lowercase letters, digits and hyphens", nil)
+	}
+	isExisted, checkErr := c.categoryRepo.IsSlugExisted(slug, id)
+	if checkErr != nil {
+		c.logger.Error().Err(checkErr).Msg("")
+		return "", "", err.CommonError()
+	}
+	if isExisted {
+		return "", "", err.NewProductError(409, "slug already existed", "slug already existed", nil)
+	}
+	if parentId != uuid.Nil {
+		if _, getErr := c.GetCategory(parentId); getErr != nil {
+			return "", "", err.NewProductError(400, "parent category not found", "parent category not found", nil)
+		}
+	}
+	return name, slug, nil
+}
+
+// isSelfOrDescendant reports whether the category target is the category id or one of its descendants
+func (c *CategoryService) isSelfOrDescendant(id, target uuid.UUID) bool {
+	categories, getErr := c.categoryRepo.GetCategories()
+	if getErr != nil {
+		c.logger.Error().Err(getErr).Msg("")
+		return true
+	}
+	parents := make(map[uuid.UUID]uuid.UUID, len(categories))
+	for _, category := range categories {
+		parents[category.Id] = category.ParentId
+	}
+	// Walking more steps than there are categories means the tree already has a cycle
+	current := target
+	for i := 0; current != uuid.Nil && i <= len(categories); i++ {
+		if current == id {
+			return true
+		}
+		current = parents[current]
+	}
+	return current != uuid.Nil
+}
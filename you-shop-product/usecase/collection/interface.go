@@ -0,0 +1,31 @@
+package collection
+
+import (
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/google/uuid"
+)
+
+type CollectionUseCase interface {
+	GetCollections() []entity.Collection
+	GetCollection(id uuid.UUID) (*entity.Collection, err.ApplicationError)
+	AddCollection(name, slug, description string) (*entity.Collection, err.ApplicationError)
+	UpdateCollection(id uuid.UUID, name, slug, description string) (*entity.Collection, err.ApplicationError)
+	DeleteCollection(id uuid.UUID) err.ApplicationError
+	// SetProducts replaces the products of the collection, the given order is the display order
+	SetProducts(id uuid.UUID, productIds []uuid.UUID) err.ApplicationError
+	GetCollectionProducts(slug string, page int, pageSize int) (count int, products []entity.Product, appErr err.ApplicationError)
+}
+
+type CollectionRepository interface {
+	AddCollection(*entity.Collection) error
+	UpdateCollection(*entity.Collection) error
+	DeleteCollection(id uuid.UUID) error
+	GetCollections() ([]entity.Collection, error)
+	GetCollectionById(id uuid.UUID) (*entity.Collection, error)
+	GetCollectionBySlug(slug string) (*entity.Collection, error)
+	IsSlugExisted(slug string, excludeId uuid.UUID) (bool, error)
+	SetProducts(collectionId uuid.UUID, productIds []uuid.UUID) error
+	CountProducts(collectionId uuid.UUID) (int, error)
+	GetProducts(collectionId uuid.UUID, page int, pageSize int) ([]entity.Product, error)
+}
@@ -0,0 +1,159 @@
+package collection
+
+import (
+	"strings"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/product"
+	"github.com/TechwizsonORG/product-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type CollectionService struct {
+	logger         zerolog.Logger
+	collectionRepo CollectionRepository
+	productRepo    product.Reader
+}
+
+func NewCollectionService(logger zerolog.Logger, collectionRepo CollectionRepository, productRepo product.Reader) *CollectionService {
+	logger = logger.With().Str("usecase", "collection").Logger()
+	return &CollectionService{
+		logger:         logger,
+		collectionRepo: collectionRepo,
+		productRepo:    productRepo,
+	}
+}
+
+func (c *CollectionService) GetCollections() []entity.Collection {
+	collections, getErr := c.collectionRepo.GetCollections()
+	if getErr != nil {
+		c.logger.Error().Err(getErr).Msg("")
+		return make([]entity.Collection, 0)
+	}
+	return collections
+}
+
+func (c *CollectionService) GetCollection(id uuid.UUID) (*entity.Collection, err.ApplicationError) {
+	collection, getErr := c.collectionRepo.GetCollectionById(id)
+	if getErr != nil {
+		c.logger.Error().Err(getErr).Msg("")
+		return nil, err.CommonError()
+	}
+	if collection == nil {
+		return nil, err.NotFoundProductError("collection not found")
+	}
+	return collection, nil
+}
+
+func (c *CollectionService) AddCollection(name, slug, description string) (*entity.Collection, err.ApplicationError) {
+	name, slug, validateErr := c.validateCollection(uuid.Nil, name, slug)
+	if validateErr != nil {
+		return nil, validateErr
+	}
+	collection := entity.NewCollection(name, slug, description)
+	if addErr := c.collectionRepo.AddCollection(collection); addErr != nil {
+		c.logger.Error().Err(addErr).Msg("")
+		return nil, err.NewProductError(500, "adding collection failed", "adding collection failed", nil)
+	}
+	return collection, nil
+}
+
+func (c *CollectionService) UpdateCollection(id uuid.UUID, name, slug, description string) (*entity.Collection, err.ApplicationError) {
+	collection, getErr := c.GetCollection(id)
+	if getErr != nil {
+		return nil, getErr
+	}
+	name, slug, validateErr := c.validateCollection(id, name, slug)
+	if validateErr != nil {
+		return nil, validateErr
+	}
+	collection.Update(name, slug, description)
+	if updateErr := c.collectionRepo.UpdateCollection(collection); updateErr != nil {
+		c.logger.Error().Err(updateErr).Msg("")
+		return nil, err.NewProductError(500, "updating collection failed", "updating collection failed", nil)
+	}
+	return collection, nil
+}
+
+func (c *CollectionService) DeleteCollection(id uuid.UUID) err.ApplicationError {
+	if _, getErr := c.GetCollection(id); getErr != nil {
+		return getErr
+	}
+	if deleteErr := c.collectionRepo.DeleteCollection(id); deleteErr != nil {
+		c.logger.Error().Err(deleteErr).Msg("")
+		return err.NewProductError(500, "deleting collection failed", "deleting collection failed", nil)
+	}
+	return nil
+}
+
+func (c *CollectionService) SetProducts(id uuid.UUID, productIds []uuid.UUID) err.ApplicationError {
+	if _, getErr := c.GetCollection(id); getErr != nil {
+		return getErr
+	}
+	isAdded := make(map[uuid.UUID]bool, len(productIds))
+	for _, productId := range productIds {
+		if isAdded[productId] {
+			return err.NewProductError(400, "duplicated product", "product "+productId.String()+" is listed more than once", nil)
+		}
+		isAdded[productId] = true
+		isExisted, checkErr := c.productRepo.IsIdExisted(productId)
+		if checkErr != nil {
+			return checkErr
+		}
+		if !isExisted {
+			return err.NotFoundProductErrorWithId(productId.String())
+		}
+	}
+	if setErr := c.collectionRepo.SetProducts(id, productIds); setErr != nil {
+		c.logger.Error().Err(setErr).Msg("")
+		return err.NewProductError(500, "updating collection products failed", "updating collection products failed", nil)
+	}
+	return nil
+}
+
+func (c *CollectionService) GetCollectionProducts(slug string, page int, pageSize int) (int, []entity.Product, err.ApplicationError) {
+	collection, getErr := c.collectionRepo.GetCollectionBySlug(slug)
+	if getErr != nil {
+		c.logger.Error().Err(getErr).Msg("")
+		return 0, nil, err.CommonError()
+	}
+	if collection == nil {
+		return 0, nil, err.NotFoundProductError("collection not found")
+	}
+	count, countErr := c.collectionRepo.CountProducts(collection.Id)
+	if countErr != nil {
+		c.logger.Error().Err(countErr).Msg("")
+		return 0, nil, err.CommonError()
+	}
+	products, getProductsErr := c.collectionRepo.GetProducts(collection.Id, page, pageSize)
+	if getProductsErr != nil {
+		c.logger.Error().Err(getProductsErr).Msg("")
+		return 0, nil, err.CommonError()
+	}
+	return count, products, nil
+}
+
+// validateCollection trims the name and fills the slug from the name when it's empty
+func (c *CollectionService) validateCollection(id uuid.UUID, name, slug string) (string, string, err.ApplicationError) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "", err.NewProductError(400, "name is required", "name is required", nil)
+	}
+	if slug == "" {
+		slug = util.Slugify(name)
+	}
+	if !util.IsSlug(slug) {
+		return "", "", err.NewProductError(400, "invalid slug", "slug must only contain lowercase letters, digits and hyphens", nil)
+	}
+	isExisted, checkErr := c.collectionRepo.IsSlugExisted(slug, id)
+	if checkErr != nil {
+		c.logger.Error().Err(checkErr).Msg("")
+		return "", "", err.CommonError()
+	}
+	if isExisted {
+		return "", "", err.NewProductError(409, "slug already existed", "slug already existed", nil)
+	}
+	return name, slug, nil
+}
@@ -7,7 +7,10 @@ import (
 )
 
 type InventoryUseCase interface {
+	// AddInventories creates a variant for every inventory
 	AddInventories([]model.CreateInventory) appErr.ApplicationError
-	UpdateInventory(productId, colorId, sizeId uuid.UUID, quantity int, price float64) appErr.ApplicationError
-	ChangeQuantity(productId, colorId, sizeId uuid.UUID, changeAmount int) appErr.ApplicationError
+	UpdateInventory(productId, variantId uuid.UUID, quantity int, price float64) appErr.ApplicationError
+	ChangeQuantity(variantId uuid.UUID, changeAmount int) appErr.ApplicationError
+	// AdjustQuantities applies all adjustments or none of them
+	AdjustQuantities([]model.QuantityAdjustment) appErr.ApplicationError
 }
@@ -3,9 +3,20 @@ package model
 import "github.com/google/uuid"
 
 type CreateInventory struct {
+	// Generated when empty, the variant is created along with its inventory
+	VariantId uuid.UUID
 	ProductId uuid.UUID
 	ColorId   uuid.UUID
 	SizeId    uuid.UUID
+	Sku       string
+	Barcode   string
+	Weight    float64
 	Price     float64
 	Quantity  int
 }
+
+// QuantityAdjustment changes the on-hand quantity of a variant, a negative amount takes stock out
+type QuantityAdjustment struct {
+	VariantId    uuid.UUID
+	ChangeAmount int
+}
@@ -7,8 +7,14 @@ import (
 )
 
 type InventoryRepository interface {
-	GetQuantity(productId, sizeId, colorId uuid.UUID) (int, error)
+	GetQuantity(variantId uuid.UUID) (int, error)
 	AddInventories(createInventories []model.CreateInventory) error
-	GetInventory(productId uuid.UUID, colorId uuid.UUID, sizeId uuid.UUID) (*entity.Inventory, error)
-	UpdateInventory(updateInventory *entity.Inventory) error
+	GetInventory(variantId uuid.UUID) (*entity.Inventory, error)
+	// UpdateInventory sets the quantity only when the inventory belongs to the product and the quantity covers the live reservations
+	UpdateInventory(updateInventory *entity.Inventory) (bool, error)
+	// AdjustQuantity applies the change only when the quantity still covers the live reservations
+	AdjustQuantity(variantId uuid.UUID, changeAmount int) (bool, error)
+	// AdjustQuantities applies the adjustments in one transaction, nothing is applied when one of them
+	// would take out reserved stock and that variant is returned
+	AdjustQuantities(adjustments []model.QuantityAdjustment) (failedVariantId uuid.UUID, e error)
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	configModel "github.com/TechwizsonORG/product-service/config/model"
+	"github.com/TechwizsonORG/product-service/entity"
 	"github.com/TechwizsonORG/product-service/err"
 	"github.com/TechwizsonORG/product-service/usecase/event"
 	"github.com/TechwizsonORG/product-service/usecase/inventory/model"
@@ -34,6 +35,11 @@ func NewInventoryService(logger zerolog.Logger, inventoryRepo InventoryRepositor
 }
 
 func (i *InventoryService) AddInventories(createInventories []model.CreateInventory) err.ApplicationError {
+	for index := range createInventories {
+		if createInventories[index].VariantId == uuid.Nil {
+			createInventories[index].VariantId = uuid.New()
+		}
+	}
 	createErr := i.inventoryRepo.AddInventories(createInventories)
 	if createErr != nil {
 		i.logger.Error().Err(createErr).Msg("")
@@ -48,20 +54,18 @@ func (i *InventoryService) AddInventories(createInventories []model.CreateInvent
 	)
 	return nil
 }
-func (i *InventoryService) UpdateInventory(productId, colorId, sizeId uuid.UUID, quantity int, price float64) err.ApplicationError {
-
-	updateInventory, getErr := i.inventoryRepo.GetInventory(productId, colorId, sizeId)
-	if getErr != nil {
-		i.logger.Error().Err(getErr).Msg("")
+func (i *InventoryService) UpdateInventory(productId, variantId uuid.UUID, quantity int, price float64) err.ApplicationError {
+	// The quantity is checked before the price is changed so a rejected update changes nothing
+	ok, updateErr := i.inventoryRepo.UpdateInventory(&entity.Inventory{VariantId: variantId, ProductId: productId, Quantity: quantity})
+	if updateErr != nil {
+		i.logger.Error().Err(updateErr).Msg("")
 		return err.CommonError()
 	}
-	if updateInventory == nil {
-		return err.NotFoundProductError("not found inventory")
+	if !ok {
+		return i.updateFailedError(productId, variantId)
 	}
 	jsonReq, _ := json.Marshal(rpcModel.UpdatePriceRequest{
-		ProductId: productId,
-		ColorId:   colorId,
-		SizeId:    sizeId,
+		VariantId: variantId,
 		Price:     price,
 	})
 	result := i.rpcService.Req(i.rpcEndpoint.UpdatePrice, string(jsonReq))
@@ -70,31 +74,71 @@ func (i *InventoryService) UpdateInventory(productId, colorId, sizeId uuid.UUID,
 	if !res.IsUpdated {
 		return err.NewProductError(500, "updateing price failed", "updateing price failed", nil)
 	}
+	return nil
+}
 
-	updateInventory.Quantity = quantity
-	updateErr := i.inventoryRepo.UpdateInventory(updateInventory)
-	if updateErr != nil {
-		i.logger.Error().Err(updateErr).Msg("")
+// updateFailedError tells a missing inventory from a quantity below the reserved stock
+func (i *InventoryService) updateFailedError(productId, variantId uuid.UUID) err.ApplicationError {
+	inventory, getErr := i.inventoryRepo.GetInventory(variantId)
+	if getErr != nil {
+		i.logger.Error().Err(getErr).Msg("")
+		return err.CommonError()
+	}
+	if inventory == nil || inventory.ProductId != productId {
+		return err.NotFoundProductError("not found inventory")
+	}
+	return err.NewProductError(409, "quantity below reserved stock", "quantity of variant "+variantId.String()+" can't be less than its reserved stock", nil)
+}
+
+func (i *InventoryService) ChangeQuantity(variantId uuid.UUID, changeAmount int) err.ApplicationError {
+	ok, adjustErr := i.inventoryRepo.AdjustQuantity(variantId, changeAmount)
+	if adjustErr != nil {
+		i.logger.Error().Err(adjustErr).Msg("")
 		return err.CommonError()
 	}
+	if !ok {
+		return i.adjustFailedError(variantId)
+	}
 	return nil
 }
 
-func (i *InventoryService) ChangeQuantity(productId, colorId, sizeId uuid.UUID, changeAmount int) err.ApplicationError {
-	inventory, getErr := i.inventoryRepo.GetInventory(productId, colorId, sizeId)
-	if getErr != nil {
-		i.logger.Error().Err(getErr).Msg("")
+func (i *InventoryService) AdjustQuantities(adjustments []model.QuantityAdjustment) err.ApplicationError {
+	if len(adjustments) == 0 {
+		return err.NewProductError(400, "adjustments are required", "adjustments are required", nil)
+	}
+	// Adjustments of the same variant are summed so each inventory is updated once
+	changeAmounts := make(map[uuid.UUID]int, len(adjustments))
+	merged := make([]model.QuantityAdjustment, 0, len(adjustments))
+	for _, adjustment := range adjustments {
+		if _, ok := changeAmounts[adjustment.VariantId]; !ok {
+			merged = append(merged, model.QuantityAdjustment{VariantId: adjustment.VariantId})
+		}
+		changeAmounts[adjustment.VariantId] += adjustment.ChangeAmount
+	}
+	for index := range merged {
+		merged[index].ChangeAmount = changeAmounts[merged[index].VariantId]
+	}
+
+	failedVariantId, adjustErr := i.inventoryRepo.AdjustQuantities(merged)
+	if adjustErr != nil {
+		i.logger.Error().Err(adjustErr).Msg("")
 		return err.CommonError()
 	}
-	if inventory == nil {
-		return err.NewProductError(404, "counldn't found inventory", "counldn't found inventory", nil)
+	if failedVariantId != uuid.Nil {
+		return i.adjustFailedError(failedVariantId)
 	}
+	return nil
+}
 
-	inventory.Quantity = inventory.Quantity + changeAmount
-	updateErr := i.inventoryRepo.UpdateInventory(inventory)
-	if updateErr != nil {
+// adjustFailedError tells a missing inventory from one without enough quantity
+func (i *InventoryService) adjustFailedError(variantId uuid.UUID) err.ApplicationError {
+	inventory, getErr := i.inventoryRepo.GetInventory(variantId)
+	if getErr != nil {
 		i.logger.Error().Err(getErr).Msg("")
 		return err.CommonError()
 	}
-	return nil
+	if inventory == nil {
+		return err.NewProductError(404, "counldn't found inventory", "counldn't found inventory of variant "+variantId.String(), nil)
+	}
+	return err.NewProductError(409, "not enough quantity", "not enough unreserved quantity of variant "+variantId.String(), nil)
 }
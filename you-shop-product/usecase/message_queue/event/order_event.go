@@ -32,6 +32,7 @@ type UpdatedOrderEvent struct {
 
 type UpdatedOrderItem struct {
 	Quantity  int       `json:"quantity"`
+	VariantId uuid.UUID `json:"variantId"`
 	ProductId uuid.UUID `json:"productId"`
 	ColorId   uuid.UUID `json:"colorId"`
 	SizeId    uuid.UUID `json:"sizeId"`
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/google/uuid"
+)
+
+type ProductSearchFilter struct {
+	// Free text matched against name, description and sku, accents are ignored.
+	// A variant sku or barcode matches exactly
+	Query    string
+	ColorIds []uuid.UUID
+	SizeIds  []uuid.UUID
+	// Zero matches every status
+	Status   entity.ProductStatus
+	MinPrice float64
+	// Zero means there is no upper bound
+	MaxPrice float64
+	// Restricts the result to these products, nil means no restriction
+	ProductIds []uuid.UUID
+}
+
+func (f ProductSearchFilter) HasPriceRange() bool {
+	return f.MinPrice > 0 || f.MaxPrice > 0
+}
+
+type Facet struct {
+	Id    uuid.UUID
+	Name  string
+	Count int
+}
+
+type ProductSearchResult struct {
+	Products    []entity.Product
+	Total       int
+	ColorFacets []Facet
+	SizeFacets  []Facet
+}
@@ -3,11 +3,15 @@ package product
 import (
 	"github.com/TechwizsonORG/product-service/entity"
 	appErr "github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/product/model"
 	"github.com/google/uuid"
 )
 
 type Reader interface {
-	Search(query string) ([]entity.Product, appErr.ApplicationError)
+	Search(filter model.ProductSearchFilter, page int, pageSize int) ([]entity.Product, appErr.ApplicationError)
+	CountSearch(filter model.ProductSearchFilter) (int, appErr.ApplicationError)
+	GetSearchColorFacets(filter model.ProductSearchFilter) ([]model.Facet, appErr.ApplicationError)
+	GetSearchSizeFacets(filter model.ProductSearchFilter) ([]model.Facet, appErr.ApplicationError)
 	List(page int, pageSize int) ([]entity.Product, appErr.ApplicationError)
 	Get(id uuid.UUID) (*entity.Product, appErr.ApplicationError)
 	Count() (int, appErr.ApplicationError)
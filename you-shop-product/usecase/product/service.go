@@ -2,20 +2,22 @@ package product
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"strings"
 	"sync"
-	"time"
 
 	"github.com/TechwizsonORG/product-service/config/model"
 	"github.com/TechwizsonORG/product-service/entity"
 	"github.com/TechwizsonORG/product-service/err"
 	"github.com/TechwizsonORG/product-service/usecase/inventory"
 	messagequeue "github.com/TechwizsonORG/product-service/usecase/message_queue"
+	productModel "github.com/TechwizsonORG/product-service/usecase/product/model"
 	"github.com/TechwizsonORG/product-service/usecase/rpc"
+	rpcModel "github.com/TechwizsonORG/product-service/usecase/rpc/model"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 )
@@ -28,9 +30,11 @@ type Service struct {
 	rpcService    rpc.RpcInterface
 	httpEndpoint  model.HttpEndpoint
 	rpcEndpoint   model.RpcServerEndpoint
+	// Authenticates the calls to other services
+	httpClient *http.Client
 }
 
-func NewService(httpEndpoint model.HttpEndpoint, repo ProductRepository, log zerolog.Logger, msgQueue messagequeue.MessageQueue, rpcService rpc.RpcInterface, rpcEndpoint model.RpcServerEndpoint, inventoryRepo inventory.InventoryRepository) *Service {
+func NewService(httpEndpoint model.HttpEndpoint, repo ProductRepository, log zerolog.Logger, msgQueue messagequeue.MessageQueue, rpcService rpc.RpcInterface, rpcEndpoint model.RpcServerEndpoint, inventoryRepo inventory.InventoryRepository, httpClient *http.Client) *Service {
 	logger := log.
 		With().
 		Str("product", "service").
@@ -43,16 +47,70 @@ func NewService(httpEndpoint model.HttpEndpoint, repo ProductRepository, log zer
 		rpcService:    rpcService,
 		rpcEndpoint:   rpcEndpoint,
 		inventoryRepo: inventoryRepo,
+		httpClient:    httpClient,
 	}
 }
 
-func (s *Service) SearchProducts(query string) []entity.Product {
-	products, err := s.productRepo.Search(query)
-	if err != nil {
-		s.logger.Err(err).Msg("")
-		return []entity.Product{}
+func (s *Service) SearchProducts(filter productModel.ProductSearchFilter, page int, pageSize int) (*productModel.ProductSearchResult, err.ApplicationError) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.HasPriceRange() {
+		productIds, filterErr := s.filterProductsByPrice(filter.MinPrice, filter.MaxPrice)
+		if filterErr != nil {
+			return nil, filterErr
+		}
+		filter.ProductIds = productIds
 	}
-	return products
+
+	total, countErr := s.productRepo.CountSearch(filter)
+	if countErr != nil {
+		return nil, countErr
+	}
+	products, searchErr := s.productRepo.Search(filter, page, pageSize)
+	if searchErr != nil {
+		return nil, searchErr
+	}
+	colorFacets, facetErr := s.productRepo.GetSearchColorFacets(filter)
+	if facetErr != nil {
+		return nil, facetErr
+	}
+	sizeFacets, facetErr := s.productRepo.GetSearchSizeFacets(filter)
+	if facetErr != nil {
+		return nil, facetErr
+	}
+	return &productModel.ProductSearchResult{
+		Products:    products,
+		Total:       total,
+		ColorFacets: colorFacets,
+		SizeFacets:  sizeFacets,
+	}, nil
+}
+
+// filterProductsByPrice asks the price service which products are listed within the price range
+func (s *Service) filterProductsByPrice(minPrice, maxPrice float64) ([]uuid.UUID, err.ApplicationError) {
+	jsonReq, _ := json.Marshal(rpcModel.PriceRangeRequest{
+		MinPrice: minPrice,
+		MaxPrice: maxPrice,
+	})
+	result := s.rpcService.Req(s.rpcEndpoint.FilterProductsByPrice, string(jsonReq))
+	if result == "" {
+		s.logger.Error().Msg("Price service couldn't filter the products by price")
+		return nil, err.CommonError()
+	}
+	var ids []string
+	if unmarshalErr := json.Unmarshal([]byte(result), &ids); unmarshalErr != nil {
+		s.logger.Error().Err(unmarshalErr).Msg("Couldn't unmarshal product ids")
+		return nil, err.CommonError()
+	}
+	productIds := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		productId, parseErr := uuid.Parse(id)
+		if parseErr != nil {
+			s.logger.Error().Err(parseErr).Msg("")
+			continue
+		}
+		productIds = append(productIds, productId)
+	}
+	return productIds, nil
 }
 
 func (s *Service) GetProducts(page int, pageSize int) (count int, products []entity.Product) {
@@ -157,9 +215,8 @@ func (s *Service) upload(product entity.Product, key string, buffer *bytes.Buffe
 		s.logger.Error().Err(createReqErr).Msg("Error occurred When creating uploading request")
 		return "", err.CommonError()
 	}
-	client := http.Client{Timeout: 30 * time.Second}
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	res, doErr := client.Do(req)
+	res, doErr := s.httpClient.Do(req)
 	if doErr != nil {
 		s.logger.Error().Err(doErr).Msg("Error occurred when sending request")
 		return "", err.CommonError()
@@ -194,8 +251,8 @@ func (s *Service) UpdateProduct(id uuid.UUID, name, description, sku string, sta
 func (s *Service) DeleteProduct(id uuid.UUID) err.ApplicationError {
 	return err.CommonError()
 }
-func (s *Service) CheckProductQuantity(productId, colorId, sizeId uuid.UUID, requireQuantity int) (bool, err.ApplicationError) {
-	currentQuantity, getQuantityErr := s.inventoryRepo.GetQuantity(productId, sizeId, colorId)
+func (s *Service) CheckProductQuantity(variantId uuid.UUID, requireQuantity int) (bool, err.ApplicationError) {
+	currentQuantity, getQuantityErr := s.inventoryRepo.GetQuantity(variantId)
 	if getQuantityErr != nil {
 		s.logger.Error().Err(getQuantityErr).Msg("")
 		return false, err.CommonError()
@@ -215,8 +272,8 @@ func (s *Service) GetProductByIds(productIds []uuid.UUID) []entity.Product {
 	}
 	return products
 }
-func (s *Service) GetQuantity(productId, sizeId, colorId uuid.UUID) (int, err.ApplicationError) {
-	quantity, getQuantityErr := s.inventoryRepo.GetQuantity(productId, sizeId, colorId)
+func (s *Service) GetQuantity(variantId uuid.UUID) (int, err.ApplicationError) {
+	quantity, getQuantityErr := s.inventoryRepo.GetQuantity(variantId)
 	if getQuantityErr != nil {
 		s.logger.Error().Err(getQuantityErr).Msg("")
 		return 0, err.NewProductError(500, "error occurred", "error occurred", nil)
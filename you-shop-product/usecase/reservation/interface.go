@@ -0,0 +1,30 @@
+package reservation
+
+import (
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/reservation/model"
+	"github.com/google/uuid"
+)
+
+type ReservationUseCase interface {
+	// Reserve holds the stock of every item for the order, either all items are reserved or none
+	Reserve(orderId uuid.UUID, items []model.ReserveItem) (*model.ReserveResult, err.ApplicationError)
+	// Commit deducts the reserved stock of the order and the items without a live reservation,
+	// committing the same order again deducts nothing twice
+	Commit(orderId uuid.UUID, items []model.ReserveItem) err.ApplicationError
+	Release(orderId uuid.UUID) err.ApplicationError
+	ReleaseExpired() err.ApplicationError
+}
+
+type ReservationRepository interface {
+	// Reserve adds the reservations when every variant has enough available quantity,
+	// otherwise it adds nothing and returns the short variants
+	Reserve(reservations []*entity.Reservation) (shortVariantIds []uuid.UUID, e error)
+	// Commit marks the live reservations of the order as committed and deducts them from the inventories,
+	// the quantities they don't cover are deducted directly. When a variant doesn't have enough stock nothing is applied
+	// and the short variants are returned
+	Commit(orderId uuid.UUID, quantities map[uuid.UUID]int) (shortVariantIds []uuid.UUID, e error)
+	Release(orderId uuid.UUID) (int64, error)
+	ExpireReservations() (int64, error)
+}
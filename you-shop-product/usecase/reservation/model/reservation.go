@@ -0,0 +1,19 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReserveItem struct {
+	VariantId uuid.UUID
+	Quantity  int
+}
+
+type ReserveResult struct {
+	IsReserved bool
+	ExpiresAt  time.Time
+	// Variants without enough available quantity, nothing is reserved when it isn't empty
+	ShortVariantIds []uuid.UUID
+}
@@ -0,0 +1,101 @@
+package reservation
+
+import (
+	"time"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/inventory"
+	"github.com/TechwizsonORG/product-service/usecase/reservation/model"
+	"github.com/TechwizsonORG/product-service/util"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+// reservationTtl is how long the stock is held for an unconfirmed order
+const reservationTtl = 30 * time.Minute
+
+type ReservationService struct {
+	logger           zerolog.Logger
+	reservationRepo  ReservationRepository
+	inventoryUseCase inventory.InventoryUseCase
+}
+
+func NewReservationService(logger zerolog.Logger, reservationRepo ReservationRepository, inventoryUseCase inventory.InventoryUseCase) *ReservationService {
+	logger = logger.With().Str("usecase", "reservation").Logger()
+	return &ReservationService{
+		logger:           logger,
+		reservationRepo:  reservationRepo,
+		inventoryUseCase: inventoryUseCase,
+	}
+}
+
+func (r *ReservationService) Reserve(orderId uuid.UUID, items []model.ReserveItem) (*model.ReserveResult, err.ApplicationError) {
+	if orderId == uuid.Nil || len(items) == 0 {
+		return nil, err.NewProductError(400, "invalid reservation", "order id and items are required", nil)
+	}
+	quantities := mergeItems(items)
+	expiresAt := util.GetCurrentUtcTime(7).Add(reservationTtl)
+	reservations := make([]*entity.Reservation, 0, len(quantities))
+	for variantId, quantity := range quantities {
+		if quantity <= 0 {
+			return nil, err.NewProductError(400, "invalid reservation", "quantity must be greater than 0", nil)
+		}
+		reservations = append(reservations, entity.NewReservation(orderId, variantId, quantity, expiresAt))
+	}
+
+	shortVariantIds, reserveErr := r.reservationRepo.Reserve(reservations)
+	if reserveErr != nil {
+		r.logger.Error().Err(reserveErr).Msg("")
+		return nil, err.NewProductError(500, "reserving inventory failed", "reserving inventory failed", nil)
+	}
+	if len(shortVariantIds) > 0 {
+		return &model.ReserveResult{IsReserved: false, ShortVariantIds: shortVariantIds}, nil
+	}
+	r.logger.Debug().Msgf("Reserved %d variants for order %s", len(reservations), orderId)
+	return &model.ReserveResult{IsReserved: true, ExpiresAt: expiresAt}, nil
+}
+
+func (r *ReservationService) Commit(orderId uuid.UUID, items []model.ReserveItem) err.ApplicationError {
+	shortVariantIds, commitErr := r.reservationRepo.Commit(orderId, mergeItems(items))
+	if commitErr != nil {
+		r.logger.Error().Err(commitErr).Msg("")
+		return err.NewProductError(500, "committing reservation failed", "committing reservation failed", nil)
+	}
+	if len(shortVariantIds) > 0 {
+		r.logger.Error().Msgf("Order %s was confirmed without enough stock of variants %v, nothing was deducted", orderId, shortVariantIds)
+		return err.NewProductError(409, "not enough quantity", "some variants don't have enough quantity", shortVariantIds)
+	}
+	return nil
+}
+
+func (r *ReservationService) Release(orderId uuid.UUID) err.ApplicationError {
+	released, releaseErr := r.reservationRepo.Release(orderId)
+	if releaseErr != nil {
+		r.logger.Error().Err(releaseErr).Msg("")
+		return err.NewProductError(500, "releasing reservation failed", "releasing reservation failed", nil)
+	}
+	r.logger.Debug().Msgf("Released %d reservations of order %s", released, orderId)
+	return nil
+}
+
+func (r *ReservationService) ReleaseExpired() err.ApplicationError {
+	expired, expireErr := r.reservationRepo.ExpireReservations()
+	if expireErr != nil {
+		r.logger.Error().Err(expireErr).Msg("")
+		return err.CommonError()
+	}
+	if expired > 0 {
+		r.logger.Info().Msgf("Released %d expired reservations", expired)
+	}
+	return nil
+}
+
+// mergeItems sums the quantities of the same variant
+func mergeItems(items []model.ReserveItem) map[uuid.UUID]int {
+	quantities := make(map[uuid.UUID]int, len(items))
+	for _, item := range items {
+		quantities[item.VariantId] += item.Quantity
+	}
+	return quantities
+}
@@ -3,8 +3,6 @@ package model
 import "github.com/google/uuid"
 
 type CheckProductQuantity struct {
-	ProductId       uuid.UUID `json:"productId"`
-	SizeId          uuid.UUID `json:"sizeId"`
-	ColorId         uuid.UUID `json:"colorId"`
+	VariantId       uuid.UUID `json:"variantId"`
 	RequireQuantity int       `json:"requireQuantity"`
 }
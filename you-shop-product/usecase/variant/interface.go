@@ -0,0 +1,31 @@
+package variant
+
+import (
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/google/uuid"
+)
+
+type VariantUseCase interface {
+	GetVariants(productId uuid.UUID) ([]entity.Variant, err.ApplicationError)
+	GetVariant(productId, variantId uuid.UUID) (*entity.Variant, err.ApplicationError)
+	GetVariantByBarcode(barcode string) (*entity.Variant, err.ApplicationError)
+	// AddVariant creates the variant with its inventory and initial price
+	AddVariant(productId, colorId, sizeId uuid.UUID, sku, barcode string, weight, price float64, quantity int) (*entity.Variant, err.ApplicationError)
+	UpdateVariant(productId, variantId uuid.UUID, sku, barcode string, weight float64, isActive bool) (*entity.Variant, err.ApplicationError)
+	DeleteVariant(productId, variantId uuid.UUID) err.ApplicationError
+	// BackfillVariants gives the stock which existed before variants a variant, it runs at startup
+	BackfillVariants() err.ApplicationError
+}
+
+type VariantRepository interface {
+	GetVariants(productId uuid.UUID) ([]entity.Variant, error)
+	GetVariantById(id uuid.UUID) (*entity.Variant, error)
+	GetVariantByBarcode(barcode string) (*entity.Variant, error)
+	UpdateVariant(*entity.Variant) error
+	DeleteVariant(id uuid.UUID) error
+	IsSkuExisted(sku string, excludeId uuid.UUID) (bool, error)
+	IsBarcodeExisted(barcode string, excludeId uuid.UUID) (bool, error)
+	IsOptionExisted(productId, colorId, sizeId uuid.UUID) (bool, error)
+	BackfillVariants() (int, error)
+}
@@ -0,0 +1,185 @@
+package variant
+
+import (
+	"strings"
+
+	"github.com/TechwizsonORG/product-service/entity"
+	"github.com/TechwizsonORG/product-service/err"
+	"github.com/TechwizsonORG/product-service/usecase/inventory"
+	inventoryModel "github.com/TechwizsonORG/product-service/usecase/inventory/model"
+	"github.com/TechwizsonORG/product-service/usecase/product"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+type VariantService struct {
+	logger           zerolog.Logger
+	variantRepo      VariantRepository
+	productRepo      product.Reader
+	inventoryUseCase inventory.InventoryUseCase
+}
+
+func NewVariantService(logger zerolog.Logger, variantRepo VariantRepository, productRepo product.Reader, inventoryUseCase inventory.InventoryUseCase) *VariantService {
+	logger = logger.With().Str("usecase", "variant").Logger()
+	return &VariantService{
+		logger:           logger,
+		variantRepo:      variantRepo,
+		productRepo:      productRepo,
+		inventoryUseCase: inventoryUseCase,
+	}
+}
+
+func (v *VariantService) GetVariants(productId uuid.UUID) ([]entity.Variant, err.ApplicationError) {
+	if checkErr := v.checkProduct(productId); checkErr != nil {
+		return nil, checkErr
+	}
+	variants, getErr := v.variantRepo.GetVariants(productId)
+	if getErr != nil {
+		v.logger.Error().Err(getErr).Msg("")
+		return nil, err.CommonError()
+	}
+	return variants, nil
+}
+
+func (v *VariantService) GetVariant(productId, variantId uuid.UUID) (*entity.Variant, err.ApplicationError) {
+	variant, getErr := v.variantRepo.GetVariantById(variantId)
+	if getErr != nil {
+		v.logger.Error().Err(getErr).Msg("")
+		return nil, err.CommonError()
+	}
+	if variant == nil || variant.ProductId != productId {
+		return nil, err.NotFoundProductError("variant not found")
+	}
+	return variant, nil
+}
+
+func (v *VariantService) GetVariantByBarcode(barcode string) (*entity.Variant, err.ApplicationError) {
+	variant, getErr := v.variantRepo.GetVariantByBarcode(strings.TrimSpace(barcode))
+	if getErr != nil {
+		v.logger.Error().Err(getErr).Msg("")
+		return nil, err.CommonError()
+	}
+	if variant == nil {
+		return nil, err.NotFoundProductError("variant not found")
+	}
+	return variant, nil
+}
+
+func (v *VariantService) AddVariant(productId, colorId, sizeId uuid.UUID, sku, barcode string, weight, price float64, quantity int) (*entity.Variant, err.ApplicationError) {
+	if checkErr := v.checkProduct(productId); checkErr != nil {
+		return nil, checkErr
+	}
+	if quantity < 0 || price < 0 || weight < 0 {
+		return nil, err.NewProductError(400, "invalid variant", "quantity, price and weight must not be negative", nil)
+	}
+	isExisted, checkErr := v.variantRepo.IsOptionExisted(productId, colorId, sizeId)
+	if checkErr != nil {
+		v.logger.Error().Err(checkErr).Msg("")
+		return nil, err.CommonError()
+	}
+	if isExisted {
+		return nil, err.NewProductError(409, "variant already existed", "the product already has a variant with this color and size", nil)
+	}
+	sku, barcode = strings.TrimSpace(sku), strings.TrimSpace(barcode)
+	if validateErr := v.validateIdentifiers(uuid.Nil, sku, barcode); validateErr != nil {
+		return nil, validateErr
+	}
+
+	variantId := uuid.New()
+	addErr := v.inventoryUseCase.AddInventories([]inventoryModel.CreateInventory{
+		{
+			VariantId: variantId,
+			ProductId: productId,
+			ColorId:   colorId,
+			SizeId:    sizeId,
+			Sku:       sku,
+			Barcode:   barcode,
+			Weight:    weight,
+			Price:     price,
+			Quantity:  quantity,
+		},
+	})
+	if addErr != nil {
+		return nil, addErr
+	}
+	return v.GetVariant(productId, variantId)
+}
+
+func (v *VariantService) UpdateVariant(productId, variantId uuid.UUID, sku, barcode string, weight float64, isActive bool) (*entity.Variant, err.ApplicationError) {
+	variant, getErr := v.GetVariant(productId, variantId)
+	if getErr != nil {
+		return nil, getErr
+	}
+	if weight < 0 {
+		return nil, err.NewProductError(400, "invalid variant", "weight must not be negative", nil)
+	}
+	sku, barcode = strings.TrimSpace(sku), strings.TrimSpace(barcode)
+	if validateErr := v.validateIdentifiers(variantId, sku, barcode); validateErr != nil {
+		return nil, validateErr
+	}
+	variant.Update(sku, barcode, weight, isActive)
+	if updateErr := v.variantRepo.UpdateVariant(variant); updateErr != nil {
+		v.logger.Error().Err(updateErr).Msg("")
+		return nil, err.NewProductError(500, "updating variant failed", "updating variant failed", nil)
+	}
+	return variant, nil
+}
+
+func (v *VariantService) DeleteVariant(productId, variantId uuid.UUID) err.ApplicationError {
+	if _, getErr := v.GetVariant(productId, variantId); getErr != nil {
+		return getErr
+	}
+	if deleteErr := v.variantRepo.DeleteVariant(variantId); deleteErr != nil {
+		v.logger.Error().Err(deleteErr).Msg("")
+		return err.NewProductError(500, "deleting variant failed", "deleting variant failed", nil)
+	}
+	return nil
+}
+
+func (v *VariantService) checkProduct(productId uuid.UUID) err.ApplicationError {
+	isExisted, checkErr := v.productRepo.IsIdExisted(productId)
+	if checkErr != nil {
+		return checkErr
+	}
+	if !isExisted {
+		return err.NotFoundProductErrorWithId(productId.String())
+	}
+	return nil
+}
+
+// validateIdentifiers checks the sku and barcode aren't used by another variant, both are optional
+func (v *VariantService) validateIdentifiers(variantId uuid.UUID, sku, barcode string) err.ApplicationError {
+	if sku != "" {
+		isExisted, checkErr := v.variantRepo.IsSkuExisted(sku, variantId)
+		if checkErr != nil {
+			v.logger.Error().Err(checkErr).Msg("")
+			return err.CommonError()
+		}
+		if isExisted {
+			return err.NewProductError(409, "sku already existed", "sku already existed", nil)
+		}
+	}
+	if barcode != "" {
+		isExisted, checkErr := v.variantRepo.IsBarcodeExisted(barcode, variantId)
+		if checkErr != nil {
+			v.logger.Error().Err(checkErr).Msg("")
+			return err.CommonError()
+		}
+		if isExisted {
+			return err.NewProductError(409, "barcode already existed", "barcode already existed", nil)
+		}
+	}
+	return nil
+}
+
+func (v *VariantService) BackfillVariants() err.ApplicationError {
+	backfilled, backfillErr := v.variantRepo.BackfillVariants()
+	if backfillErr != nil {
+		v.logger.Error().Err(backfillErr).Msg("")
+		return err.CommonError()
+	}
+	if backfilled > 0 {
+		v.logger.Info().Msgf("Backfilled the variants of %d legacy inventories", backfilled)
+	}
+	return nil
+}
@@ -2,7 +2,13 @@ package util
 
 import (
 	"math/rand"
+	"regexp"
 	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
 )
 
 const letterBytes = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
@@ -43,3 +49,29 @@ func ContainsAll(arr1, arr2 []string) bool {
 	}
 	return true
 }
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Convert a name into an url friendly slug, Vietnamese accents are removed.
+//
+// E.g: "Áo thun nữ" becomes "ao-thun-nu"
+func Slugify(value string) string {
+	value = strings.NewReplacer("đ", "d", "Đ", "D").Replace(value)
+	value, _, _ = transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), value)
+	var result strings.Builder
+	isSeparated := true
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			result.WriteRune(r)
+			isSeparated = false
+		} else if !isSeparated {
+			result.WriteRune('-')
+			isSeparated = true
+		}
+	}
+	return strings.TrimSuffix(result.String(), "-")
+}
+
+func IsSlug(value string) bool {
+	return slugPattern.MatchString(value)
+}